@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"maps"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// redactedValue replaces secret config values in admin responses.
+	redactedValue = "***"
+
+	// Signed admin requests carry the unix timestamp and the hex HMAC-SHA256 of
+	// signaturePayload in these headers.
+	headerAdminTimestamp = "X-Admin-Timestamp"
+	headerAdminSignature = "X-Admin-Signature"
+
+	// signatureMaxSkew is how far a signed request's timestamp may be from the server clock.
+	signatureMaxSkew = 5 * time.Minute
+)
+
+// credentialVerifier is a backend whose credentials can be checked with a minimal
+// authenticated call.
+type credentialVerifier interface {
+	VerifyCredentials(ctx context.Context) error
+}
+
+// credentialStatus reports whether a backend accepted its configured credentials.
+type credentialStatus struct {
+	Backend string `json:"backend"`
+	Valid   bool   `json:"valid"`
+	Error   string `json:"error,omitempty"`
+}
+
+// adminHandler serves the operator endpoints under /admin/. Every request must
+// carry the configured ADMIN_TOKEN as a bearer token and, when ADMIN_HMAC_SECRET
+// is set, a fresh request signature. verifiers are the configured backends, keyed by name.
+func adminHandler(cfg *config, verifiers map[string]credentialVerifier) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/config", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, redactConfig(cfg))
+	})
+	mux.HandleFunc("GET /admin/verify_credentials", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string][]credentialStatus{"backends": verifyCredentials(r.Context(), verifiers)})
+	})
+
+	var handler http.Handler = mux
+	if cfg.AdminHMACSecret != "" {
+		handler = requireSignature(cfg.AdminHMACSecret, time.Now, handler)
+	}
+
+	return requireBearerToken(cfg.AdminToken, handler)
+}
+
+// requireSignature rejects requests without a valid HMAC signature or whose timestamp
+// is more than signatureMaxSkew away from now, which stops replay of captured requests.
+func requireSignature(secret string, now func() time.Time, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		timestamp := r.Header.Get(headerAdminTimestamp)
+
+		seconds, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil || now().Sub(time.Unix(seconds, 0)).Abs() > signatureMaxSkew {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+
+			return
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		got, err := hex.DecodeString(r.Header.Get(headerAdminSignature))
+		if err != nil || !hmac.Equal(got, signRequest(secret, timestamp, r, body)) {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// signRequest returns the HMAC-SHA256 of the newline-joined timestamp, method,
+// request URI and body.
+func signRequest(secret, timestamp string, r *http.Request, body []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = io.WriteString(mac, timestamp+"\n"+r.Method+"\n"+r.URL.RequestURI()+"\n")
+	_, _ = mac.Write(body)
+
+	return mac.Sum(nil)
+}
+
+// requireBearerToken rejects requests whose Authorization header doesn't carry token.
+func requireBearerToken(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// verifyCredentials checks every backend's credentials, in backend name order.
+func verifyCredentials(ctx context.Context, verifiers map[string]credentialVerifier) []credentialStatus {
+	statuses := make([]credentialStatus, 0, len(verifiers))
+
+	for _, name := range slices.Sorted(maps.Keys(verifiers)) {
+		status := credentialStatus{Backend: name, Valid: true, Error: ""}
+
+		err := verifiers[name].VerifyCredentials(ctx)
+		if err != nil {
+			status.Valid = false
+			status.Error = err.Error()
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses
+}
+
+// redactConfig returns the effective configuration keyed by environment variable name.
+// Fields tagged `redact:"true"` are masked when set, so the output is safe to share.
+func redactConfig(cfg *config) map[string]any {
+	value := reflect.ValueOf(*cfg)
+	fields := value.Type()
+	out := make(map[string]any, fields.NumField())
+
+	for i := range fields.NumField() {
+		field := fields.Field(i)
+
+		name := field.Tag.Get("env")
+		if name == "" {
+			continue
+		}
+
+		fieldValue := value.Field(i)
+
+		if field.Tag.Get("redact") == "true" && !fieldValue.IsZero() {
+			out[name] = redactedValue
+
+			continue
+		}
+
+		out[name] = fieldValue.Interface()
+	}
+
+	return out
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+
+	err := json.NewEncoder(w).Encode(v)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,375 @@
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func newAdminTestConfig() *config {
+	return &config{
+		LogLevel:                       "info",
+		LogFormat:                      "production",
+		LogEncoding:                    "",
+		LogOutput:                      "stderr",
+		LogSampling:                    true,
+		Transport:                      "http",
+		NamecheapAPIUser:               "api-user",
+		NamecheapAPIKey:                "super-secret-key",
+		NamecheapUserName:              "username",
+		NamecheapClientIP:              "127.0.0.1",
+		NamecheapIPEchoURL:             "",
+		NamecheapEndpoint:              "https://api.namecheap.com/xml.response",
+		NamecheapSandbox:               false,
+		AdminToken:                     "admin-token",
+		SuggestTLDs:                    nil,
+		AlternativeTLDs:                nil,
+		VariantsMax:                    0,
+		PriceHistoryFile:               "",
+		JobOutputDir:                   "",
+		JobMaxRunning:                  0,
+		JobMaxDomains:                  0,
+		JobRetention:                   0,
+		Watchlist:                      nil,
+		WatchlistInterval:              0,
+		MetricsEnabled:                 false,
+		MockProvider:                   false,
+		DebugRawResults:                false,
+		IncludeRawResponse:             false,
+		AdminHMACSecret:                "",
+		NamecheapRegisterURLTemplate:   "",
+		MaxRetries:                     2,
+		HTTPUserAgent:                  "",
+		NamecheapMaxRetries:            nil,
+		MaxConcurrentRequests:          0,
+		BackendRateLimits:              nil,
+		BackendMaxConcurrency:          nil,
+		BackendMaxRetries:              nil,
+		BackendTimeouts:                nil,
+		ServerTimeout:                  time.Minute * 3,
+		RequestTimeout:                 0,
+		CheckTimeout:                   0,
+		ToolTimeout:                    0,
+		CompareProviderTimeout:         0,
+		CompareTimeout:                 0,
+		PorkbunAPIKey:                  "",
+		PorkbunSecretAPIKey:            "",
+		PorkbunEndpoint:                "",
+		PorkbunMaxRetries:              nil,
+		WhoisServers:                   nil,
+		FallbackProviders:              nil,
+		WebcomAPIKey:                   "",
+		WebcomEndpoint:                 "",
+		WebcomMaxRetries:               nil,
+		GoDaddyAPIKey:                  "",
+		GoDaddyAPISecret:               "",
+		GoDaddyEndpoint:                "",
+		GoDaddyMaxRetries:              nil,
+		GoDaddyMaxDomainsPerCheck:      0,
+		CloudflareAPIToken:             "",
+		CloudflareAccountID:            "",
+		CloudflareEndpoint:             "",
+		CloudflareMaxRetries:           nil,
+		GandiAPIKey:                    "",
+		GandiAuthScheme:                "",
+		GandiEndpoint:                  "",
+		GandiMaxRetries:                nil,
+		DynadotAPIKey:                  "",
+		DynadotEndpoint:                "",
+		DynadotMaxRetries:              nil,
+		DynadotMaxDomainsPerCheck:      0,
+		Route53Region:                  "",
+		Route53Endpoint:                "",
+		Route53MaxRetries:              nil,
+		CacheTTL:                       0,
+		CacheErrorTTL:                  0,
+		NamecheapRateLimitPerSecond:    0,
+		NamecheapRateLimitBurst:        0,
+		NamecheapStrictXML:             false,
+		NamecheapDecimalSeparator:      "",
+		NamecheapThousandsSeparator:    "",
+		PorkbunDecimalSeparator:        "",
+		PorkbunThousandsSeparator:      "",
+		BatchRetry:                     false,
+		BatchRetryDelay:                0,
+		ListenAddr:                     "",
+		ReadTimeout:                    0,
+		WriteTimeout:                   0,
+		IdleTimeout:                    0,
+		NamecheapAvailableErrorNumbers: nil,
+		NamecheapMaxDomainsPerCheck:    0,
+		NamecheapStandardPricing:       false,
+		CORSAllowedOrigins:             nil,
+		CORSAllowedMethods:             nil,
+		CORSAllowedHeaders:             nil,
+		ReferencePrices:                nil,
+		PriceCurrency:                  "",
+		ExchangeRates:                  nil,
+		ExchangeRatesLive:              false,
+		ExchangeRatesURL:               "",
+		DNSPrecheck:                    false,
+		OmitLegacyAvailable:            false,
+	}
+}
+
+func TestAdminHandler_Auth(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantStatus    int
+	}{
+		{name: "missing token", authorization: "", wantStatus: http.StatusUnauthorized},
+		{name: "wrong token", authorization: "Bearer nope", wantStatus: http.StatusUnauthorized},
+		{name: "wrong scheme", authorization: "Basic admin-token", wantStatus: http.StatusUnauthorized},
+		{name: "valid token", authorization: "Bearer admin-token", wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/admin/config", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+
+			rec := httptest.NewRecorder()
+
+			adminHandler(newAdminTestConfig(), nil).ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status code = %v, want %v", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestAdminHandler_ConfigIsRedacted(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/admin/config", nil)
+	req.Header.Set("Authorization", "Bearer admin-token")
+
+	rec := httptest.NewRecorder()
+
+	adminHandler(newAdminTestConfig(), nil).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status code = %v, want %v", rec.Code, http.StatusOK)
+	}
+
+	var got map[string]any
+
+	err := json.Unmarshal(rec.Body.Bytes(), &got)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	for _, key := range []string{"NAMECHEAP_API_KEY", "NAMECHEAP_API_USER", "NAMECHEAP_CLIENT_IP", "ADMIN_TOKEN"} {
+		if got[key] != redactedValue {
+			t.Errorf("%s = %v, want %q", key, got[key], redactedValue)
+		}
+	}
+
+	wantPlain := map[string]string{
+		"LOG_LEVEL":          "info",
+		"TRANSPORT":          "http",
+		"NAMECHEAP_USERNAME": "username",
+	}
+
+	for key, want := range wantPlain {
+		if got[key] != want {
+			t.Errorf("%s = %v, want %q", key, got[key], want)
+		}
+	}
+}
+
+func TestNewHTTPHandler_AdminDisabledWithoutToken(t *testing.T) {
+	t.Parallel()
+
+	cfg := newAdminTestConfig()
+	cfg.AdminToken = ""
+
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/admin/config", nil)
+	req.Header.Set("Authorization", "Bearer ")
+
+	rec := httptest.NewRecorder()
+
+	mcpServer := mcp.NewServer(&mcp.Implementation{Name: "test", Title: "", Version: "", WebsiteURL: "", Icons: nil}, nil)
+
+	newHTTPHandler(mcpServer, cfg, nil).ServeHTTP(rec, req)
+
+	if rec.Code == http.StatusOK {
+		t.Errorf("status code = %v, want admin endpoint to be unavailable", rec.Code)
+	}
+}
+
+func TestNewHTTPHandler_Metrics(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		enabled     bool
+		wantMetrics bool
+	}{
+		{name: "enabled", enabled: true, wantMetrics: true},
+		{name: "disabled by default", enabled: false, wantMetrics: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := newAdminTestConfig()
+			cfg.MetricsEnabled = tt.enabled
+
+			req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/metrics", nil)
+			rec := httptest.NewRecorder()
+
+			mcpServer := mcp.NewServer(&mcp.Implementation{Name: "test", Title: "", Version: "", WebsiteURL: "", Icons: nil}, nil)
+
+			newHTTPHandler(mcpServer, cfg, nil).ServeHTTP(rec, req)
+
+			// Without the endpoint the request falls through to the MCP handler.
+			served := rec.Code == http.StatusOK && strings.Contains(rec.Body.String(), "go_goroutines")
+			if served != tt.wantMetrics {
+				t.Errorf("metrics served = %v (status %v), want %v", served, rec.Code, tt.wantMetrics)
+			}
+		})
+	}
+}
+
+func TestRequireSignature(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1_700_000_000, 0)
+
+	sign := func(secret string, at time.Time, method, uri, body string) (string, string) {
+		timestamp := strconv.FormatInt(at.Unix(), 10)
+
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(timestamp + "\n" + method + "\n" + uri + "\n" + body))
+
+		return timestamp, hex.EncodeToString(mac.Sum(nil))
+	}
+
+	tests := []struct {
+		name       string
+		secret     string
+		signedAt   time.Time
+		signedURI  string
+		wantStatus int
+	}{
+		{name: "valid signature", secret: "hmac-secret", signedAt: now, signedURI: "/admin/config",
+			wantStatus: http.StatusOK},
+		{name: "slightly skewed clock", secret: "hmac-secret", signedAt: now.Add(-time.Minute),
+			signedURI: "/admin/config", wantStatus: http.StatusOK},
+		{name: "stale timestamp", secret: "hmac-secret", signedAt: now.Add(-10 * time.Minute),
+			signedURI: "/admin/config", wantStatus: http.StatusUnauthorized},
+		{name: "wrong secret", secret: "other-secret", signedAt: now, signedURI: "/admin/config",
+			wantStatus: http.StatusUnauthorized},
+		{name: "tampered path", secret: "hmac-secret", signedAt: now, signedURI: "/admin/other",
+			wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			timestamp, signature := sign(tt.secret, tt.signedAt, http.MethodPost, tt.signedURI, "{}")
+
+			req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/admin/config",
+				strings.NewReader("{}"))
+			req.Header.Set(headerAdminTimestamp, timestamp)
+			req.Header.Set(headerAdminSignature, signature)
+
+			rec := httptest.NewRecorder()
+
+			handler := requireSignature("hmac-secret", func() time.Time { return now },
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				}))
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status code = %v, want %v", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestAdminHandler_SignatureRequiredWhenConfigured(t *testing.T) {
+	t.Parallel()
+
+	cfg := newAdminTestConfig()
+	cfg.AdminHMACSecret = "hmac-secret"
+
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/admin/config", nil)
+	req.Header.Set("Authorization", "Bearer admin-token")
+
+	rec := httptest.NewRecorder()
+
+	adminHandler(cfg, nil).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status code = %v, want %v for an unsigned request", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+// fakeVerifier implements credentialVerifier with a fixed outcome.
+type fakeVerifier struct {
+	err error
+}
+
+func (f fakeVerifier) VerifyCredentials(_ context.Context) error {
+	return f.err
+}
+
+func TestAdminHandler_VerifyCredentials(t *testing.T) {
+	t.Parallel()
+
+	verifiers := map[string]credentialVerifier{
+		"namecheap": fakeVerifier{err: nil},
+		"porkbun":   fakeVerifier{err: errors.New("API key is invalid")},
+	}
+
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/admin/verify_credentials", nil)
+	req.Header.Set("Authorization", "Bearer admin-token")
+
+	rec := httptest.NewRecorder()
+
+	adminHandler(newAdminTestConfig(), verifiers).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status code = %v, want %v", rec.Code, http.StatusOK)
+	}
+
+	var got map[string][]credentialStatus
+
+	err := json.Unmarshal(rec.Body.Bytes(), &got)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	want := []credentialStatus{
+		{Backend: "namecheap", Valid: true, Error: ""},
+		{Backend: "porkbun", Valid: false, Error: "API key is invalid"},
+	}
+	if !reflect.DeepEqual(got["backends"], want) {
+		t.Errorf("backends = %+v, want %+v", got["backends"], want)
+	}
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"go.uber.org/zap"
+)
+
+const (
+	formatJSON  = "json"
+	formatTable = "table"
+
+	// stdinPath is the -file value that reads the domain list from stdin.
+	stdinPath = "-"
+)
+
+var (
+	errInvalidFormat       = errors.New("invalid format")
+	errNoDomains           = errors.New("no domains to check")
+	errNamecheapNotEnabled = errors.New("namecheap credentials are not configured")
+)
+
+// resolveFormat validates the -format flag. Only "json" and "table" are accepted.
+func resolveFormat(format string) (string, error) {
+	switch format {
+	case formatJSON, formatTable:
+		return format, nil
+	default:
+		return "", fmt.Errorf("%w %q (must be %q or %q)", errInvalidFormat, format, formatJSON, formatTable)
+	}
+}
+
+// openDomainList opens the -file argument, "-" being stdin.
+func openDomainList(path string) (io.ReadCloser, error) {
+	if path == stdinPath {
+		return io.NopCloser(os.Stdin), nil
+	}
+
+	file, err := os.Open(path) //nolint:gosec // the path is the operator's own -file flag
+	if err != nil {
+		return nil, fmt.Errorf("failed to open domain list: %w", err)
+	}
+
+	return file, nil
+}
+
+// readDomains reads one domain per line, normalizing pasted URLs to their host and
+// skipping blank lines and # comments.
+func readDomains(in io.Reader) ([]string, error) {
+	var domains []string
+
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		domains = append(domains, namecheap.NormalizeDomain(line))
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read domain list: %w", err)
+	}
+
+	return domains, nil
+}
+
+// runCheckFile checks every domain listed in in and writes the results to out in format.
+// The checker splits long lists into batches itself.
+func runCheckFile(ctx context.Context, checker namecheap.DomainChecker, in io.Reader, out io.Writer,
+	format string,
+) error {
+	domains, err := readDomains(in)
+	if err != nil {
+		return err
+	}
+
+	if len(domains) == 0 {
+		return errNoDomains
+	}
+
+	results, err := checker.DomainsCheck(ctx, domains)
+	if err != nil && !namecheap.IsPartial(err) {
+		return fmt.Errorf("failed to check domains: %w", err)
+	}
+
+	return writeResults(out, results, format)
+}
+
+// writeResults writes results as indented JSON or as an aligned table.
+func writeResults(out io.Writer, results []namecheap.Result, format string) error {
+	if format == formatJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+
+		err := encoder.Encode(results)
+		if err != nil {
+			return fmt.Errorf("failed to write results: %w", err)
+		}
+
+		return nil
+	}
+
+	table := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0) //nolint:mnd
+
+	_, _ = fmt.Fprintln(table, "DOMAIN\tAVAILABILITY\tPREMIUM\tPRICE\tCURRENCY\tERROR")
+
+	for _, result := range results {
+		price := ""
+		if result.IsPremiumName {
+			price = strconv.FormatFloat(result.PremiumRegistrationPrice, 'f', 2, 64) //nolint:mnd
+		}
+
+		_, _ = fmt.Fprintf(table, "%s\t%s\t%t\t%s\t%s\t%s\n", result.Domain, result.Availability,
+			result.IsPremiumName, price, result.Currency, result.Error)
+	}
+
+	err := table.Flush()
+	if err != nil {
+		return fmt.Errorf("failed to write results: %w", err)
+	}
+
+	return nil
+}
+
+// checkFile runs the -file mode: it checks the listed domains with the Namecheap
+// configuration from the environment, decorated like the server's checker, and prints
+// the results to stdout.
+func checkFile(ctx context.Context, logger *zap.Logger, cfg *config, path, format string) error {
+	requestTimeout, checkTimeout := resolveTimeouts(logger, cfg)
+	namecheapConfig, namecheapLimits := newNamecheapConfig(cfg, requestTimeout, checkTimeout)
+
+	if namecheapConfig.APIUser == "" || namecheapConfig.APIKey == "" ||
+		namecheapConfig.UserName == "" || namecheapConfig.ClientIP == "" {
+		return errNamecheapNotEnabled
+	}
+
+	service, err := namecheap.NewService(logger, namecheapConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create Namecheap service: %w", err)
+	}
+
+	in, err := openDomainList(path)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = in.Close()
+	}()
+
+	return runCheckFile(ctx, decorate(logger, cfg, newExchangeRates(logger, cfg), withLimits(service, namecheapLimits)), in, os.Stdout, format)
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap/namecheaptest"
+)
+
+func TestResolveFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		format  string
+		wantErr bool
+	}{
+		{name: "json", format: "json", wantErr: false},
+		{name: "table", format: "table", wantErr: false},
+		{name: "empty", format: "", wantErr: true},
+		{name: "unknown", format: "csv", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := resolveFormat(tt.format)
+			if tt.wantErr {
+				if !errors.Is(err, errInvalidFormat) {
+					t.Errorf("resolveFormat(%q) error = %v, want %v", tt.format, err, errInvalidFormat)
+				}
+
+				return
+			}
+
+			if err != nil || got != tt.format {
+				t.Errorf("resolveFormat(%q) = %q, %v, want %q, nil", tt.format, got, err, tt.format)
+			}
+		})
+	}
+}
+
+func TestRunCheckFile(t *testing.T) {
+	t.Parallel()
+
+	input := "# portfolio\nexample.com\n\n  https://Example.org/path  \n"
+
+	tests := []struct {
+		name   string
+		format string
+		check  func(t *testing.T, out string)
+	}{
+		{
+			name:   "json",
+			format: formatJSON,
+			check: func(t *testing.T, out string) {
+				t.Helper()
+
+				var results []namecheap.Result
+
+				err := json.Unmarshal([]byte(out), &results)
+				if err != nil {
+					t.Fatalf("output is not JSON: %v\n%s", err, out)
+				}
+
+				if len(results) != 2 || results[0].Availability != namecheap.AvailabilityTaken ||
+					results[1].Availability != namecheap.AvailabilityAvailable {
+					t.Errorf("results = %+v, want example.com taken and example.org available", results)
+				}
+			},
+		},
+		{
+			name:   "table",
+			format: formatTable,
+			check: func(t *testing.T, out string) {
+				t.Helper()
+
+				lines := strings.Split(strings.TrimSpace(out), "\n")
+				if len(lines) != 3 {
+					t.Fatalf("got %d lines, want a header and 2 rows:\n%s", len(lines), out)
+				}
+
+				if !strings.HasPrefix(lines[0], "DOMAIN") {
+					t.Errorf("header = %q, want it to start with DOMAIN", lines[0])
+				}
+
+				if fields := strings.Fields(lines[1]); fields[0] != "example.com" || fields[1] != "taken" {
+					t.Errorf("row = %q, want example.com taken", lines[1])
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			checker := &namecheaptest.Checker{Answers: map[string]namecheap.Result{ //nolint:exhaustruct
+				"example.com": namecheaptest.Taken("example.com"),
+			}}
+
+			var out bytes.Buffer
+
+			err := runCheckFile(context.Background(), checker, strings.NewReader(input), &out, tt.format)
+			if err != nil {
+				t.Fatalf("runCheckFile() unexpected error: %v", err)
+			}
+
+			wantChecked := []string{"example.com", "example.org"}
+			if !reflect.DeepEqual(checker.Checked(), wantChecked) {
+				t.Errorf("checked = %v, want %v", checker.Checked(), wantChecked)
+			}
+
+			tt.check(t, out.String())
+		})
+	}
+}
+
+func TestRunCheckFile_NoDomains(t *testing.T) {
+	t.Parallel()
+
+	checker := &namecheaptest.Checker{} //nolint:exhaustruct
+
+	err := runCheckFile(context.Background(), checker, strings.NewReader("# nothing\n\n"), &bytes.Buffer{}, formatJSON)
+	if !errors.Is(err, errNoDomains) {
+		t.Errorf("runCheckFile() error = %v, want %v", err, errNoDomains)
+	}
+}
@@ -1,26 +1,133 @@
 package main
 
 import (
+	"fmt"
+	"os"
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
 type config struct {
-	LogLevel          string `env:"LOG_LEVEL" envDefault:"info"`
-	LogFormat         string `env:"LOG_FORMAT" envDefault:"production"`
-	Transport         string `env:"TRANSPORT" envDefault:"http"`
-	NamecheapAPIUser  string `env:"NAMECHEAP_API_USER"`
-	NamecheapAPIKey   string `env:"NAMECHEAP_API_KEY"`
-	NamecheapUserName string `env:"NAMECHEAP_USERNAME"`
-	NamecheapClientIP string `env:"NAMECHEAP_CLIENT_IP"`
-	NamecheapEndpoint string `env:"NAMECHEAP_ENDPOINT" envDefault:"https://api.namecheap.com/xml.response"`
+	LogLevel                       string                   `env:"LOG_LEVEL" envDefault:"info"`
+	LogFormat                      string                   `env:"LOG_FORMAT" envDefault:"production"`
+	LogEncoding                    string                   `env:"LOG_ENCODING"`
+	LogOutput                      string                   `env:"LOG_OUTPUT" envDefault:"stderr"`
+	LogSampling                    bool                     `env:"LOG_SAMPLING" envDefault:"true"`
+	Transport                      string                   `env:"TRANSPORT" envDefault:"http"`
+	ListenAddr                     string                   `env:"LISTEN_ADDR" envDefault:":8080"`
+	ServerTimeout                  time.Duration            `env:"SERVER_TIMEOUT" envDefault:"3m"`
+	ReadTimeout                    time.Duration            `env:"READ_TIMEOUT"`
+	WriteTimeout                   time.Duration            `env:"WRITE_TIMEOUT"`
+	IdleTimeout                    time.Duration            `env:"IDLE_TIMEOUT"`
+	CORSAllowedOrigins             []string                 `env:"CORS_ALLOWED_ORIGINS"`
+	CORSAllowedMethods             []string                 `env:"CORS_ALLOWED_METHODS"`
+	CORSAllowedHeaders             []string                 `env:"CORS_ALLOWED_HEADERS"`
+	RequestTimeout                 time.Duration            `env:"REQUEST_TIMEOUT"`
+	CheckTimeout                   time.Duration            `env:"CHECK_TIMEOUT"`
+	ToolTimeout                    time.Duration            `env:"TOOL_TIMEOUT" envDefault:"60s"`
+	CompareProviderTimeout         time.Duration            `env:"COMPARE_PROVIDER_TIMEOUT" envDefault:"30s"`
+	CompareTimeout                 time.Duration            `env:"COMPARE_TIMEOUT" envDefault:"45s"`
+	MaxRetries                     int                      `env:"MAX_RETRIES" envDefault:"2"`
+	HTTPUserAgent                  string                   `env:"HTTP_USER_AGENT"`
+	BatchRetry                     bool                     `env:"BATCH_RETRY" envDefault:"false"`
+	BatchRetryDelay                time.Duration            `env:"BATCH_RETRY_DELAY" envDefault:"1s"`
+	MaxConcurrentRequests          int                      `env:"MAX_CONCURRENT_REQUESTS" envDefault:"0"`
+	BackendRateLimits              map[string]float64       `env:"BACKEND_RATE_LIMITS"`
+	BackendMaxConcurrency          map[string]int           `env:"BACKEND_MAX_CONCURRENCY"`
+	BackendMaxRetries              map[string]int           `env:"BACKEND_MAX_RETRIES"`
+	BackendTimeouts                map[string]time.Duration `env:"BACKEND_TIMEOUTS"`
+	CacheTTL                       time.Duration            `env:"CACHE_TTL" envDefault:"5m"`
+	CacheErrorTTL                  time.Duration            `env:"CACHE_ERROR_TTL" envDefault:"30s"`
+	ReferencePrices                map[string]float64       `env:"REFERENCE_PRICES"`
+	PriceCurrency                  string                   `env:"PRICE_CURRENCY"`
+	ExchangeRates                  map[string]float64       `env:"EXCHANGE_RATES"`
+	ExchangeRatesLive              bool                     `env:"EXCHANGE_RATES_LIVE" envDefault:"false"`
+	ExchangeRatesURL               string                   `env:"EXCHANGE_RATES_URL" envDefault:"https://api.frankfurter.app/latest?from=USD" redact:"true"`
+	DNSPrecheck                    bool                     `env:"DNS_PRECHECK" envDefault:"false"`
+	OmitLegacyAvailable            bool                     `env:"OMIT_LEGACY_AVAILABLE" envDefault:"false"`
+	NamecheapAPIUser               string                   `env:"NAMECHEAP_API_USER" redact:"true"`
+	NamecheapAPIKey                string                   `env:"NAMECHEAP_API_KEY" redact:"true"`
+	NamecheapUserName              string                   `env:"NAMECHEAP_USERNAME"`
+	NamecheapClientIP              string                   `env:"NAMECHEAP_CLIENT_IP" redact:"true"`
+	NamecheapIPEchoURL             string                   `env:"NAMECHEAP_IP_ECHO_URL" envDefault:"https://api.ipify.org"`
+	NamecheapEndpoint              string                   `env:"NAMECHEAP_ENDPOINT"`
+	NamecheapSandbox               bool                     `env:"NAMECHEAP_SANDBOX" envDefault:"false"`
+	NamecheapRegisterURLTemplate   string                   `env:"NAMECHEAP_REGISTER_URL_TEMPLATE" envDefault:"https://www.namecheap.com/domains/registration/results/?domain={domain}"`
+	NamecheapMaxRetries            *int                     `env:"NAMECHEAP_MAX_RETRIES"`
+	NamecheapRateLimitPerSecond    float64                  `env:"NAMECHEAP_RATE_LIMIT_PER_SECOND" envDefault:"0"`
+	NamecheapRateLimitBurst        int                      `env:"NAMECHEAP_RATE_LIMIT_BURST" envDefault:"1"`
+	NamecheapStrictXML             bool                     `env:"NAMECHEAP_STRICT_XML" envDefault:"false"`
+	NamecheapDecimalSeparator      string                   `env:"NAMECHEAP_DECIMAL_SEPARATOR"`
+	NamecheapThousandsSeparator    string                   `env:"NAMECHEAP_THOUSANDS_SEPARATOR"`
+	NamecheapAvailableErrorNumbers []string                 `env:"NAMECHEAP_AVAILABLE_ERROR_NUMBERS"`
+	NamecheapMaxDomainsPerCheck    int                      `env:"NAMECHEAP_MAX_DOMAINS_PER_CHECK" envDefault:"50"`
+	NamecheapStandardPricing       bool                     `env:"NAMECHEAP_STANDARD_PRICING" envDefault:"false"`
+	PorkbunAPIKey                  string                   `env:"PORKBUN_API_KEY" redact:"true"`
+	PorkbunSecretAPIKey            string                   `env:"PORKBUN_SECRET_API_KEY" redact:"true"`
+	PorkbunEndpoint                string                   `env:"PORKBUN_ENDPOINT" envDefault:"https://api.porkbun.com/api/json/v3"`
+	PorkbunMaxRetries              *int                     `env:"PORKBUN_MAX_RETRIES"`
+	PorkbunDecimalSeparator        string                   `env:"PORKBUN_DECIMAL_SEPARATOR"`
+	PorkbunThousandsSeparator      string                   `env:"PORKBUN_THOUSANDS_SEPARATOR"`
+	WebcomAPIKey                   string                   `env:"WEBCOM_API_KEY" redact:"true"`
+	WebcomEndpoint                 string                   `env:"WEBCOM_ENDPOINT"`
+	WebcomMaxRetries               *int                     `env:"WEBCOM_MAX_RETRIES"`
+	GoDaddyAPIKey                  string                   `env:"GODADDY_API_KEY" redact:"true"`
+	GoDaddyAPISecret               string                   `env:"GODADDY_API_SECRET" redact:"true"`
+	GoDaddyEndpoint                string                   `env:"GODADDY_ENDPOINT" envDefault:"https://api.godaddy.com"`
+	GoDaddyMaxRetries              *int                     `env:"GODADDY_MAX_RETRIES"`
+	GoDaddyMaxDomainsPerCheck      int                      `env:"GODADDY_MAX_DOMAINS_PER_CHECK" envDefault:"500"`
+	CloudflareAPIToken             string                   `env:"CLOUDFLARE_API_TOKEN" redact:"true"`
+	CloudflareAccountID            string                   `env:"CLOUDFLARE_ACCOUNT_ID"`
+	CloudflareEndpoint             string                   `env:"CLOUDFLARE_ENDPOINT" envDefault:"https://api.cloudflare.com/client/v4"`
+	CloudflareMaxRetries           *int                     `env:"CLOUDFLARE_MAX_RETRIES"`
+	GandiAPIKey                    string                   `env:"GANDI_API_KEY" redact:"true"`
+	GandiAuthScheme                string                   `env:"GANDI_AUTH_SCHEME" envDefault:"apikey"`
+	GandiEndpoint                  string                   `env:"GANDI_ENDPOINT" envDefault:"https://api.gandi.net"`
+	GandiMaxRetries                *int                     `env:"GANDI_MAX_RETRIES"`
+	DynadotAPIKey                  string                   `env:"DYNADOT_API_KEY" redact:"true"`
+	DynadotEndpoint                string                   `env:"DYNADOT_ENDPOINT" envDefault:"https://api.dynadot.com/api3.xml"`
+	DynadotMaxRetries              *int                     `env:"DYNADOT_MAX_RETRIES"`
+	DynadotMaxDomainsPerCheck      int                      `env:"DYNADOT_MAX_DOMAINS_PER_CHECK" envDefault:"100"`
+	Route53Region                  string                   `env:"ROUTE53_REGION"`
+	Route53Endpoint                string                   `env:"ROUTE53_ENDPOINT"`
+	Route53MaxRetries              *int                     `env:"ROUTE53_MAX_RETRIES"`
+	WhoisServers                   map[string]string        `env:"WHOIS_SERVERS"`
+	FallbackProviders              []string                 `env:"FALLBACK_PROVIDERS"`
+	AdminToken                     string                   `env:"ADMIN_TOKEN" redact:"true"`
+	SuggestTLDs                    []string                 `env:"SUGGEST_TLDS" envDefault:"com,net,org,io,co"`
+	AlternativeTLDs                []string                 `env:"ALTERNATIVE_TLDS" envDefault:"com,net,org,io,co,ai,app,dev,me,xyz"`
+	VariantsMax                    int                      `env:"VARIANTS_MAX" envDefault:"100"`
+	PriceHistoryFile               string                   `env:"PRICE_HISTORY_FILE"`
+	JobOutputDir                   string                   `env:"JOB_OUTPUT_DIR"`
+	JobMaxRunning                  int                      `env:"JOB_MAX_RUNNING" envDefault:"4"`
+	JobMaxDomains                  int                      `env:"JOB_MAX_DOMAINS" envDefault:"10000"`
+	JobRetention                   time.Duration            `env:"JOB_RETENTION" envDefault:"24h"`
+	Watchlist                      []string                 `env:"WATCHLIST"`
+	WatchlistInterval              time.Duration            `env:"WATCHLIST_INTERVAL" envDefault:"1h"`
+	MetricsEnabled                 bool                     `env:"METRICS_ENABLED" envDefault:"false"`
+	MockProvider                   bool                     `env:"MOCK_PROVIDER" envDefault:"false"`
+	DebugRawResults                bool                     `env:"DEBUG_RAW_RESULTS" envDefault:"false"`
+	IncludeRawResponse             bool                     `env:"INCLUDE_RAW_RESPONSE" envDefault:"false"`
+	AdminHMACSecret                string                   `env:"ADMIN_HMAC_SECRET" redact:"true"`
 }
 
+const (
+	// logFileMode is the permission of a log file created for LOG_OUTPUT.
+	logFileMode = 0o600
+
+	logEncodingJSON    = "json"
+	logEncodingConsole = "console"
+)
+
 // createLogger creates and configures a zap logger based on the provided configuration.
 // It supports different log levels (debug, info, warn, error, fatal, panic) and formats (production, development).
 // The logger defaults to info level and production format if invalid values are provided.
+// LOG_ENCODING picks the json or console encoder independently of the format preset; any
+// other value fails with errInvalidLogEncoding. LOG_OUTPUT sends the logs to stdout,
+// stderr or a file, and LOG_SAMPLING=false keeps every repeated entry.
 func createLogger(cfg *config) (*zap.Logger, error) {
 	logLevel := strings.ToLower(cfg.LogLevel)
 
@@ -51,5 +158,36 @@ func createLogger(cfg *config) (*zap.Logger, error) {
 		loggerConfig.Level = zap.NewAtomicLevelAt(level)
 	}
 
+	// LOG_ENCODING overrides the preset's encoder, e.g. JSON logs at debug level.
+	switch encoding := strings.ToLower(cfg.LogEncoding); encoding {
+	case "":
+	case logEncodingJSON, logEncodingConsole:
+		loggerConfig.Encoding = encoding
+	default:
+		return nil, fmt.Errorf("%w %q: must be %q or %q",
+			errInvalidLogEncoding, cfg.LogEncoding, logEncodingJSON, logEncodingConsole)
+	}
+
+	// Sampling drops repeated entries, which high-traffic deployments may need to keep.
+	if !cfg.LogSampling {
+		loggerConfig.Sampling = nil
+	}
+
+	switch cfg.LogOutput {
+	case "", "stderr":
+	case "stdout":
+		loggerConfig.OutputPaths = []string{"stdout"}
+	default:
+		// Open the file up front so a bad path fails with a clear error.
+		file, err := os.OpenFile(cfg.LogOutput, os.O_WRONLY|os.O_CREATE|os.O_APPEND, logFileMode)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open log output %q: %w", cfg.LogOutput, err)
+		}
+
+		_ = file.Close()
+
+		loggerConfig.OutputPaths = []string{cfg.LogOutput}
+	}
+
 	return loggerConfig.Build()
 }
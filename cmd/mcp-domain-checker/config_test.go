@@ -1,7 +1,14 @@
 package main
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestCreateLogger(t *testing.T) {
@@ -36,14 +43,107 @@ func TestCreateLogger(t *testing.T) {
 			t.Parallel()
 
 			cfg := &config{
-				LogLevel:          tt.logLevel,
-				LogFormat:         tt.logFormat,
-				Transport:         "",
-				NamecheapAPIUser:  "",
-				NamecheapAPIKey:   "",
-				NamecheapUserName: "",
-				NamecheapClientIP: "",
-				NamecheapEndpoint: "",
+				LogLevel:                       tt.logLevel,
+				LogFormat:                      tt.logFormat,
+				LogEncoding:                    "",
+				LogOutput:                      "",
+				LogSampling:                    false,
+				Transport:                      "",
+				NamecheapAPIUser:               "",
+				NamecheapAPIKey:                "",
+				NamecheapUserName:              "",
+				NamecheapClientIP:              "",
+				NamecheapIPEchoURL:             "",
+				NamecheapEndpoint:              "",
+				NamecheapSandbox:               false,
+				AdminToken:                     "",
+				SuggestTLDs:                    nil,
+				AlternativeTLDs:                nil,
+				VariantsMax:                    0,
+				PriceHistoryFile:               "",
+				JobOutputDir:                   "",
+				JobMaxRunning:                  4,
+				JobMaxDomains:                  10000,
+				JobRetention:                   24 * time.Hour,
+				Watchlist:                      nil,
+				WatchlistInterval:              0,
+				MetricsEnabled:                 false,
+				MockProvider:                   false,
+				DebugRawResults:                false,
+				IncludeRawResponse:             false,
+				AdminHMACSecret:                "",
+				NamecheapRegisterURLTemplate:   "",
+				MaxRetries:                     2,
+				HTTPUserAgent:                  "",
+				NamecheapMaxRetries:            nil,
+				MaxConcurrentRequests:          0,
+				BackendRateLimits:              nil,
+				BackendMaxConcurrency:          nil,
+				BackendMaxRetries:              nil,
+				BackendTimeouts:                nil,
+				ServerTimeout:                  time.Minute * 3,
+				RequestTimeout:                 0,
+				CheckTimeout:                   0,
+				ToolTimeout:                    time.Minute,
+				CompareProviderTimeout:         30 * time.Second,
+				CompareTimeout:                 45 * time.Second,
+				PorkbunAPIKey:                  "",
+				PorkbunSecretAPIKey:            "",
+				PorkbunEndpoint:                "",
+				PorkbunMaxRetries:              nil,
+				WhoisServers:                   nil,
+				FallbackProviders:              nil,
+				WebcomAPIKey:                   "",
+				WebcomEndpoint:                 "",
+				WebcomMaxRetries:               nil,
+				GoDaddyAPIKey:                  "",
+				GoDaddyAPISecret:               "",
+				GoDaddyEndpoint:                "https://api.godaddy.com",
+				GoDaddyMaxRetries:              nil,
+				GoDaddyMaxDomainsPerCheck:      500,
+				CloudflareAPIToken:             "",
+				CloudflareAccountID:            "",
+				CloudflareEndpoint:             "",
+				CloudflareMaxRetries:           nil,
+				GandiAPIKey:                    "",
+				GandiAuthScheme:                "apikey",
+				GandiEndpoint:                  "https://api.gandi.net",
+				GandiMaxRetries:                nil,
+				DynadotAPIKey:                  "",
+				DynadotEndpoint:                "https://api.dynadot.com/api3.xml",
+				DynadotMaxRetries:              nil,
+				DynadotMaxDomainsPerCheck:      100,
+				Route53Region:                  "",
+				Route53Endpoint:                "",
+				Route53MaxRetries:              nil,
+				CacheTTL:                       0,
+				CacheErrorTTL:                  0,
+				NamecheapRateLimitPerSecond:    0,
+				NamecheapRateLimitBurst:        0,
+				NamecheapStrictXML:             false,
+				NamecheapDecimalSeparator:      "",
+				NamecheapThousandsSeparator:    "",
+				PorkbunDecimalSeparator:        "",
+				PorkbunThousandsSeparator:      "",
+				BatchRetry:                     false,
+				BatchRetryDelay:                0,
+				ListenAddr:                     "",
+				ReadTimeout:                    0,
+				WriteTimeout:                   0,
+				IdleTimeout:                    0,
+				NamecheapAvailableErrorNumbers: nil,
+				NamecheapMaxDomainsPerCheck:    50,
+				NamecheapStandardPricing:       false,
+				CORSAllowedOrigins:             nil,
+				CORSAllowedMethods:             nil,
+				CORSAllowedHeaders:             nil,
+				ReferencePrices:                nil,
+				PriceCurrency:                  "",
+				ExchangeRates:                  nil,
+				ExchangeRatesLive:              false,
+				ExchangeRatesURL:               "https://api.frankfurter.app/latest?from=USD",
+				DNSPrecheck:                    false,
+				OmitLegacyAvailable:            false,
 			}
 
 			logger, err := createLogger(cfg)
@@ -59,3 +159,113 @@ func TestCreateLogger(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateLogger_Output(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		output  string
+		wantErr bool
+	}{
+		{name: "stdout", output: "stdout", wantErr: false},
+		{name: "stderr", output: "stderr", wantErr: false},
+		{name: "file", output: filepath.Join(dir, "server.log"), wantErr: false},
+		{name: "file in missing directory", output: filepath.Join(dir, "missing", "server.log"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := newAdminTestConfig()
+			cfg.LogOutput = tt.output
+			cfg.LogSampling = false
+
+			logger, err := createLogger(cfg)
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), tt.output) {
+					t.Errorf("createLogger() error = %v, want one naming %q", err, tt.output)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("createLogger() unexpected error: %v", err)
+			}
+
+			if filepath.IsAbs(tt.output) {
+				logger.Info("written to file")
+				_ = logger.Sync()
+
+				data, err := os.ReadFile(tt.output)
+				if err != nil || !strings.Contains(string(data), "written to file") {
+					t.Errorf("log file = %q, %v, want the logged entry", data, err)
+				}
+			}
+		})
+	}
+}
+
+func TestCreateLogger_Encoding(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		logFormat string
+		encoding  string
+		wantJSON  bool
+	}{
+		{name: "production preset", logFormat: "production", encoding: "", wantJSON: true},
+		{name: "development preset", logFormat: "development", encoding: "", wantJSON: false},
+		{name: "json with development preset", logFormat: "development", encoding: "json", wantJSON: true},
+		{name: "console with production preset", logFormat: "production", encoding: "console", wantJSON: false},
+		{name: "case insensitive", logFormat: "development", encoding: "JSON", wantJSON: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			output := filepath.Join(t.TempDir(), "server.log")
+
+			cfg := newAdminTestConfig()
+			cfg.LogLevel = "debug"
+			cfg.LogFormat = tt.logFormat
+			cfg.LogEncoding = tt.encoding
+			cfg.LogOutput = output
+
+			logger, err := createLogger(cfg)
+			if err != nil {
+				t.Fatalf("createLogger() unexpected error: %v", err)
+			}
+
+			logger.Debug("encoded")
+			_ = logger.Sync()
+
+			data, err := os.ReadFile(output)
+			if err != nil {
+				t.Fatalf("failed to read log file: %v", err)
+			}
+
+			if gotJSON := json.Valid(bytes.TrimSpace(data)); gotJSON != tt.wantJSON {
+				t.Errorf("JSON encoded = %v, want %v:\n%s", gotJSON, tt.wantJSON, data)
+			}
+		})
+	}
+}
+
+func TestCreateLogger_InvalidEncoding(t *testing.T) {
+	t.Parallel()
+
+	cfg := newAdminTestConfig()
+	cfg.LogEncoding = "xml"
+
+	_, err := createLogger(cfg)
+	if !errors.Is(err, errInvalidLogEncoding) {
+		t.Errorf("createLogger() error = %v, want %v", err, errInvalidLogEncoding)
+	}
+}
@@ -8,33 +8,87 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
+	"strings"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/caarlos0/env/v11"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/blocklist"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/cache"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/cloudflare"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/compare"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/csvcheck"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/currency"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/dnsprecheck"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/dynadot"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/fallback"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/gandi"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/godaddy"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/jobs"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/limit"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/metrics"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/mock"
 	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/porkbun"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/pricehistory"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/rdap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/refprice"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/requestid"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/route53"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/suggest"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/tldtype"
 	"github.com/jsgv/mcp-domain-checker/internal/pkg/tool"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/tracing"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/variants"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/watchlist"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/webcom"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/whois"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"go.uber.org/zap"
 )
 
 const (
-	addr            = ":8080"
 	serverName      = "com.jsgv.domain-checker"
 	serverTitle     = "Domain Checker"
-	serverTimeout   = time.Minute * 3
 	shutdownTimeout = time.Second * 10
 
+	// defaultIdleTimeout is how long an idle keep-alive connection is kept when IDLE_TIMEOUT is unset.
+	defaultIdleTimeout = 2 * time.Minute
+
+	// Upstream timeouts default to these fractions of SERVER_TIMEOUT, so a whole check,
+	// and each request within it, finishes inside the server's window.
+	requestTimeoutFraction = 1.0 / 6
+	checkTimeoutFraction   = 2.0 / 3
+
+	// defaultCORSMethods and defaultCORSHeaders are sent when CORS_ALLOWED_METHODS and
+	// CORS_ALLOWED_HEADERS are unset.
+	defaultCORSMethods = "GET, POST, DELETE, OPTIONS"
+	defaultCORSHeaders = "Content-Type, Authorization, Mcp-Protocol-Version, Mcp-Session-Id, X-Request-ID"
+
+	// retryAfterSeconds is the Retry-After hint sent when the request limit is reached.
+	retryAfterSeconds = "1"
+
 	transportHTTP  = "http"
 	transportStdio = "stdio"
 )
 
-// errInvalidTransport is returned when the resolved transport value isn't one of
-// the supported options. Declared as a sentinel so callers can errors.Is against it.
-var errInvalidTransport = errors.New("invalid transport")
+var (
+	// errInvalidTransport is returned when the resolved transport value isn't one of
+	// the supported options. Declared as a sentinel so callers can errors.Is against it.
+	errInvalidTransport = errors.New("invalid transport")
+	// errInvalidLogEncoding is returned when LOG_ENCODING is neither json nor console.
+	errInvalidLogEncoding = errors.New("invalid LOG_ENCODING")
+	// errStdoutLogWithStdio is returned when LOG_OUTPUT=stdout is combined with the stdio
+	// transport, whose protocol messages the logs would corrupt.
+	errStdoutLogWithStdio = errors.New("LOG_OUTPUT=stdout can't be used with the stdio transport")
+)
 
 // version and commit are set at build time via -ldflags.
 //
@@ -51,6 +105,10 @@ func main() {
 	transportFlag := flag.String("transport", "",
 		"Transport: http or stdio (overrides TRANSPORT env; default http)")
 
+	fileFlag := flag.String("file", "",
+		"Check the domains listed one per line in this file (- for stdin), print the results and exit")
+	formatFlag := flag.String("format", formatTable, "Output format of -file: json or table")
+
 	flag.Parse()
 
 	if *showVersion {
@@ -71,6 +129,16 @@ func main() {
 		log.Fatal("Error resolving transport: ", err)
 	}
 
+	err = checkLogOutput(transport, cfg.LogOutput)
+	if err != nil {
+		log.Fatal("Error validating log output: ", err)
+	}
+
+	format, err := resolveFormat(*formatFlag)
+	if err != nil {
+		log.Fatal("Error resolving format: ", err)
+	}
+
 	logger, err := createLogger(&cfg)
 	if err != nil {
 		log.Fatal("Error creating logger: ", err)
@@ -79,6 +147,25 @@ func main() {
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
+	err = resolveClientIP(ctx, logger, &cfg)
+	if err != nil {
+		log.Fatal("Error resolving Namecheap client IP: ", err)
+	}
+
+	if *fileFlag != "" {
+		err = checkFile(ctx, logger, &cfg, *fileFlag, format)
+		if err != nil {
+			log.Fatal("Error checking domains: ", err)
+		}
+
+		return
+	}
+
+	shutdownTracing, err := tracing.Setup(ctx, serverName, version)
+	if err != nil {
+		log.Fatal("Error setting up tracing: ", err)
+	}
+
 	mcpServer := mcp.NewServer(&mcp.Implementation{ //nolint:exhaustruct
 		Name:    serverName,
 		Title:   serverTitle,
@@ -87,13 +174,29 @@ func main() {
 		Capabilities: &mcp.ServerCapabilities{}, //nolint:exhaustruct
 	})
 
-	setupTools(mcpServer, logger, &cfg)
+	var background sync.WaitGroup
+
+	verifiers := setupTools(ctx, mcpServer, logger, &cfg, &background)
+	setupPrompts(mcpServer, logger)
 
 	switch transport {
 	case transportStdio:
 		runStdio(ctx, mcpServer, logger)
 	case transportHTTP:
-		runHTTP(ctx, mcpServer, logger)
+		runHTTP(ctx, mcpServer, logger, &cfg, verifiers)
+	}
+
+	// Stop the background work too when the transport ended on its own, e.g. stdin closed.
+	stop()
+	background.Wait()
+
+	// Flush the spans still buffered for export.
+	flushCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	err = shutdownTracing(flushCtx)
+	if err != nil {
+		logger.Warn("Failed to flush traces", zap.Error(err))
 	}
 }
 
@@ -114,36 +217,935 @@ func resolveTransport(flagVal, envVal string) (string, error) {
 	}
 }
 
-func setupTools(mcpServer *mcp.Server, logger *zap.Logger, cfg *config) {
-	// Add Namecheap tool if configuration is provided
-	namecheapConfig := namecheap.Config{
-		APIUser:  cfg.NamecheapAPIUser,
-		APIKey:   cfg.NamecheapAPIKey,
-		UserName: cfg.NamecheapUserName,
-		ClientIP: cfg.NamecheapClientIP,
-		Endpoint: cfg.NamecheapEndpoint,
+// checkLogOutput rejects LOG_OUTPUT=stdout with the stdio transport, as stdout carries
+// the MCP messages there.
+func checkLogOutput(transport, logOutput string) error {
+	if transport == transportStdio && logOutput == "stdout" {
+		return fmt.Errorf("%w; use stderr or a file path", errStdoutLogWithStdio)
 	}
 
+	return nil
+}
+
+// setupTools registers the tools of every configured backend and returns the backends,
+// keyed by name, so the admin endpoints can verify their credentials. Background work,
+// such as the watchlist scheduler and check jobs, runs until ctx is done and is tracked
+// by background.
+func setupTools(
+	ctx context.Context,
+	mcpServer *mcp.Server,
+	logger *zap.Logger,
+	cfg *config,
+	background *sync.WaitGroup,
+) map[string]credentialVerifier {
+	verifiers := make(map[string]credentialVerifier)
+	requestTimeout, checkTimeout := resolveTimeouts(logger, cfg)
+	// providers are the checkers of the configured paid providers, for price comparison.
+	var providers []namecheap.DomainChecker
+	// named are the same checkers keyed by backend name, for FALLBACK_PROVIDERS.
+	named := make(map[string]namecheap.DomainChecker)
+
+	// rates are shared by every provider's price conversion.
+	rates := newExchangeRates(logger, cfg)
+
+	if cfg.PriceCurrency != "" {
+		logger.Info("Price conversion enabled",
+			zap.String("currency", cfg.PriceCurrency),
+			zap.Bool("live_rates", cfg.ExchangeRatesLive),
+		)
+
+		if !cfg.ExchangeRatesLive {
+			_, err := rates.Rate(ctx, namecheap.CurrencyUSD, cfg.PriceCurrency)
+			if err != nil {
+				logger.Warn("PRICE_CURRENCY has no rate in EXCHANGE_RATES; prices stay unconverted", zap.Error(err))
+			}
+		}
+	}
+
+	// Add Namecheap tool if configuration is provided
+	namecheapConfig, namecheapLimits := newNamecheapConfig(cfg, requestTimeout, checkTimeout)
+
 	if namecheapConfig.APIUser != "" && namecheapConfig.APIKey != "" &&
 		namecheapConfig.UserName != "" && namecheapConfig.ClientIP != "" {
 		service, err := namecheap.NewService(logger, namecheapConfig)
 		if err != nil {
 			logger.Warn("Failed to create Namecheap service", zap.Error(err))
 		} else {
-			namecheapTool := tool.NewTool(service)
-			mcp.AddTool(
-				mcpServer,
-				&mcp.Tool{ //nolint:exhaustruct
-					Name:        namecheapTool.Name(),
-					Description: namecheapTool.Description(),
-				},
-				namecheapTool.Handler,
-			)
+			history := newPriceHistoryStore(cfg)
+			// The cache wraps the recorder so cached answers aren't recorded twice.
+			checker := decorate(logger, cfg, rates, pricehistory.NewRecorder(logger, withLimits(service, namecheapLimits),
+				history))
+
+			addTool(mcpServer, logger, cfg, namecheap.NewCheckService(checker, tldtype.Default()))
+			addTool(mcpServer, logger, cfg, namecheap.NewSingleCheckService(checker))
+			addTool(mcpServer, logger, cfg, csvcheck.NewService(checker))
+			addTool(mcpServer, logger, cfg, suggest.NewSynonymService(checker, suggest.DefaultThesaurus(), cfg.SuggestTLDs))
+			addTool(mcpServer, logger, cfg, suggest.NewKeywordService(checker, suggest.NewFrequencyExtractor(), cfg.SuggestTLDs))
+			addTool(mcpServer, logger, cfg, suggest.NewAvailableTLDsService(checker, cfg.SuggestTLDs))
+			portfolio := suggest.NewPortfolioService(checker, service, cfg.SuggestTLDs)
+			if cfg.PriceCurrency != "" {
+				portfolio = portfolio.WithCurrency(rates, cfg.PriceCurrency)
+			}
+
+			addTool(mcpServer, logger, cfg, portfolio)
+			addTool(mcpServer, logger, cfg, suggest.NewAlternativesService(checker, cfg.AlternativeTLDs))
+			addTool(mcpServer, logger, cfg, variants.NewService(checker, cfg.AlternativeTLDs, cfg.VariantsMax))
+			addTool(mcpServer, logger, cfg, pricehistory.NewHistoryService(history))
+			addTool(mcpServer, logger, cfg, namecheap.NewPricingService(service))
+			addTool(mcpServer, logger, cfg, namecheap.NewTLDListService(service))
+
+			if cfg.JobOutputDir != "" {
+				manager := jobs.NewManager(ctx, logger, checker, jobs.Config{
+					OutputDir:  cfg.JobOutputDir,
+					MaxRunning: cfg.JobMaxRunning,
+					MaxDomains: cfg.JobMaxDomains,
+					Retention:  cfg.JobRetention,
+				})
+
+				// Jobs stop with ctx; shutdown waits for them to record their outcome.
+				background.Go(func() {
+					<-ctx.Done()
+					manager.Wait()
+				})
+				addTool(mcpServer, logger, cfg, jobs.NewSubmitService(manager))
+				addTool(mcpServer, logger, cfg, jobs.NewStatusService(manager))
+				addTool(mcpServer, logger, cfg, jobs.NewResultsService(manager))
+			}
+
+			if len(cfg.Watchlist) > 0 {
+				snapshots := watchlist.NewMemoryStore()
+				scheduler := watchlist.NewScheduler(logger, checker, snapshots, watchlist.Config{
+					Domains:  cfg.Watchlist,
+					Interval: cfg.WatchlistInterval,
+					Clock:    nil,
+				})
+
+				background.Go(func() { scheduler.Run(ctx) })
+				addTool(mcpServer, logger, cfg, watchlist.NewHistoryService(snapshots))
+			}
+
+			verifiers["namecheap"] = service
+			providers = append(providers, checker)
+			named["namecheap"] = checker
+
 			logger.Info("Namecheap tool enabled")
 		}
 	} else {
 		logger.Info("Namecheap tool disabled - missing configuration")
 	}
+
+	if checker := setupPorkbun(mcpServer, logger, cfg, requestTimeout, rates, verifiers); checker != nil {
+		providers = append(providers, checker)
+		named["porkbun"] = checker
+	}
+
+	if checker := setupWebcom(mcpServer, logger, cfg, requestTimeout, rates, verifiers); checker != nil {
+		providers = append(providers, checker)
+		named["webcom"] = checker
+	}
+
+	if checker := setupGoDaddy(mcpServer, logger, cfg, requestTimeout, rates, verifiers); checker != nil {
+		providers = append(providers, checker)
+		named["godaddy"] = checker
+	}
+
+	if checker := setupCloudflare(mcpServer, logger, cfg, requestTimeout, rates, verifiers); checker != nil {
+		providers = append(providers, checker)
+		named["cloudflare"] = checker
+	}
+
+	if checker := setupGandi(mcpServer, logger, cfg, requestTimeout, rates, verifiers); checker != nil {
+		providers = append(providers, checker)
+		named["gandi"] = checker
+	}
+
+	if checker := setupDynadot(mcpServer, logger, cfg, requestTimeout, rates, verifiers); checker != nil {
+		providers = append(providers, checker)
+		named["dynadot"] = checker
+	}
+
+	if checker := setupRoute53(ctx, mcpServer, logger, cfg, requestTimeout, rates, verifiers); checker != nil {
+		providers = append(providers, checker)
+		named["route53"] = checker
+	}
+
+	if cfg.MockProvider {
+		checker := decorate(logger, cfg, rates, mock.NewService())
+		addTool(mcpServer, logger, cfg, namecheap.NewCheckService(checker, tldtype.Default()))
+
+		providers = append(providers, checker)
+		named["mock"] = checker
+
+		logger.Warn("Mock tool enabled - its answers are made up, not checked")
+	}
+
+	// With more than one provider, offer comparing their prices.
+	if len(providers) > 1 {
+		service := compare.NewMultiProviderService(providers).
+			WithTimeouts(cfg.CompareProviderTimeout, cfg.CompareTimeout)
+		if cfg.PriceCurrency != "" {
+			service = service.WithCurrency(cfg.PriceCurrency)
+		}
+
+		addTool(mcpServer, logger, cfg, service)
+	}
+
+	// Without any registrar keys, fall back to RDAP so availability checks still work.
+	if len(providers) == 0 {
+		addTool(mcpServer, logger, cfg,
+			namecheap.NewCheckService(newRDAPChecker(logger, cfg, requestTimeout, rates), tldtype.Default()))
+
+		logger.Info("RDAP tool enabled - no paid provider configured")
+	}
+
+	if len(cfg.FallbackProviders) > 0 {
+		setupFallback(mcpServer, logger, cfg, requestTimeout, rates, named)
+	}
+
+	return verifiers
+}
+
+// newRDAPChecker returns the decorated RDAP checker, which asks WHOIS about TLDs without
+// an RDAP server.
+//
+//nolint:ireturn
+func newRDAPChecker(
+	logger *zap.Logger,
+	cfg *config,
+	requestTimeout time.Duration,
+	rates currency.ExchangeRateProvider,
+) namecheap.DomainChecker {
+	limits := limitsFor(cfg, "rdap", nil, requestTimeout)
+	service := rdap.NewService(logger, rdap.Config{
+		BootstrapURL:       "",
+		RequestTimeout:     limits.Timeout,
+		MaxRetries:         limits.MaxRetries,
+		RateLimitPerSecond: limits.RateLimit,
+		Fallback: whois.NewService(logger, whois.Config{
+			Servers:           cfg.WhoisServers,
+			AvailablePatterns: nil,
+			Timeout:           limits.Timeout,
+		}),
+	})
+
+	return decorate(logger, cfg, rates, withLimits(service, limits))
+}
+
+// setupFallback registers the check_availability_fallback tool trying the backends of
+// FALLBACK_PROVIDERS in order. "rdap" is always available; other names must be
+// configured providers in named, and the ones that aren't are skipped with a warning.
+func setupFallback(
+	mcpServer *mcp.Server,
+	logger *zap.Logger,
+	cfg *config,
+	requestTimeout time.Duration,
+	rates currency.ExchangeRateProvider,
+	named map[string]namecheap.DomainChecker,
+) {
+	checkers := make([]namecheap.DomainChecker, 0, len(cfg.FallbackProviders))
+
+	for _, name := range cfg.FallbackProviders {
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		checker, ok := named[name]
+		if name == "rdap" {
+			checker, ok = newRDAPChecker(logger, cfg, requestTimeout, rates), true
+		}
+
+		if !ok {
+			logger.Warn("Skipping fallback provider that isn't configured", zap.String("provider", name))
+
+			continue
+		}
+
+		checkers = append(checkers, checker)
+	}
+
+	checker, err := fallback.NewChecker(logger, checkers)
+	if err != nil {
+		logger.Warn("Fallback tool disabled", zap.Error(err))
+
+		return
+	}
+
+	addTool(mcpServer, logger, cfg, namecheap.NewCheckService(checker, tldtype.Default()))
+
+	logger.Info("Fallback tool enabled", zap.Strings("providers", cfg.FallbackProviders))
+}
+
+// setupPorkbun registers the Porkbun availability tool when its API keys are configured
+// and returns its checker, or nil when it didn't.
+//
+//nolint:ireturn
+func setupPorkbun(
+	mcpServer *mcp.Server,
+	logger *zap.Logger,
+	cfg *config,
+	requestTimeout time.Duration,
+	rates currency.ExchangeRateProvider,
+	verifiers map[string]credentialVerifier,
+) namecheap.DomainChecker {
+	if cfg.PorkbunAPIKey == "" || cfg.PorkbunSecretAPIKey == "" {
+		logger.Info("Porkbun tool disabled - missing configuration")
+
+		return nil
+	}
+
+	limits := limitsFor(cfg, "porkbun", cfg.PorkbunMaxRetries, requestTimeout)
+
+	service, err := porkbun.NewService(logger, porkbun.Config{
+		APIKey:             cfg.PorkbunAPIKey,
+		SecretAPIKey:       cfg.PorkbunSecretAPIKey,
+		Endpoint:           cfg.PorkbunEndpoint,
+		RequestTimeout:     limits.Timeout,
+		MaxRetries:         limits.MaxRetries,
+		RateLimitPerSecond: limits.RateLimit,
+		NumberFormat: namecheap.NumberFormat{
+			Decimal:   cfg.PorkbunDecimalSeparator,
+			Thousands: cfg.PorkbunThousandsSeparator,
+		},
+	})
+	if err != nil {
+		logger.Warn("Failed to create Porkbun service", zap.Error(err))
+
+		return nil
+	}
+
+	checker := decorate(logger, cfg, rates, withLimits(service, limits))
+	addTool(mcpServer, logger, cfg, namecheap.NewCheckService(checker, tldtype.Default()))
+
+	verifiers["porkbun"] = service
+
+	logger.Info("Porkbun tool enabled")
+
+	return checker
+}
+
+// setupWebcom registers the Web.com reseller availability tool when its API key is
+// configured and returns its checker, or nil when it didn't.
+//
+//nolint:ireturn
+func setupWebcom(
+	mcpServer *mcp.Server,
+	logger *zap.Logger,
+	cfg *config,
+	requestTimeout time.Duration,
+	rates currency.ExchangeRateProvider,
+	verifiers map[string]credentialVerifier,
+) namecheap.DomainChecker {
+	if cfg.WebcomAPIKey == "" {
+		logger.Info("Web.com tool disabled - missing configuration")
+
+		return nil
+	}
+
+	limits := limitsFor(cfg, "webcom", cfg.WebcomMaxRetries, requestTimeout)
+
+	service, err := webcom.NewService(logger, webcom.Config{
+		APIKey:             cfg.WebcomAPIKey,
+		Endpoint:           cfg.WebcomEndpoint,
+		RequestTimeout:     limits.Timeout,
+		MaxRetries:         limits.MaxRetries,
+		RateLimitPerSecond: limits.RateLimit,
+	})
+	if err != nil {
+		logger.Warn("Failed to create Web.com service", zap.Error(err))
+
+		return nil
+	}
+
+	checker := decorate(logger, cfg, rates, withLimits(service, limits))
+	addTool(mcpServer, logger, cfg, namecheap.NewCheckService(checker, tldtype.Default()))
+
+	verifiers["webcom"] = service
+
+	logger.Info("Web.com tool enabled")
+
+	return checker
+}
+
+// setupGoDaddy registers the GoDaddy availability tool when its API key and secret are
+// configured and returns its checker, or nil when it didn't.
+//
+//nolint:ireturn
+func setupGoDaddy(
+	mcpServer *mcp.Server,
+	logger *zap.Logger,
+	cfg *config,
+	requestTimeout time.Duration,
+	rates currency.ExchangeRateProvider,
+	verifiers map[string]credentialVerifier,
+) namecheap.DomainChecker {
+	if cfg.GoDaddyAPIKey == "" || cfg.GoDaddyAPISecret == "" {
+		logger.Info("GoDaddy tool disabled - missing configuration")
+
+		return nil
+	}
+
+	limits := limitsFor(cfg, "godaddy", cfg.GoDaddyMaxRetries, requestTimeout)
+
+	service, err := godaddy.NewService(logger, godaddy.Config{
+		APIKey:             cfg.GoDaddyAPIKey,
+		APISecret:          cfg.GoDaddyAPISecret,
+		Endpoint:           cfg.GoDaddyEndpoint,
+		RequestTimeout:     limits.Timeout,
+		MaxRetries:         limits.MaxRetries,
+		RateLimitPerSecond: limits.RateLimit,
+		IncludeRaw:         cfg.DebugRawResults,
+		MaxDomainsPerCheck: cfg.GoDaddyMaxDomainsPerCheck,
+	})
+	if err != nil {
+		logger.Warn("Failed to create GoDaddy service", zap.Error(err))
+
+		return nil
+	}
+
+	checker := decorate(logger, cfg, rates, withLimits(service, limits))
+	addTool(mcpServer, logger, cfg, namecheap.NewCheckService(checker, tldtype.Default()))
+
+	verifiers["godaddy"] = service
+
+	logger.Info("GoDaddy tool enabled")
+
+	return checker
+}
+
+// setupCloudflare registers the Cloudflare Registrar availability tool when its API token
+// and account ID are configured and returns its checker, or nil when it didn't.
+//
+//nolint:ireturn
+func setupCloudflare(
+	mcpServer *mcp.Server,
+	logger *zap.Logger,
+	cfg *config,
+	requestTimeout time.Duration,
+	rates currency.ExchangeRateProvider,
+	verifiers map[string]credentialVerifier,
+) namecheap.DomainChecker {
+	if cfg.CloudflareAPIToken == "" || cfg.CloudflareAccountID == "" {
+		logger.Info("Cloudflare tool disabled - missing configuration")
+
+		return nil
+	}
+
+	limits := limitsFor(cfg, "cloudflare", cfg.CloudflareMaxRetries, requestTimeout)
+
+	service, err := cloudflare.NewService(logger, cloudflare.Config{
+		APIToken:           cfg.CloudflareAPIToken,
+		AccountID:          cfg.CloudflareAccountID,
+		Endpoint:           cfg.CloudflareEndpoint,
+		RequestTimeout:     limits.Timeout,
+		MaxRetries:         limits.MaxRetries,
+		RateLimitPerSecond: limits.RateLimit,
+	})
+	if err != nil {
+		logger.Warn("Failed to create Cloudflare service", zap.Error(err))
+
+		return nil
+	}
+
+	checker := decorate(logger, cfg, rates, withLimits(service, limits))
+	addTool(mcpServer, logger, cfg, namecheap.NewCheckService(checker, tldtype.Default()))
+
+	verifiers["cloudflare"] = service
+
+	logger.Info("Cloudflare tool enabled")
+
+	return checker
+}
+
+// setupGandi registers the Gandi availability tool when its API key is configured and
+// returns its checker, or nil when it didn't.
+//
+//nolint:ireturn
+func setupGandi(
+	mcpServer *mcp.Server,
+	logger *zap.Logger,
+	cfg *config,
+	requestTimeout time.Duration,
+	rates currency.ExchangeRateProvider,
+	verifiers map[string]credentialVerifier,
+) namecheap.DomainChecker {
+	if cfg.GandiAPIKey == "" {
+		logger.Info("Gandi tool disabled - missing configuration")
+
+		return nil
+	}
+
+	limits := limitsFor(cfg, "gandi", cfg.GandiMaxRetries, requestTimeout)
+
+	service, err := gandi.NewService(logger, gandi.Config{
+		APIKey:             cfg.GandiAPIKey,
+		AuthScheme:         cfg.GandiAuthScheme,
+		Endpoint:           cfg.GandiEndpoint,
+		RequestTimeout:     limits.Timeout,
+		MaxRetries:         limits.MaxRetries,
+		RateLimitPerSecond: limits.RateLimit,
+		IncludeRaw:         cfg.DebugRawResults,
+	})
+	if err != nil {
+		logger.Warn("Failed to create Gandi service", zap.Error(err))
+
+		return nil
+	}
+
+	checker := decorate(logger, cfg, rates, withLimits(service, limits))
+	addTool(mcpServer, logger, cfg, namecheap.NewCheckService(checker, tldtype.Default()))
+
+	verifiers["gandi"] = service
+
+	logger.Info("Gandi tool enabled")
+
+	return checker
+}
+
+// setupDynadot registers the Dynadot availability tool when its API key is configured
+// and returns its checker, or nil when it didn't.
+//
+//nolint:ireturn
+func setupDynadot(
+	mcpServer *mcp.Server,
+	logger *zap.Logger,
+	cfg *config,
+	requestTimeout time.Duration,
+	rates currency.ExchangeRateProvider,
+	verifiers map[string]credentialVerifier,
+) namecheap.DomainChecker {
+	if cfg.DynadotAPIKey == "" {
+		logger.Info("Dynadot tool disabled - missing configuration")
+
+		return nil
+	}
+
+	limits := limitsFor(cfg, "dynadot", cfg.DynadotMaxRetries, requestTimeout)
+
+	service, err := dynadot.NewService(logger, dynadot.Config{
+		APIKey:             cfg.DynadotAPIKey,
+		Endpoint:           cfg.DynadotEndpoint,
+		MaxDomainsPerCheck: cfg.DynadotMaxDomainsPerCheck,
+		RequestTimeout:     limits.Timeout,
+		MaxRetries:         limits.MaxRetries,
+		RateLimitPerSecond: limits.RateLimit,
+		IncludeRaw:         cfg.DebugRawResults,
+	})
+	if err != nil {
+		logger.Warn("Failed to create Dynadot service", zap.Error(err))
+
+		return nil
+	}
+
+	checker := decorate(logger, cfg, rates, withLimits(service, limits))
+	addTool(mcpServer, logger, cfg, namecheap.NewCheckService(checker, tldtype.Default()))
+
+	verifiers["dynadot"] = service
+
+	logger.Info("Dynadot tool enabled")
+
+	return checker
+}
+
+// setupRoute53 registers the Route 53 Domains availability tool when its region is
+// configured and returns its checker, or nil when it didn't. Credentials come from the
+// standard AWS chain.
+//
+//nolint:ireturn
+func setupRoute53(
+	ctx context.Context,
+	mcpServer *mcp.Server,
+	logger *zap.Logger,
+	cfg *config,
+	requestTimeout time.Duration,
+	rates currency.ExchangeRateProvider,
+	verifiers map[string]credentialVerifier,
+) namecheap.DomainChecker {
+	if cfg.Route53Region == "" {
+		logger.Info("Route 53 tool disabled - missing configuration")
+
+		return nil
+	}
+
+	limits := limitsFor(cfg, "route53", cfg.Route53MaxRetries, requestTimeout)
+
+	service, err := route53.NewService(ctx, logger, route53.Config{
+		Region:             cfg.Route53Region,
+		Endpoint:           cfg.Route53Endpoint,
+		Credentials:        nil,
+		RequestTimeout:     limits.Timeout,
+		MaxRetries:         limits.MaxRetries,
+		RateLimitPerSecond: limits.RateLimit,
+		IncludeRaw:         cfg.DebugRawResults,
+	})
+	if err != nil {
+		logger.Warn("Failed to create Route 53 service", zap.Error(err))
+
+		return nil
+	}
+
+	checker := decorate(logger, cfg, rates, withLimits(service, limits))
+	addTool(mcpServer, logger, cfg, namecheap.NewCheckService(checker, tldtype.Default()))
+
+	verifiers["route53"] = service
+
+	logger.Info("Route 53 tool enabled")
+
+	return checker
+}
+
+// resolveClientIP replaces NAMECHEAP_CLIENT_IP=auto with the public IP reported by the
+// NAMECHEAP_IP_ECHO_URL service, logging it so operators can whitelist it at Namecheap.
+func resolveClientIP(ctx context.Context, logger *zap.Logger, cfg *config) error {
+	if !strings.EqualFold(cfg.NamecheapClientIP, namecheap.ClientIPAuto) {
+		return nil
+	}
+
+	clientIP, err := namecheap.DiscoverClientIP(ctx, nil, cfg.NamecheapIPEchoURL)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Discovered Namecheap client IP, make sure it is whitelisted",
+		zap.String("client_ip", clientIP), zap.String("echo_url", cfg.NamecheapIPEchoURL))
+
+	cfg.NamecheapClientIP = clientIP
+
+	return nil
+}
+
+// newNamecheapConfig builds the Namecheap client configuration and returns it with the
+// backend limits it was built from. NAMECHEAP_RATE_LIMIT_PER_SECOND applies unless
+// BACKEND_RATE_LIMITS sets a namecheap rate.
+func newNamecheapConfig(cfg *config, requestTimeout, checkTimeout time.Duration) (namecheap.Config, backendLimits) {
+	namecheapLimits := limitsFor(cfg, "namecheap", cfg.NamecheapMaxRetries, requestTimeout)
+	if _, ok := cfg.BackendRateLimits["namecheap"]; !ok {
+		namecheapLimits.RateLimit = cfg.NamecheapRateLimitPerSecond
+	}
+
+	return namecheap.Config{
+		APIUser:             cfg.NamecheapAPIUser,
+		APIKey:              cfg.NamecheapAPIKey,
+		UserName:            cfg.NamecheapUserName,
+		ClientIP:            cfg.NamecheapClientIP,
+		Endpoint:            cfg.NamecheapEndpoint,
+		Sandbox:             cfg.NamecheapSandbox,
+		IncludeRaw:          cfg.DebugRawResults,
+		RegisterURLTemplate: cfg.NamecheapRegisterURLTemplate,
+		MaxRetries:          namecheapLimits.MaxRetries,
+		HTTPClient:          nil,
+		RequestTimeout:      namecheapLimits.Timeout,
+		CheckTimeout:        checkTimeout,
+		RateLimitPerSecond:  namecheapLimits.RateLimit,
+		RateLimitBurst:      cfg.NamecheapRateLimitBurst,
+		StrictXML:           cfg.NamecheapStrictXML,
+		BatchRetry:          cfg.BatchRetry,
+		BatchRetryDelay:     cfg.BatchRetryDelay,
+		NumberFormat: namecheap.NumberFormat{
+			Decimal:   cfg.NamecheapDecimalSeparator,
+			Thousands: cfg.NamecheapThousandsSeparator,
+		},
+		AvailableErrorNumbers: cfg.NamecheapAvailableErrorNumbers,
+		MaxDomainsPerCheck:    cfg.NamecheapMaxDomainsPerCheck,
+		IncludeRawResponse:    cfg.IncludeRawResponse,
+		UserAgent:             userAgent(cfg),
+		StandardPricing:       cfg.NamecheapStandardPricing,
+	}, namecheapLimits
+}
+
+// userAgent returns HTTP_USER_AGENT, or "mcp-domain-checker/<version>" when it is unset.
+func userAgent(cfg *config) string {
+	if cfg.HTTPUserAgent != "" {
+		return cfg.HTTPUserAgent
+	}
+
+	return namecheap.DefaultUserAgent + "/" + version
+}
+
+// decorate adds the cross-cutting behavior every provider shares: the opt-in DNS
+// pre-check, the result cache, reference price comparison and advisory blocklist flags,
+// the latter two applied outside the cache so they follow the current configuration,
+// conversion into PRICE_CURRENCY with the shared rates, and with OMIT_LEGACY_AVAILABLE,
+// tri-state only results.
+//
+//nolint:ireturn
+func decorate(
+	logger *zap.Logger,
+	cfg *config,
+	rates currency.ExchangeRateProvider,
+	checker namecheap.DomainChecker,
+) namecheap.DomainChecker {
+	if cfg.DNSPrecheck {
+		checker = dnsprecheck.NewChecker(logger, checker, net.DefaultResolver)
+	}
+
+	checker = withCache(logger, cfg, checker)
+
+	if len(cfg.ReferencePrices) > 0 {
+		checker = refprice.NewChecker(checker, refprice.Table(cfg.ReferencePrices))
+	}
+
+	// Converted after the reference comparison, so the reference prices, which refprice only
+	// sets on US dollar results, are converted along with the prices they were compared with.
+	if cfg.PriceCurrency != "" {
+		checker = currency.NewChecker(logger, checker, rates, cfg.PriceCurrency)
+	}
+
+	checker = blocklist.NewChecker(checker, blocklist.Default())
+
+	if cfg.OmitLegacyAvailable {
+		checker = namecheap.NewOmitAvailableChecker(checker)
+	}
+
+	return checker
+}
+
+// newExchangeRates returns the live rates with EXCHANGE_RATES_LIVE=true and the static
+// EXCHANGE_RATES otherwise. Build them once and share them between providers, so live
+// rates are fetched once per TTL rather than once per provider.
+//
+//nolint:ireturn
+func newExchangeRates(logger *zap.Logger, cfg *config) currency.ExchangeRateProvider {
+	var rates currency.ExchangeRateProvider
+
+	if cfg.ExchangeRatesLive {
+		rates = currency.NewLiveRates(logger, currency.LiveRatesConfig{
+			URL:            cfg.ExchangeRatesURL,
+			TTL:            0,
+			RequestTimeout: 0,
+		})
+	} else {
+		static := make(currency.StaticRates, len(cfg.ExchangeRates))
+		for code, rate := range cfg.ExchangeRates {
+			static[strings.ToUpper(code)] = rate
+		}
+
+		rates = static
+	}
+
+	return rates
+}
+
+// withCache wraps checker in the result cache, or returns it unchanged when CACHE_TTL
+// is zero or negative. Results carrying an error are kept for CACHE_ERROR_TTL instead.
+//
+//nolint:ireturn
+func withCache(logger *zap.Logger, cfg *config, checker namecheap.DomainChecker) namecheap.DomainChecker {
+	if cfg.CacheTTL <= 0 {
+		return checker
+	}
+
+	return cache.NewChecker(logger, checker, cache.Config{
+		TTL:      cfg.CacheTTL,
+		ErrorTTL: cfg.CacheErrorTTL,
+		Now:      nil,
+	})
+}
+
+// resolveTimeouts returns the per-request and whole-check upstream timeouts. Unset values
+// default to fractions of SERVER_TIMEOUT; configured values larger than it are kept but
+// logged, since the server may cut the response off before the check completes.
+func resolveTimeouts(logger *zap.Logger, cfg *config) (time.Duration, time.Duration) {
+	requestTimeout := cfg.RequestTimeout
+	if requestTimeout <= 0 {
+		requestTimeout = time.Duration(float64(cfg.ServerTimeout) * requestTimeoutFraction)
+	}
+
+	checkTimeout := cfg.CheckTimeout
+	if checkTimeout <= 0 {
+		checkTimeout = time.Duration(float64(cfg.ServerTimeout) * checkTimeoutFraction)
+	}
+
+	if requestTimeout > cfg.ServerTimeout {
+		logger.Warn("REQUEST_TIMEOUT exceeds SERVER_TIMEOUT; responses may be cut off",
+			zap.Duration("request_timeout", requestTimeout),
+			zap.Duration("server_timeout", cfg.ServerTimeout),
+		)
+	}
+
+	if checkTimeout > cfg.ServerTimeout {
+		logger.Warn("CHECK_TIMEOUT exceeds SERVER_TIMEOUT; responses may be cut off",
+			zap.Duration("check_timeout", checkTimeout),
+			zap.Duration("server_timeout", cfg.ServerTimeout),
+		)
+	}
+
+	return requestTimeout, checkTimeout
+}
+
+// backendLimits are the rate, concurrency, retry and timeout settings of one backend.
+type backendLimits struct {
+	// RateLimit is how many upstream requests may start per second; zero is unlimited. The
+	// provider clients apply it to each request, as one check may send a request per domain
+	RateLimit float64
+	// MaxConcurrency is how many checks may run at once; zero is unlimited
+	MaxConcurrency int
+	// MaxRetries is how many times a transient network failure is retried
+	MaxRetries int
+	// Timeout bounds each upstream request
+	Timeout time.Duration
+}
+
+// limitsFor resolves the limits of the named backend. Its BACKEND_* entries win, then its
+// own *_MAX_RETRIES override, then the global defaults: no rate or concurrency limit,
+// MAX_RETRIES and the per-request timeout.
+func limitsFor(cfg *config, name string, maxRetries *int, requestTimeout time.Duration) backendLimits {
+	limits := backendLimits{
+		RateLimit:      cfg.BackendRateLimits[name],
+		MaxConcurrency: cfg.BackendMaxConcurrency[name],
+		MaxRetries:     retriesFor(maxRetries, cfg.MaxRetries),
+		Timeout:        requestTimeout,
+	}
+
+	if retries, ok := cfg.BackendMaxRetries[name]; ok {
+		limits.MaxRetries = retries
+	}
+
+	if timeout, ok := cfg.BackendTimeouts[name]; ok && timeout > 0 {
+		limits.Timeout = timeout
+	}
+
+	return limits
+}
+
+// withLimits wraps checker in the concurrency limit, or returns it unchanged when it is
+// unset. The rate limit is applied by the provider clients to each upstream request.
+//
+//nolint:ireturn
+func withLimits(checker namecheap.DomainChecker, limits backendLimits) namecheap.DomainChecker {
+	if limits.MaxConcurrency <= 0 {
+		return checker
+	}
+
+	return limit.NewChecker(checker, limit.Config{
+		MaxConcurrency: limits.MaxConcurrency,
+	})
+}
+
+// retriesFor returns a backend's retry override, falling back to the global MAX_RETRIES.
+func retriesFor(backend *int, global int) int {
+	if backend != nil {
+		return *backend
+	}
+
+	return global
+}
+
+// newPriceHistoryStore persists price history to PRICE_HISTORY_FILE when set and
+// keeps it in memory otherwise.
+func newPriceHistoryStore(cfg *config) pricehistory.Store { //nolint:ireturn
+	if cfg.PriceHistoryFile != "" {
+		return pricehistory.NewFileStore(cfg.PriceHistoryFile)
+	}
+
+	return pricehistory.NewMemoryStore()
+}
+
+// addTool wraps a service with the generic tool adapter and registers it on the server.
+// Each call is logged to logger, and calls without a deadline are bounded by TOOL_TIMEOUT.
+func addTool[In, Out any](mcpServer *mcp.Server, logger *zap.Logger, cfg *config, service tool.Service[In, Out]) {
+	wrapped := tool.NewTool(logger, service, clientErrors()...).WithTimeout(cfg.ToolTimeout)
+
+	schema, err := outputSchema[Out]()
+	if err != nil {
+		logger.Fatal("Failed to infer tool output schema", zap.String("tool", wrapped.Name()), zap.Error(err))
+	}
+
+	mcp.AddTool(
+		mcpServer,
+		&mcp.Tool{ //nolint:exhaustruct
+			Name:         wrapped.Name(),
+			Description:  wrapped.Description(),
+			OutputSchema: schema,
+		},
+		wrapped.Handler,
+	)
+}
+
+// outputSchema infers the output schema of a tool like mcp.AddTool does, except that the
+// legacy "available" flag of results is optional, since OMIT_LEGACY_AVAILABLE leaves it out
+// and the SDK validates every output against the schema.
+func outputSchema[Out any]() (*jsonschema.Schema, error) {
+	schema, err := jsonschema.For[Out](&jsonschema.ForOptions{}) //nolint:exhaustruct
+	if err != nil {
+		return nil, fmt.Errorf("failed to infer output schema: %w", err)
+	}
+
+	optionalAvailable(schema)
+
+	return schema, nil
+}
+
+// optionalAvailable drops "available" from the required properties of every result
+// schema within schema, recognized by their tri-state "availability" property.
+func optionalAvailable(schema *jsonschema.Schema) {
+	if schema == nil {
+		return
+	}
+
+	if _, ok := schema.Properties["availability"]; ok {
+		schema.Required = slices.DeleteFunc(schema.Required, func(name string) bool {
+			return name == "available"
+		})
+	}
+
+	for _, property := range schema.Properties {
+		optionalAvailable(property)
+	}
+
+	for _, def := range schema.Defs {
+		optionalAvailable(def)
+	}
+
+	optionalAvailable(schema.Items)
+	optionalAvailable(schema.AdditionalProperties)
+}
+
+// clientErrors maps the sentinel errors of the backends to the explanations tool clients
+// get back, most specific first. Errors not listed here, or wrapping tool.ErrInvalidInput,
+// still fail the call.
+func clientErrors() []tool.ClientError {
+	return []tool.ClientError{
+		{
+			Err:     namecheap.ErrMissingDomains,
+			Code:    "missing_domains",
+			Message: "No domains were given. Pass at least one domain name to check.",
+		},
+		{
+			Err:  namecheap.ErrIPNotWhitelisted,
+			Code: "ip_not_whitelisted",
+			Message: "Namecheap rejected the server's IP address. Add the IP below to the Namecheap API whitelist " +
+				"(Profile > Tools > API Access) or fix NAMECHEAP_CLIENT_IP, then try again.",
+		},
+		{
+			Err:  namecheap.ErrAuthFailed,
+			Code: "auth_failed",
+			Message: "Namecheap rejected the API credentials. Check NAMECHEAP_API_USER and NAMECHEAP_API_KEY " +
+				"and that API access is enabled for the account, then try again.",
+		},
+		{
+			Err:     namecheap.ErrAPIError,
+			Code:    "registrar_rejected",
+			Message: "Namecheap rejected the request; the details below say why. Retrying won't help until that is fixed.",
+		},
+		{
+			Err:     porkbun.ErrAPIError,
+			Code:    "registrar_rejected",
+			Message: "Porkbun rejected the request; the details below say why. Retrying won't help until that is fixed.",
+		},
+		{
+			Err:     jobs.ErrTooManyJobs,
+			Code:    "too_many_jobs",
+			Message: "Too many check jobs are running. Wait for one to finish, then submit again.",
+		},
+		{
+			Err:     context.DeadlineExceeded,
+			Code:    "timeout",
+			Message: "The check took too long and was stopped. Try again with fewer domains.",
+		},
+		{
+			Err:     namecheap.ErrNamecheapAPIFailed,
+			Code:    "upstream_unavailable",
+			Message: "The domain registry could not be reached or answered unexpectedly. Try again shortly.",
+		},
+	}
 }
 
 func runStdio(ctx context.Context, mcpServer *mcp.Server, logger *zap.Logger) {
@@ -155,23 +1157,23 @@ func runStdio(ctx context.Context, mcpServer *mcp.Server, logger *zap.Logger) {
 	}
 }
 
-func runHTTP(ctx context.Context, mcpServer *mcp.Server, logger *zap.Logger) {
-	handler := mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server {
-		return mcpServer
-	}, nil)
-
-	corsHandler := corsMiddleware(handler)
-
-	httpServer := &http.Server{ //nolint:exhaustruct
-		Addr:        addr,
-		Handler:     corsHandler,
-		ReadTimeout: serverTimeout,
+func runHTTP(
+	ctx context.Context,
+	mcpServer *mcp.Server,
+	logger *zap.Logger,
+	cfg *config,
+	verifiers map[string]credentialVerifier,
+) {
+	if cfg.AdminToken != "" {
+		logger.Info("Admin endpoints enabled under /admin/")
 	}
 
+	httpServer := newHTTPServer(logger, cfg, newHTTPHandler(mcpServer, cfg, verifiers))
+
 	errCh := make(chan error, 1)
 
 	go func() {
-		logger.Info("Starting server on " + addr)
+		logger.Info("Starting server on " + httpServer.Addr)
 
 		errCh <- httpServer.ListenAndServe()
 	}()
@@ -196,12 +1198,98 @@ func runHTTP(ctx context.Context, mcpServer *mcp.Server, logger *zap.Logger) {
 	}
 }
 
-func corsMiddleware(next http.Handler) http.Handler {
+// newHTTPServer creates the HTTP server listening on LISTEN_ADDR. READ_TIMEOUT defaults
+// to SERVER_TIMEOUT and IDLE_TIMEOUT to two minutes; negative values are logged and
+// replaced by those defaults. Writes are only bounded when WRITE_TIMEOUT is set, as a
+// write deadline would cut off long-lived streamable HTTP event streams.
+func newHTTPServer(logger *zap.Logger, cfg *config, handler http.Handler) *http.Server {
+	return &http.Server{ //nolint:exhaustruct
+		Addr:         cfg.ListenAddr,
+		Handler:      handler,
+		ReadTimeout:  serverTimeout(logger, "READ_TIMEOUT", cfg.ReadTimeout, cfg.ServerTimeout),
+		WriteTimeout: serverTimeout(logger, "WRITE_TIMEOUT", cfg.WriteTimeout, 0),
+		IdleTimeout:  serverTimeout(logger, "IDLE_TIMEOUT", cfg.IdleTimeout, defaultIdleTimeout),
+	}
+}
+
+// serverTimeout returns value, or fallback when value is unset or negative.
+func serverTimeout(logger *zap.Logger, name string, value, fallback time.Duration) time.Duration {
+	if value < 0 {
+		logger.Warn(name+" is negative; using the default",
+			zap.Duration("value", value),
+			zap.Duration("default", fallback),
+		)
+	}
+
+	if value <= 0 {
+		return fallback
+	}
+
+	return value
+}
+
+// newHTTPHandler routes MCP traffic through the CORS and concurrency limit middleware
+// and, when an ADMIN_TOKEN is configured, mounts the token-protected admin endpoints.
+// With METRICS_ENABLED, the Prometheus metrics are served on /metrics. Every request gets
+// an X-Request-ID, see requestid.Middleware.
+func newHTTPHandler(mcpServer *mcp.Server, cfg *config, verifiers map[string]credentialVerifier) http.Handler {
+	handler := mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server {
+		return mcpServer
+	}, nil)
+
+	mux := http.NewServeMux()
+	mux.Handle("/", corsMiddleware(cfg, limitConcurrency(cfg.MaxConcurrentRequests, handler)))
+
+	if cfg.AdminToken != "" {
+		mux.Handle("/admin/", adminHandler(cfg, verifiers))
+	}
+
+	if cfg.MetricsEnabled {
+		mux.Handle("/metrics", metrics.Handler())
+	}
+
+	return requestid.Middleware(mux)
+}
+
+// corsMiddleware adds the CORS headers and answers preflight requests. Origins listed in
+// CORS_ALLOWED_ORIGINS are echoed back and others get no CORS headers; an empty list or
+// "*" allows any origin.
+func corsMiddleware(cfg *config, next http.Handler) http.Handler {
+	allowAny := len(cfg.CORSAllowedOrigins) == 0
+	allowed := make(map[string]bool, len(cfg.CORSAllowedOrigins))
+
+	for _, origin := range cfg.CORSAllowedOrigins {
+		origin = strings.TrimSpace(origin)
+		if origin == "*" {
+			allowAny = true
+		}
+
+		allowed[origin] = true
+	}
+
+	methods := joinOrDefault(cfg.CORSAllowedMethods, defaultCORSMethods)
+	headers := joinOrDefault(cfg.CORSAllowedHeaders, defaultCORSHeaders)
+
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, Mcp-Protocol-Version, Mcp-Session-Id")
-		w.Header().Set("Access-Control-Expose-Headers", "Mcp-Session-Id")
+		origin := r.Header.Get("Origin")
+
+		switch {
+		case allowAny:
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+		case origin != "" && allowed[origin]:
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+		}
+
+		if !allowAny {
+			// The answer depends on the Origin, so caches must not share it across origins.
+			w.Header().Add("Vary", "Origin")
+		}
+
+		if w.Header().Get("Access-Control-Allow-Origin") != "" {
+			w.Header().Set("Access-Control-Allow-Methods", methods)
+			w.Header().Set("Access-Control-Allow-Headers", headers)
+			w.Header().Set("Access-Control-Expose-Headers", "Mcp-Session-Id, "+requestid.Header)
+		}
 
 		if r.Method == http.MethodOptions {
 			w.WriteHeader(http.StatusOK)
@@ -212,3 +1300,43 @@ func corsMiddleware(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r)
 	})
 }
+
+// joinOrDefault joins the trimmed values with ", ", or returns fallback when there are none.
+func joinOrDefault(values []string, fallback string) string {
+	trimmed := make([]string, 0, len(values))
+
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			trimmed = append(trimmed, value)
+		}
+	}
+
+	if len(trimmed) == 0 {
+		return fallback
+	}
+
+	return strings.Join(trimmed, ", ")
+}
+
+// limitConcurrency rejects requests with 503 and a Retry-After header while limit
+// requests are already in flight, protecting memory and the upstream API quota.
+// A limit of zero or less disables the check.
+func limitConcurrency(limit int, next http.Handler) http.Handler {
+	if limit <= 0 {
+		return next
+	}
+
+	slots := make(chan struct{}, limit)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case slots <- struct{}{}:
+			defer func() { <-slots }()
+
+			next.ServeHTTP(w, r)
+		default:
+			w.Header().Set("Retry-After", retryAfterSeconds)
+			http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		}
+	})
+}
@@ -2,9 +2,18 @@ package main
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 func TestResolveTransport(t *testing.T) {
@@ -54,6 +63,235 @@ func TestResolveTransport(t *testing.T) {
 	}
 }
 
+func TestCheckLogOutput(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		transport string
+		logOutput string
+		wantErr   error
+	}{
+		{transport: transportStdio, logOutput: "stdout", wantErr: errStdoutLogWithStdio},
+		{transport: transportStdio, logOutput: "stderr", wantErr: nil},
+		{transport: transportStdio, logOutput: "/var/log/checker.log", wantErr: nil},
+		{transport: transportHTTP, logOutput: "stdout", wantErr: nil},
+	}
+
+	for _, tt := range tests {
+		err := checkLogOutput(tt.transport, tt.logOutput)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("checkLogOutput(%q, %q) = %v, want %v", tt.transport, tt.logOutput, err, tt.wantErr)
+		}
+	}
+}
+
+func TestRetriesFor(t *testing.T) {
+	t.Parallel()
+
+	three := 3
+
+	if got := retriesFor(&three, 1); got != 3 {
+		t.Errorf("retriesFor(3, 1) = %d, want the backend override 3", got)
+	}
+
+	if got := retriesFor(nil, 1); got != 1 {
+		t.Errorf("retriesFor(nil, 1) = %d, want the global default 1", got)
+	}
+}
+
+func TestLimitsFor(t *testing.T) {
+	t.Parallel()
+
+	cfg := newAdminTestConfig()
+	cfg.MaxRetries = 2
+	cfg.BackendRateLimits = map[string]float64{"namecheap": 1, "porkbun": 5}
+	cfg.BackendMaxConcurrency = map[string]int{"namecheap": 2, "porkbun": 8}
+	cfg.BackendMaxRetries = map[string]int{"namecheap": 0, "porkbun": 4}
+	cfg.BackendTimeouts = map[string]time.Duration{"namecheap": 10 * time.Second, "porkbun": time.Minute}
+
+	one := 1
+
+	tests := []struct {
+		name       string
+		backend    string
+		maxRetries *int
+		want       backendLimits
+	}{
+		{
+			name:       "backend A",
+			backend:    "namecheap",
+			maxRetries: &one,
+			want:       backendLimits{RateLimit: 1, MaxConcurrency: 2, MaxRetries: 0, Timeout: 10 * time.Second},
+		},
+		{
+			name:       "backend B",
+			backend:    "porkbun",
+			maxRetries: nil,
+			want:       backendLimits{RateLimit: 5, MaxConcurrency: 8, MaxRetries: 4, Timeout: time.Minute},
+		},
+		{
+			name:       "unconfigured backend uses the global defaults",
+			backend:    "webcom",
+			maxRetries: nil,
+			want:       backendLimits{RateLimit: 0, MaxConcurrency: 0, MaxRetries: 2, Timeout: 30 * time.Second},
+		},
+		{
+			name:       "backend retry override without a registry entry",
+			backend:    "godaddy",
+			maxRetries: &one,
+			want:       backendLimits{RateLimit: 0, MaxConcurrency: 0, MaxRetries: 1, Timeout: 30 * time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := limitsFor(cfg, tt.backend, tt.maxRetries, 30*time.Second); got != tt.want {
+				t.Errorf("limitsFor(%q) = %+v, want %+v", tt.backend, got, tt.want)
+			}
+		})
+	}
+
+}
+
+func TestResolveTimeouts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		requestTimeout time.Duration
+		checkTimeout   time.Duration
+		wantRequest    time.Duration
+		wantCheck      time.Duration
+		wantWarnings   int
+	}{
+		{
+			name:           "defaults derive from the server timeout",
+			requestTimeout: 0,
+			checkTimeout:   0,
+			wantRequest:    30 * time.Second,
+			wantCheck:      2 * time.Minute,
+			wantWarnings:   0,
+		},
+		{
+			name:           "configured values within the server timeout",
+			requestTimeout: 10 * time.Second,
+			checkTimeout:   time.Minute,
+			wantRequest:    10 * time.Second,
+			wantCheck:      time.Minute,
+			wantWarnings:   0,
+		},
+		{
+			name:           "request timeout beyond the server timeout warns",
+			requestTimeout: 5 * time.Minute,
+			checkTimeout:   0,
+			wantRequest:    5 * time.Minute,
+			wantCheck:      2 * time.Minute,
+			wantWarnings:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			core, logs := observer.New(zap.WarnLevel)
+
+			cfg := newAdminTestConfig()
+			cfg.ServerTimeout = 3 * time.Minute
+			cfg.RequestTimeout = tt.requestTimeout
+			cfg.CheckTimeout = tt.checkTimeout
+
+			gotRequest, gotCheck := resolveTimeouts(zap.New(core), cfg)
+			if gotRequest != tt.wantRequest || gotCheck != tt.wantCheck {
+				t.Errorf("resolveTimeouts() = %v, %v; want %v, %v", gotRequest, gotCheck, tt.wantRequest, tt.wantCheck)
+			}
+
+			if got := logs.Len(); got != tt.wantWarnings {
+				t.Errorf("warnings = %d, want %d", got, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestNewHTTPServer(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		read         time.Duration
+		write        time.Duration
+		idle         time.Duration
+		wantRead     time.Duration
+		wantWrite    time.Duration
+		wantIdle     time.Duration
+		wantWarnings int
+	}{
+		{
+			name:         "unset values use the defaults",
+			read:         0,
+			write:        0,
+			idle:         0,
+			wantRead:     3 * time.Minute,
+			wantWrite:    0,
+			wantIdle:     2 * time.Minute,
+			wantWarnings: 0,
+		},
+		{
+			name:         "configured values are applied",
+			read:         10 * time.Second,
+			write:        time.Minute,
+			idle:         30 * time.Second,
+			wantRead:     10 * time.Second,
+			wantWrite:    time.Minute,
+			wantIdle:     30 * time.Second,
+			wantWarnings: 0,
+		},
+		{
+			name:         "negative values fall back to the defaults",
+			read:         -time.Second,
+			write:        -time.Second,
+			idle:         -time.Minute,
+			wantRead:     3 * time.Minute,
+			wantWrite:    0,
+			wantIdle:     2 * time.Minute,
+			wantWarnings: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			core, logs := observer.New(zap.WarnLevel)
+
+			cfg := newAdminTestConfig()
+			cfg.ListenAddr = "127.0.0.1:9090"
+			cfg.ServerTimeout = 3 * time.Minute
+			cfg.ReadTimeout = tt.read
+			cfg.WriteTimeout = tt.write
+			cfg.IdleTimeout = tt.idle
+
+			server := newHTTPServer(zap.New(core), cfg, http.NotFoundHandler())
+			if server.Addr != cfg.ListenAddr {
+				t.Errorf("Addr = %q, want %q", server.Addr, cfg.ListenAddr)
+			}
+
+			if server.ReadTimeout != tt.wantRead || server.WriteTimeout != tt.wantWrite ||
+				server.IdleTimeout != tt.wantIdle {
+				t.Errorf("timeouts = %v, %v, %v; want %v, %v, %v",
+					server.ReadTimeout, server.WriteTimeout, server.IdleTimeout,
+					tt.wantRead, tt.wantWrite, tt.wantIdle)
+			}
+
+			if got := logs.Len(); got != tt.wantWarnings {
+				t.Errorf("warnings = %d, want %d", got, tt.wantWarnings)
+			}
+		})
+	}
+}
+
 //nolint:funlen
 func TestCorsMiddleware(t *testing.T) {
 	t.Parallel()
@@ -106,7 +344,7 @@ func TestCorsMiddleware(t *testing.T) {
 				w.WriteHeader(tt.nextHandlerStatus)
 			})
 
-			handler := corsMiddleware(nextHandler)
+			handler := corsMiddleware(newAdminTestConfig(), nextHandler)
 
 			req := httptest.NewRequestWithContext(context.Background(), tt.method, "/", nil)
 			rec := httptest.NewRecorder()
@@ -132,14 +370,224 @@ func TestCorsMiddleware(t *testing.T) {
 				t.Errorf("Access-Control-Allow-Methods = %v, want GET, POST, DELETE, OPTIONS", got)
 			}
 
-			expectedHeaders := "Content-Type, Authorization, Mcp-Protocol-Version, Mcp-Session-Id"
+			expectedHeaders := "Content-Type, Authorization, Mcp-Protocol-Version, Mcp-Session-Id, X-Request-ID"
 			if got := rec.Header().Get("Access-Control-Allow-Headers"); got != expectedHeaders {
 				t.Errorf("Access-Control-Allow-Headers = %v, want %v", got, expectedHeaders)
 			}
 
-			if got := rec.Header().Get("Access-Control-Expose-Headers"); got != "Mcp-Session-Id" {
-				t.Errorf("Access-Control-Expose-Headers = %v, want Mcp-Session-Id", got)
+			if got := rec.Header().Get("Access-Control-Expose-Headers"); got != "Mcp-Session-Id, X-Request-ID" {
+				t.Errorf("Access-Control-Expose-Headers = %v, want Mcp-Session-Id, X-Request-ID", got)
+			}
+		})
+	}
+}
+
+//nolint:funlen
+func TestCorsMiddleware_AllowedOrigins(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		origins     []string
+		methods     []string
+		origin      string
+		wantOrigin  string
+		wantMethods string
+		wantVary    string
+	}{
+		{
+			name:        "listed origin is echoed back",
+			origins:     []string{"https://app.example.com", " https://admin.example.com"},
+			methods:     nil,
+			origin:      "https://admin.example.com",
+			wantOrigin:  "https://admin.example.com",
+			wantMethods: "GET, POST, DELETE, OPTIONS",
+			wantVary:    "Origin",
+		},
+		{
+			name:        "unlisted origin gets no CORS headers",
+			origins:     []string{"https://app.example.com"},
+			methods:     nil,
+			origin:      "https://evil.example.com",
+			wantOrigin:  "",
+			wantMethods: "",
+			wantVary:    "Origin",
+		},
+		{
+			name:        "wildcard keeps the permissive behaviour",
+			origins:     []string{"*"},
+			methods:     nil,
+			origin:      "https://evil.example.com",
+			wantOrigin:  "*",
+			wantMethods: "GET, POST, DELETE, OPTIONS",
+			wantVary:    "",
+		},
+		{
+			name:        "methods are overridable",
+			origins:     []string{"https://app.example.com"},
+			methods:     []string{"GET", "POST"},
+			origin:      "https://app.example.com",
+			wantOrigin:  "https://app.example.com",
+			wantMethods: "GET, POST",
+			wantVary:    "Origin",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := newAdminTestConfig()
+			cfg.CORSAllowedOrigins = tt.origins
+			cfg.CORSAllowedMethods = tt.methods
+
+			handler := corsMiddleware(cfg, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequestWithContext(context.Background(), http.MethodOptions, "/", nil)
+			req.Header.Set("Origin", tt.origin)
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if got := rec.Header().Get("Access-Control-Allow-Origin"); got != tt.wantOrigin {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, tt.wantOrigin)
+			}
+
+			if got := rec.Header().Get("Access-Control-Allow-Methods"); got != tt.wantMethods {
+				t.Errorf("Access-Control-Allow-Methods = %q, want %q", got, tt.wantMethods)
+			}
+
+			if got := rec.Header().Get("Vary"); got != tt.wantVary {
+				t.Errorf("Vary = %q, want %q", got, tt.wantVary)
 			}
 		})
 	}
 }
+
+func TestHTTPServer_CORSPreflight(t *testing.T) {
+	t.Parallel()
+
+	mcpServer := mcp.NewServer(&mcp.Implementation{Name: "test", Title: "", Version: "", WebsiteURL: "", Icons: nil}, nil)
+	cfg := newAdminTestConfig()
+	httpServer := newHTTPServer(zap.NewNop(), cfg, newHTTPHandler(mcpServer, cfg, nil))
+
+	server := httptest.NewServer(httpServer.Handler)
+	t.Cleanup(server.Close)
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodOptions, server.URL+"/", nil)
+	if err != nil {
+		t.Fatalf("NewRequest() unexpected error: %v", err)
+	}
+
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+
+	resp, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatalf("OPTIONS request failed: %v", err)
+	}
+
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status code = %v, want %v", resp.StatusCode, http.StatusOK)
+	}
+
+	wantHeaders := map[string]string{
+		"Access-Control-Allow-Origin":   "*",
+		"Access-Control-Allow-Methods":  "GET, POST, DELETE, OPTIONS",
+		"Access-Control-Allow-Headers":  "Content-Type, Authorization, Mcp-Protocol-Version, Mcp-Session-Id, X-Request-ID",
+		"Access-Control-Expose-Headers": "Mcp-Session-Id, X-Request-ID",
+	}
+
+	for header, want := range wantHeaders {
+		if got := resp.Header.Get(header); got != want {
+			t.Errorf("%s = %q, want %q", header, got, want)
+		}
+	}
+}
+
+func TestOutputSchema_AvailableIsOptional(t *testing.T) {
+	t.Parallel()
+
+	schema, err := outputSchema[namecheap.ParamsOut]()
+	if err != nil {
+		t.Fatalf("outputSchema() unexpected error: %v", err)
+	}
+
+	result := schema.Properties["results"].Items
+	if result == nil || result.Properties["available"] == nil {
+		t.Fatal("result schema not found in the output schema")
+	}
+
+	if slices.Contains(result.Required, "available") {
+		t.Errorf("Required = %v, want \"available\" optional", result.Required)
+	}
+
+	if !slices.Contains(result.Required, "availability") {
+		t.Errorf("Required = %v, want \"availability\" kept", result.Required)
+	}
+}
+
+func TestLimitConcurrency(t *testing.T) {
+	t.Parallel()
+
+	const limit = 2
+
+	entered := make(chan struct{})
+	release := make(chan struct{})
+
+	handler := limitConcurrency(limit, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		entered <- struct{}{}
+
+		<-release
+
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	serve := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/", nil)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	var wg sync.WaitGroup
+
+	inFlight := make([]*httptest.ResponseRecorder, limit)
+	for i := range limit {
+		wg.Go(func() { inFlight[i] = serve() })
+		<-entered
+	}
+
+	// Every slot is taken, so further simultaneous requests are turned away.
+	for range 3 {
+		rec := serve()
+		if rec.Code != http.StatusServiceUnavailable {
+			t.Errorf("excess request status = %v, want %v", rec.Code, http.StatusServiceUnavailable)
+		}
+
+		if rec.Header().Get("Retry-After") == "" {
+			t.Error("excess request is missing the Retry-After header")
+		}
+	}
+
+	close(release)
+	wg.Wait()
+
+	for _, rec := range inFlight {
+		if rec.Code != http.StatusOK {
+			t.Errorf("in-flight request status = %v, want %v", rec.Code, http.StatusOK)
+		}
+	}
+
+	// Released slots accept new requests again.
+	go func() { <-entered }()
+
+	if rec := serve(); rec.Code != http.StatusOK {
+		t.Errorf("request after release status = %v, want %v", rec.Code, http.StatusOK)
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+)
+
+const (
+	// promptDomainCheckGuidance is the name of the prompt explaining check results.
+	promptDomainCheckGuidance = "domain_check_guidance"
+
+	// domainCheckGuidance is the text of the domain_check_guidance prompt.
+	domainCheckGuidance = `How to read domain check results:
+
+- "availability" is the answer to rely on: "available" means the domain can be registered,
+  "taken" means it can't, and "unknown" means the check failed; the reason is in "error".
+  Never present an unknown domain as available or taken. The legacy "available" boolean is
+  false for both taken and unknown domains.
+- "status", when set, tells why: "registered", "premium" (registrable at a premium price),
+  "reserved" (held by the registry or a premium name that isn't offered) or "error".
+- "isPremiumName" marks names the registry prices above the standard rate. For those,
+  "premiumRegistrationPrice" is the first-year price and "premiumRenewalPrice" the yearly
+  renewal, which is often as high as the registration; point that out, since a cheap-looking
+  name can be expensive to keep. Dynadot doesn't flag premium names and reports its
+  one-year price in "premiumRegistrationPrice" too.
+- "standardRegistrationPrice" is the regular one-year price of the TLD, given for names
+  that aren't premium.
+- "icannFee" is the ICANN fee added to the price. "eapFee" is the extra Early Access Program
+  fee charged during the first days after a TLD launches; it drops as the period ends, so
+  waiting may be cheaper.
+- Prices are in "currency"; don't convert or compare prices in different currencies.
+- "blocked" results matched an abuse or trademark blocklist; advise against registering them
+  and give "blockReason".
+
+When the wanted domain is taken, premium or too expensive, suggest alternatives with the
+tools this server offers: suggest_alternatives for the same name on other TLDs,
+suggest_synonym_domains for names built from synonyms of a keyword, and
+suggest_domains_from_description for new names from what the project is about. Only
+suggest domains a check reported available, and say which TLDs you checked.`
+)
+
+// setupPrompts registers the prompts that guide the model in using the tools.
+func setupPrompts(mcpServer *mcp.Server, logger *zap.Logger) {
+	mcpServer.AddPrompt(&mcp.Prompt{ //nolint:exhaustruct
+		Name:  promptDomainCheckGuidance,
+		Title: "Domain check guidance",
+		Description: "Explains how to interpret domain check results, premium pricing and fees, " +
+			"and how to suggest alternatives",
+	}, guidancePrompt)
+
+	logger.Info("Prompts enabled", zap.String("prompt", promptDomainCheckGuidance))
+}
+
+// guidancePrompt returns the domain_check_guidance prompt.
+func guidancePrompt(_ context.Context, _ *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	return &mcp.GetPromptResult{ //nolint:exhaustruct
+		Description: "How to interpret domain check results",
+		Messages: []*mcp.PromptMessage{
+			{
+				Role:    "user",
+				Content: &mcp.TextContent{Text: domainCheckGuidance}, //nolint:exhaustruct
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+)
+
+func TestSetupPrompts(t *testing.T) {
+	t.Parallel()
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "test"}, nil) //nolint:exhaustruct
+	setupPrompts(server, zap.NewNop())
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+
+	_, err := server.Connect(context.Background(), serverTransport, nil)
+	if err != nil {
+		t.Fatalf("Connect() server: %v", err)
+	}
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "client", Version: "test"}, nil) //nolint:exhaustruct
+
+	session, err := client.Connect(context.Background(), clientTransport, nil)
+	if err != nil {
+		t.Fatalf("Connect() client: %v", err)
+	}
+
+	t.Cleanup(func() { _ = session.Close() })
+
+	result, err := session.GetPrompt(context.Background(), &mcp.GetPromptParams{ //nolint:exhaustruct
+		Name: promptDomainCheckGuidance,
+	})
+	if err != nil {
+		t.Fatalf("GetPrompt() unexpected error: %v", err)
+	}
+
+	if len(result.Messages) != 1 {
+		t.Fatalf("GetPrompt() returned %d messages, want 1", len(result.Messages))
+	}
+
+	text, ok := result.Messages[0].Content.(*mcp.TextContent)
+	if !ok {
+		t.Fatalf("message content = %T, want *mcp.TextContent", result.Messages[0].Content)
+	}
+
+	for _, want := range []string{"availability", "isPremiumName", "premiumRenewalPrice", "icannFee", "eapFee",
+		"suggest_alternatives"} {
+		if !strings.Contains(text.Text, want) {
+			t.Errorf("guidance doesn't mention %q", want)
+		}
+	}
+}
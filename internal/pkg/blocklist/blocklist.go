@@ -0,0 +1,100 @@
+// Package blocklist flags domains whose name matches a known-abuse or trademark
+// blocklist. Flagging is advisory: Checker decorates any namecheap.DomainChecker and
+// marks matching results, but never drops or fails them.
+package blocklist
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+)
+
+// Blocklist decides whether a second-level name is blocklisted.
+type Blocklist interface {
+	// Lookup returns the reason sld is blocklisted, and false when it isn't.
+	Lookup(sld string) (string, bool)
+}
+
+// List is a static Blocklist mapping lowercase second-level names to the reason they're listed.
+type List map[string]string
+
+// Lookup returns the list entry for sld.
+func (l List) Lookup(sld string) (string, bool) {
+	reason, ok := l[strings.ToLower(sld)]
+
+	return reason, ok
+}
+
+// Default returns the small blocklist bundled with the server: well-known trademarks
+// that are common targets of typosquatting and phishing, and names that read as
+// official login or support pages.
+func Default() List {
+	return List{
+		"amazon":         "trademark: Amazon",
+		"apple":          "trademark: Apple",
+		"facebook":       "trademark: Meta",
+		"google":         "trademark: Google",
+		"instagram":      "trademark: Meta",
+		"microsoft":      "trademark: Microsoft",
+		"netflix":        "trademark: Netflix",
+		"paypal":         "trademark: PayPal",
+		"whatsapp":       "trademark: Meta",
+		"account-verify": "abuse: impersonates an account verification page",
+		"secure-login":   "abuse: impersonates a login page",
+		"support-desk":   "abuse: impersonates a support page",
+		"wallet-connect": "abuse: common crypto wallet phishing name",
+	}
+}
+
+// Checker is a DomainChecker that flags the results whose second-level name is on the
+// blocklist, setting Blocked and BlockReason. The wrapped checker's results are otherwise
+// returned untouched.
+type Checker struct {
+	checker   namecheap.DomainChecker
+	blocklist Blocklist
+}
+
+// NewChecker wraps checker so its results are flagged against blocklist.
+func NewChecker(checker namecheap.DomainChecker, blocklist Blocklist) *Checker {
+	return &Checker{
+		checker:   checker,
+		blocklist: blocklist,
+	}
+}
+
+// Name returns the name of the wrapped checker.
+func (c *Checker) Name() string {
+	return c.checker.Name()
+}
+
+// Description returns the description of the wrapped checker.
+func (c *Checker) Description() string {
+	return c.checker.Description()
+}
+
+// DomainsCheck checks domains with the wrapped checker and flags the blocklisted results.
+func (c *Checker) DomainsCheck(ctx context.Context, domains []string) ([]namecheap.Result, error) {
+	results, err := c.checker.DomainsCheck(ctx, domains)
+	if err != nil && !namecheap.IsPartial(err) {
+		return nil, err
+	}
+
+	for i := range results {
+		reason, blocked := c.blocklist.Lookup(secondLevelName(results[i].Domain))
+		if blocked {
+			results[i].Blocked = true
+			results[i].BlockReason = reason
+		}
+	}
+
+	return results, err
+}
+
+// secondLevelName returns the leftmost label of domain, which is the second-level name
+// of the registrable domains the checkers are given, e.g. "brand" for brand.co.uk.
+func secondLevelName(domain string) string {
+	label, _, _ := strings.Cut(namecheap.NormalizeDomain(domain), ".")
+
+	return label
+}
@@ -0,0 +1,64 @@
+package blocklist_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/blocklist"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap/namecheaptest"
+)
+
+// fakeBlocklist implements blocklist.Blocklist, listing a single name.
+type fakeBlocklist struct{}
+
+func (f fakeBlocklist) Lookup(sld string) (string, bool) {
+	if sld == "acme" {
+		return "trademark: ACME Corp", true
+	}
+
+	return "", false
+}
+
+func TestChecker_FlagsBlocklistedNames(t *testing.T) {
+	t.Parallel()
+
+	checker := blocklist.NewChecker(&namecheaptest.Checker{}, fakeBlocklist{}) //nolint:exhaustruct
+
+	results, err := checker.DomainsCheck(context.Background(), []string{"acme.com", "ACME.co.uk", "acmetools.com"})
+	if err != nil {
+		t.Fatalf("DomainsCheck() unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		domain     string
+		wantBlock  bool
+		wantReason string
+	}{
+		{domain: "acme.com", wantBlock: true, wantReason: "trademark: ACME Corp"},
+		{domain: "ACME.co.uk", wantBlock: true, wantReason: "trademark: ACME Corp"},
+		{domain: "acmetools.com", wantBlock: false, wantReason: ""},
+	}
+
+	for i, tt := range tests {
+		got := results[i]
+		if got.Domain != tt.domain || got.Blocked != tt.wantBlock || got.BlockReason != tt.wantReason {
+			t.Errorf("results[%d] = %+v, want blocked=%v reason=%q", i, got, tt.wantBlock, tt.wantReason)
+		}
+
+		if !got.Available {
+			t.Errorf("results[%d].Available = false, want blocklisting to be advisory", i)
+		}
+	}
+}
+
+func TestDefault(t *testing.T) {
+	t.Parallel()
+
+	if reason, ok := blocklist.Default().Lookup("PayPal"); !ok || reason == "" {
+		t.Errorf("Default().Lookup(%q) = %q, %v; want a listed name", "PayPal", reason, ok)
+	}
+
+	if _, ok := blocklist.Default().Lookup("mybrand"); ok {
+		t.Errorf("Default().Lookup(%q) listed, want clean", "mybrand")
+	}
+}
@@ -0,0 +1,145 @@
+// Package cache keeps recent availability results in memory. Checker decorates any
+// namecheap.DomainChecker, so repeated checks of the same names within the TTL are
+// answered without calling the provider again.
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"go.uber.org/zap"
+)
+
+// entry is a cached result and the time it stops being served.
+type entry struct {
+	result    namecheap.Result
+	expiresAt time.Time
+}
+
+// Config holds the configuration of a Checker.
+type Config struct {
+	// TTL is how long a clean result is served from the cache
+	TTL time.Duration
+	// ErrorTTL is how long a result carrying an error is served; it is capped at TTL and
+	// zero or less doesn't cache errors at all
+	ErrorTTL time.Duration
+	// Now is the time source; nil uses time.Now
+	Now func() time.Time
+}
+
+// Checker is a DomainChecker that serves cached results for domains checked within
+// the TTL and only forwards the rest to the wrapped checker. Results carrying an
+// error are kept for the shorter ErrorTTL, so a transient failure is retried soon.
+type Checker struct {
+	checker  namecheap.DomainChecker
+	ttl      time.Duration
+	errorTTL time.Duration
+	logger   *zap.Logger
+	now      func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]entry
+}
+
+// NewChecker wraps checker with a result cache configured by config.
+func NewChecker(logger *zap.Logger, checker namecheap.DomainChecker, config Config) *Checker {
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+
+	return &Checker{
+		checker:  checker,
+		ttl:      config.TTL,
+		errorTTL: min(config.ErrorTTL, config.TTL),
+		logger:   logger,
+		now:      config.Now,
+		mu:       sync.Mutex{},
+		entries:  make(map[string]entry),
+	}
+}
+
+// Name returns the name of the wrapped checker.
+func (c *Checker) Name() string {
+	return c.checker.Name()
+}
+
+// Description returns the description of the wrapped checker.
+func (c *Checker) Description() string {
+	return c.checker.Description()
+}
+
+// DomainsCheck answers cached domains from memory, checks the misses with the wrapped
+// checker and returns all results in input order.
+func (c *Checker) DomainsCheck(ctx context.Context, domains []string) ([]namecheap.Result, error) {
+	results := make([]namecheap.Result, len(domains))
+	misses := make([]string, 0, len(domains))
+	// missIndexes[i] is the position in domains of misses[i].
+	missIndexes := make([]int, 0, len(domains))
+
+	c.mu.Lock()
+
+	now := c.now()
+
+	for i, domain := range domains {
+		cached, ok := c.entries[namecheap.NormalizeDomain(domain)]
+		if ok && now.Before(cached.expiresAt) {
+			results[i] = cached.result
+			results[i].Domain = domain
+
+			continue
+		}
+
+		misses = append(misses, domain)
+		missIndexes = append(missIndexes, i)
+	}
+
+	c.mu.Unlock()
+
+	c.logger.Debug("Checked result cache",
+		zap.String("checker", c.checker.Name()),
+		zap.Int("hits", len(domains)-len(misses)),
+		zap.Int("misses", len(misses)))
+
+	if len(misses) == 0 && len(domains) > 0 {
+		return results, nil
+	}
+
+	checked, err := c.checker.DomainsCheck(ctx, misses)
+	if err != nil && !namecheap.IsPartial(err) {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now = c.now()
+	c.evictExpired(now)
+
+	// The checker may answer in its own order or leave domains out; a domain without a
+	// result gets an error result, which isn't cached.
+	for j, result := range namecheap.MatchResults(misses, checked) {
+		results[missIndexes[j]] = result
+
+		ttl := c.ttl
+		if result.Error != "" {
+			ttl = c.errorTTL
+		}
+
+		if ttl > 0 && result.Error != namecheap.ErrMissingResult.Error() {
+			c.entries[namecheap.NormalizeDomain(misses[j])] = entry{result: result, expiresAt: now.Add(ttl)}
+		}
+	}
+
+	return results, err
+}
+
+// evictExpired drops the entries that can no longer be served. Callers hold c.mu.
+func (c *Checker) evictExpired(now time.Time) {
+	for key, cached := range c.entries {
+		if !now.Before(cached.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+}
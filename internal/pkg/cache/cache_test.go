@@ -0,0 +1,279 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/cache"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"go.uber.org/zap"
+)
+
+// countingChecker implements namecheap.DomainChecker, recording every domain it is asked
+// for and failing the ones listed in failing.
+type countingChecker struct {
+	checked [][]string
+	failing map[string]bool
+}
+
+func (c *countingChecker) DomainsCheck(_ context.Context, domains []string) ([]namecheap.Result, error) {
+	c.checked = append(c.checked, domains)
+
+	results := make([]namecheap.Result, 0, len(domains))
+	for _, domain := range domains {
+		result := namecheap.Result{ //nolint:exhaustruct
+			Domain:       domain,
+			Available:    true,
+			Availability: namecheap.AvailabilityAvailable,
+		}
+
+		if c.failing[domain] {
+			result = namecheap.Result{ //nolint:exhaustruct
+				Domain:       domain,
+				Availability: namecheap.AvailabilityUnknown,
+				Error:        "upstream error",
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func (c *countingChecker) Name() string {
+	return "counting"
+}
+
+func (c *countingChecker) Description() string {
+	return "counting"
+}
+
+func domainsOf(results []namecheap.Result) []string {
+	domains := make([]string, 0, len(results))
+	for _, result := range results {
+		domains = append(domains, result.Domain)
+	}
+
+	return domains
+}
+
+func TestChecker_HitsAndMisses(t *testing.T) {
+	t.Parallel()
+
+	upstream := &countingChecker{checked: nil, failing: map[string]bool{"broken.com": true}}
+	checker := cache.NewChecker(zap.NewNop(), upstream, cache.Config{TTL: time.Hour, ErrorTTL: 0, Now: nil})
+
+	_, err := checker.DomainsCheck(context.Background(), []string{"one.com", "broken.com"})
+	if err != nil {
+		t.Fatalf("DomainsCheck() unexpected error: %v", err)
+	}
+
+	domains := []string{"new.com", "ONE.com", "broken.com"}
+
+	results, err := checker.DomainsCheck(context.Background(), domains)
+	if err != nil {
+		t.Fatalf("DomainsCheck() unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(domainsOf(results), domains) {
+		t.Errorf("result domains = %v, want %v in input order", domainsOf(results), domains)
+	}
+
+	if !results[1].Available || results[2].Error == "" {
+		t.Errorf("results = %+v, want the cached hit and a fresh error", results)
+	}
+
+	want := [][]string{{"one.com", "broken.com"}, {"new.com", "broken.com"}}
+	if !reflect.DeepEqual(upstream.checked, want) {
+		t.Errorf("upstream checked %v, want %v (hits and normalized hits skipped, errors retried)",
+			upstream.checked, want)
+	}
+
+	_, err = checker.DomainsCheck(context.Background(), []string{"one.com", "new.com"})
+	if err != nil {
+		t.Fatalf("DomainsCheck() unexpected error: %v", err)
+	}
+
+	if len(upstream.checked) != len(want) {
+		t.Errorf("upstream called for a fully cached check: %v", upstream.checked)
+	}
+}
+
+func TestChecker_Expiry(t *testing.T) {
+	t.Parallel()
+
+	upstream := &countingChecker{checked: nil, failing: nil}
+	checker := cache.NewChecker(zap.NewNop(), upstream,
+		cache.Config{TTL: 10 * time.Millisecond, ErrorTTL: 0, Now: nil})
+
+	for range 2 {
+		_, err := checker.DomainsCheck(context.Background(), []string{"example.com"})
+		if err != nil {
+			t.Fatalf("DomainsCheck() unexpected error: %v", err)
+		}
+
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	if len(upstream.checked) != 2 {
+		t.Errorf("upstream called %d times, want expired entries checked again", len(upstream.checked))
+	}
+}
+
+func TestChecker_ErrorTTL(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	upstream := &countingChecker{checked: nil, failing: map[string]bool{"broken.com": true}}
+	checker := cache.NewChecker(zap.NewNop(), upstream, cache.Config{
+		TTL:      time.Hour,
+		ErrorTTL: time.Minute,
+		Now:      func() time.Time { return now },
+	})
+
+	domains := []string{"one.com", "broken.com"}
+
+	checks := []struct {
+		advance time.Duration
+		want    []string
+	}{
+		{advance: 0, want: domains},
+		{advance: 30 * time.Second, want: nil},
+		{advance: time.Minute, want: []string{"broken.com"}},
+		{advance: time.Hour, want: domains},
+	}
+
+	for i, check := range checks {
+		now = now.Add(check.advance)
+		calls := len(upstream.checked)
+
+		_, err := checker.DomainsCheck(context.Background(), domains)
+		if err != nil {
+			t.Fatalf("DomainsCheck() unexpected error: %v", err)
+		}
+
+		var got []string
+		if len(upstream.checked) > calls {
+			got = upstream.checked[calls]
+		}
+
+		if !reflect.DeepEqual(got, check.want) {
+			t.Errorf("check %d: upstream checked %v, want %v", i, got, check.want)
+		}
+	}
+}
+
+// partialChecker reports the failures of its countingChecker as a partial failure, like
+// a Namecheap check with a failed batch.
+type partialChecker struct {
+	*countingChecker
+}
+
+func (p partialChecker) DomainsCheck(ctx context.Context, domains []string) ([]namecheap.Result, error) {
+	results, _ := p.countingChecker.DomainsCheck(ctx, domains)
+
+	var failed []string
+
+	for _, result := range results {
+		if result.Error != "" {
+			failed = append(failed, result.Domain)
+		}
+	}
+
+	if len(failed) == 0 {
+		return results, nil
+	}
+
+	return results, namecheap.NewPartialError(errors.New("upstream error"), failed) //nolint:err113
+}
+
+func TestChecker_PartialFailure(t *testing.T) {
+	t.Parallel()
+
+	upstream := &countingChecker{checked: nil, failing: map[string]bool{"broken.com": true}}
+	checker := cache.NewChecker(zap.NewNop(), partialChecker{upstream},
+		cache.Config{TTL: time.Hour, ErrorTTL: 0, Now: nil})
+
+	// Cache one.com so the partial check only sends the misses upstream.
+	_, err := checker.DomainsCheck(context.Background(), []string{"one.com"})
+	if err != nil {
+		t.Fatalf("DomainsCheck() unexpected error: %v", err)
+	}
+
+	domains := []string{"one.com", "broken.com", "two.com"}
+
+	results, err := checker.DomainsCheck(context.Background(), domains)
+	if !namecheap.IsPartial(err) {
+		t.Fatalf("DomainsCheck() error = %v, want the partial failure passed on", err)
+	}
+
+	if got := domainsOf(results); !reflect.DeepEqual(got, domains) {
+		t.Errorf("results = %v, want every domain %v", got, domains)
+	}
+
+	if results[1].Error == "" || results[2].Error != "" {
+		t.Errorf("results = %+v, want only broken.com failed", results)
+	}
+}
+
+// reversingChecker implements namecheap.DomainChecker, answering in reverse order and
+// leaving out the domains listed in skip.
+type reversingChecker struct {
+	skip map[string]bool
+}
+
+func (r reversingChecker) DomainsCheck(_ context.Context, domains []string) ([]namecheap.Result, error) {
+	var results []namecheap.Result
+
+	for i := len(domains) - 1; i >= 0; i-- {
+		if r.skip[domains[i]] {
+			continue
+		}
+
+		results = append(results, namecheap.Result{ //nolint:exhaustruct
+			Domain:       domains[i],
+			Available:    domains[i] == "free.com",
+			Availability: namecheap.AvailabilityTaken,
+		})
+	}
+
+	return results, nil
+}
+
+func (r reversingChecker) Name() string {
+	return "reversing"
+}
+
+func (r reversingChecker) Description() string {
+	return "reversing"
+}
+
+func TestChecker_MatchesResultsByDomain(t *testing.T) {
+	t.Parallel()
+
+	upstream := reversingChecker{skip: map[string]bool{"lost.com": true}}
+	checker := cache.NewChecker(zap.NewNop(), upstream, cache.Config{TTL: time.Hour, ErrorTTL: time.Hour, Now: nil})
+
+	for range 2 {
+		results, err := checker.DomainsCheck(context.Background(), []string{"free.com", "lost.com", "taken.com"})
+		if err != nil {
+			t.Fatalf("DomainsCheck() unexpected error: %v", err)
+		}
+
+		if got := domainsOf(results); !reflect.DeepEqual(got, []string{"free.com", "lost.com", "taken.com"}) {
+			t.Fatalf("DomainsCheck() domains = %v, want input order", got)
+		}
+
+		if !results[0].Available || results[2].Available {
+			t.Errorf("DomainsCheck() = %+v, want free.com available and taken.com taken", results)
+		}
+
+		if results[1].Error != namecheap.ErrMissingResult.Error() {
+			t.Errorf("lost.com: Error = %q, want %q", results[1].Error, namecheap.ErrMissingResult)
+		}
+	}
+}
@@ -0,0 +1,304 @@
+// Package cloudflare provides domain availability and at-cost pricing through the
+// Cloudflare Registrar API. Its Service implements namecheap.DomainChecker, so it plugs
+// into the same tools and decorators as the other providers.
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/limit"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/metrics"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/requestid"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/retry"
+	"go.uber.org/zap"
+)
+
+const (
+	// metricsProvider labels the metrics of this backend.
+	metricsProvider = "cloudflare"
+
+	// DefaultEndpoint is the Cloudflare v4 API.
+	DefaultEndpoint = "https://api.cloudflare.com/client/v4"
+
+	// defaultRequestTimeout is the per-request HTTP timeout when Config.RequestTimeout is unset.
+	defaultRequestTimeout = 30 * time.Second
+	// retryBackoff is the delay before the first retry.
+	retryBackoff = 250 * time.Millisecond
+
+	// tokenStatusActive is the status of a usable API token.
+	tokenStatusActive = "active"
+)
+
+var (
+	// ErrMissingAPIToken is returned when the API token is missing.
+	ErrMissingAPIToken = errors.New("missing Cloudflare API token")
+	// ErrMissingAccountID is returned when the account ID is missing.
+	ErrMissingAccountID = errors.New("missing Cloudflare account ID")
+	// ErrAPIError is returned when the Cloudflare API answers with success false.
+	ErrAPIError = errors.New("cloudflare API error")
+	// ErrUnsupportedTLD is reported per domain when Cloudflare doesn't sell its TLD.
+	ErrUnsupportedTLD = errors.New("TLD not supported by Cloudflare")
+	// ErrInactiveToken is returned by VerifyCredentials for a token that isn't active.
+	ErrInactiveToken = errors.New("cloudflare API token is not active")
+)
+
+// Config holds the configuration for the Cloudflare Registrar API client.
+type Config struct {
+	// APIToken is the API token, sent as a bearer token
+	APIToken string
+	// AccountID is the Cloudflare account the registrar requests are made for
+	AccountID string
+	// Endpoint is the base URL of the API; empty uses DefaultEndpoint
+	Endpoint string
+	// RequestTimeout bounds each API request; zero uses a 30 second default
+	RequestTimeout time.Duration
+	// MaxRetries is how many times a transient network failure is retried; zero disables retries
+	MaxRetries int
+	// RateLimitPerSecond caps outbound API requests per second; zero or less means unlimited
+	RateLimitPerSecond float64
+}
+
+// apiResponse is the envelope of every Cloudflare API response.
+type apiResponse struct {
+	Success bool            `json:"success"`
+	Errors  []apiError      `json:"errors"`
+	Result  json.RawMessage `json:"result"`
+}
+
+// apiError is an entry of the envelope's errors list.
+type apiError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Domain is the registrar's view of a domain name.
+type Domain struct {
+	Name         string `json:"name"`
+	Available    bool   `json:"available"`
+	CanRegister  bool   `json:"can_register"`
+	SupportedTLD bool   `json:"supported_tld"`
+	Fees         Fees   `json:"fees"`
+}
+
+// Fees are the at-cost prices of a domain, in US dollars.
+type Fees struct {
+	RegistrationFee float64 `json:"registration_fee"`
+	RenewalFee      float64 `json:"renewal_fee"`
+	IcannFee        float64 `json:"icann_fee"`
+}
+
+// tokenStatus is the result of the token verification endpoint.
+type tokenStatus struct {
+	Status string `json:"status"`
+}
+
+// Service checks domain availability using the Cloudflare Registrar API.
+type Service struct {
+	logger *zap.Logger
+	config Config
+	client *http.Client
+}
+
+// NewService creates a new Cloudflare service with the given configuration.
+// Returns ErrMissingAPIToken or ErrMissingAccountID if either is missing.
+func NewService(logger *zap.Logger, config Config) (*Service, error) {
+	if config.APIToken == "" {
+		return nil, ErrMissingAPIToken
+	}
+
+	if config.AccountID == "" {
+		return nil, ErrMissingAccountID
+	}
+
+	if config.Endpoint == "" {
+		config.Endpoint = DefaultEndpoint
+	}
+
+	timeout := config.RequestTimeout
+	if timeout <= 0 {
+		timeout = defaultRequestTimeout
+	}
+
+	return &Service{
+		logger: logger,
+		config: config,
+		client: &http.Client{ //nolint:exhaustruct
+			Timeout: timeout,
+			Transport: retry.NewTransport(
+				limit.NewTransport(http.DefaultTransport, config.RateLimitPerSecond), config.MaxRetries, retryBackoff),
+		},
+	}, nil
+}
+
+// Name returns the name of the Cloudflare service.
+func (s *Service) Name() string {
+	return "check_availability_cloudflare"
+}
+
+// Description returns a description of the Cloudflare service.
+func (s *Service) Description() string {
+	return "Check domain availability and at-cost prices using Cloudflare Registrar API"
+}
+
+// DomainsCheck looks each domain up with the registrar domain endpoint, one request per
+// domain. A failed lookup or a TLD Cloudflare doesn't sell is reported in that domain's
+// Result.Error; only a cancelled or expired ctx aborts the whole check.
+func (s *Service) DomainsCheck(ctx context.Context, domains []string) ([]namecheap.Result, error) {
+	if len(domains) == 0 {
+		return nil, namecheap.ErrMissingDomains
+	}
+
+	metrics.DomainsCheck(metricsProvider, len(domains))
+
+	results := make([]namecheap.Result, 0, len(domains))
+
+	for _, domain := range domains {
+		result, err := s.checkDomain(ctx, domain)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+
+			result = namecheap.Result{ //nolint:exhaustruct
+				Domain:       domain,
+				Availability: namecheap.AvailabilityUnknown,
+				Status:       namecheap.StatusError,
+				Error:        err.Error(),
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// VerifyCredentials calls the token verification endpoint and returns an error unless
+// the token is active.
+func (s *Service) VerifyCredentials(ctx context.Context) error {
+	var status tokenStatus
+
+	err := s.call(ctx, "user/tokens/verify", &status)
+	if err != nil {
+		return fmt.Errorf("failed to verify credentials: %w", err)
+	}
+
+	if status.Status != tokenStatusActive {
+		return fmt.Errorf("%w: %q", ErrInactiveToken, status.Status)
+	}
+
+	return nil
+}
+
+// checkDomain looks one domain up. Cloudflare sells at cost and doesn't flag premium
+// names, so the registration and renewal fees of an available domain are reported as
+// its registration and renewal prices.
+func (s *Service) checkDomain(ctx context.Context, domain string) (namecheap.Result, error) {
+	var resp Domain
+
+	path := "accounts/" + url.PathEscape(s.config.AccountID) + "/registrar/domains/" + url.PathEscape(domain)
+
+	err := s.call(ctx, path, &resp)
+	if err != nil {
+		return namecheap.Result{}, err
+	}
+
+	if !resp.SupportedTLD {
+		return namecheap.Result{}, ErrUnsupportedTLD
+	}
+
+	result := namecheap.Result{
+		Domain:                    domain,
+		Available:                 resp.Available && resp.CanRegister,
+		Availability:              namecheap.AvailabilityTaken,
+		Status:                    "",
+		IsPremiumName:             false,
+		PremiumRegistrationPrice:  0,
+		PremiumRenewalPrice:       0,
+		IcannFee:                  0,
+		EapFee:                    0,
+		StandardRegistrationPrice: 0,
+		Currency:                  "",
+		Error:                     "",
+		ErrorCode:                 0,
+		RegisterURL:               "",
+		Blocked:                   false,
+		BlockReason:               "",
+		CorrelationID:             "",
+		Input:                     "",
+		ReferencePrice:            0,
+		ReferencePriceDelta:       0,
+		Raw:                       nil,
+	}
+
+	if result.Available {
+		result.Availability = namecheap.AvailabilityAvailable
+		// Cloudflare sells at cost and offers no premium names, so the fee is the standard
+		// price; its renewal fee is the same at-cost price and isn't reported separately.
+		result.StandardRegistrationPrice = resp.Fees.RegistrationFee
+		result.IcannFee = resp.Fees.IcannFee
+		result.Currency = namecheap.CurrencyUSD
+	}
+
+	result.Status = namecheap.StatusOf(result)
+
+	return result, nil
+}
+
+// call GETs path under the configured endpoint and decodes the "result" field of a
+// successful answer into out.
+func (s *Service) call(ctx context.Context, path string, out any) error {
+	reqURL := strings.TrimSuffix(s.config.Endpoint, "/") + "/" + path
+
+	s.logger.Debug("Making Cloudflare API call", zap.String("path", path), requestid.Field(ctx))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+s.config.APIToken)
+
+	start := time.Now()
+	resp, err := s.client.Do(req)
+	metrics.UpstreamRequest(metricsProvider, time.Since(start), resp, err)
+
+	if err != nil {
+		return fmt.Errorf("HTTP request failed: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	var apiResp apiResponse
+
+	err = json.NewDecoder(resp.Body).Decode(&apiResp)
+	if err != nil {
+		return fmt.Errorf("failed to decode JSON response: %w", err)
+	}
+
+	if !apiResp.Success {
+		messages := make([]string, 0, len(apiResp.Errors))
+		for _, apiErr := range apiResp.Errors {
+			messages = append(messages, fmt.Sprintf("%d %s", apiErr.Code, apiErr.Message))
+		}
+
+		return fmt.Errorf("%w: %s", ErrAPIError, strings.Join(messages, "; "))
+	}
+
+	err = json.Unmarshal(apiResp.Result, out)
+	if err != nil {
+		return fmt.Errorf("failed to decode result: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+package cloudflare_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/cloudflare"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"go.uber.org/zap"
+)
+
+// responses are the stubbed registrar domain answers, keyed by domain.
+//
+//nolint:gochecknoglobals
+var responses = map[string]string{
+	"fresh.com": `{"success":true,"errors":[],"result":{"name":"fresh.com","available":true,"can_register":true,` +
+		`"supported_tld":true,"fees":{"registration_fee":10.44,"renewal_fee":10.44,"icann_fee":0.20}}}`,
+	"taken.com": `{"success":true,"errors":[],"result":{"name":"taken.com","available":false,"can_register":false,` +
+		`"supported_tld":true,"fees":{"registration_fee":10.44,"renewal_fee":10.44,"icann_fee":0.20}}}`,
+	"brand.es": `{"success":true,"errors":[],"result":{"name":"brand.es","available":true,"can_register":false,` +
+		`"supported_tld":false,"fees":{}}}`,
+	"bad..com": `{"success":false,"errors":[{"code":10000,"message":"Invalid domain name"}],"result":null}`,
+}
+
+func newTestService(t *testing.T, token string) *cloudflare.Service {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer cf-token" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"success":false,"errors":[{"code":9109,"message":"Invalid access token"}]}`))
+
+			return
+		}
+
+		if r.URL.Path == "/user/tokens/verify" {
+			_, _ = w.Write([]byte(`{"success":true,"errors":[],"result":{"id":"t1","status":"active"}}`))
+
+			return
+		}
+
+		domain, ok := strings.CutPrefix(r.URL.Path, "/accounts/acct-1/registrar/domains/")
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"success":false,"errors":[{"code":7003,"message":"Could not route"}]}`))
+
+			return
+		}
+
+		_, _ = w.Write([]byte(responses[domain]))
+	}))
+	t.Cleanup(server.Close)
+
+	service, err := cloudflare.NewService(zap.NewNop(), cloudflare.Config{
+		APIToken:           token,
+		AccountID:          "acct-1",
+		Endpoint:           server.URL,
+		RequestTimeout:     0,
+		MaxRetries:         0,
+		RateLimitPerSecond: 0,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+
+	return service
+}
+
+func TestNewService_MissingConfiguration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		token     string
+		accountID string
+		wantErr   error
+	}{
+		{name: "missing token", token: "", accountID: "acct-1", wantErr: cloudflare.ErrMissingAPIToken},
+		{name: "missing account", token: "cf-token", accountID: "", wantErr: cloudflare.ErrMissingAccountID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := cloudflare.NewService(zap.NewNop(), cloudflare.Config{
+				APIToken:           tt.token,
+				AccountID:          tt.accountID,
+				Endpoint:           "",
+				RequestTimeout:     0,
+				MaxRetries:         0,
+				RateLimitPerSecond: 0,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewService() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDomainsCheck(t *testing.T) {
+	t.Parallel()
+
+	results, err := newTestService(t, "cf-token").DomainsCheck(context.Background(),
+		[]string{"fresh.com", "taken.com", "brand.es", "bad..com"})
+	if err != nil {
+		t.Fatalf("DomainsCheck() unexpected error: %v", err)
+	}
+
+	if len(results) != 4 {
+		t.Fatalf("DomainsCheck() returned %d results, want 4", len(results))
+	}
+
+	tests := []struct {
+		domain       string
+		available    bool
+		availability string
+		registration float64
+		icannFee     float64
+		wantError    string
+	}{
+		{domain: "fresh.com", available: true, availability: namecheap.AvailabilityAvailable,
+			registration: 10.44, icannFee: 0.20, wantError: ""},
+		{domain: "taken.com", available: false, availability: namecheap.AvailabilityTaken,
+			registration: 0, icannFee: 0, wantError: ""},
+		{domain: "brand.es", available: false, availability: namecheap.AvailabilityUnknown,
+			registration: 0, icannFee: 0, wantError: "TLD not supported by Cloudflare"},
+		{domain: "bad..com", available: false, availability: namecheap.AvailabilityUnknown,
+			registration: 0, icannFee: 0, wantError: "Invalid domain name"},
+	}
+
+	for i, tt := range tests {
+		got := results[i]
+
+		if got.Domain != tt.domain || got.Available != tt.available || got.Availability != tt.availability ||
+			got.StandardRegistrationPrice != tt.registration || got.IcannFee != tt.icannFee ||
+			!strings.Contains(got.Error, tt.wantError) || (got.Error == "") != (tt.wantError == "") {
+			t.Errorf("results[%d] = %+v, want %+v", i, got, tt)
+		}
+	}
+}
+
+func TestVerifyCredentials(t *testing.T) {
+	t.Parallel()
+
+	err := newTestService(t, "cf-token").VerifyCredentials(context.Background())
+	if err != nil {
+		t.Errorf("VerifyCredentials() unexpected error: %v", err)
+	}
+
+	err = newTestService(t, "wrong").VerifyCredentials(context.Background())
+	if !errors.Is(err, cloudflare.ErrAPIError) {
+		t.Errorf("VerifyCredentials() error = %v, want %v", err, cloudflare.ErrAPIError)
+	}
+}
@@ -0,0 +1,312 @@
+// Package compare checks domains with every configured provider at once and reports,
+// per domain, the cheapest registration price and which provider offers it.
+package compare
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/tool"
+)
+
+// toolPrefix is the common prefix of the provider tool names, dropped from the provider
+// names in the output.
+const toolPrefix = "check_availability_"
+
+// ErrProviderTimeout is the error of a provider abandoned because it didn't answer
+// within its timeout or the overall deadline.
+var ErrProviderTimeout = errors.New("provider timed out")
+
+// ParamsIn represents the input parameters for a price comparison.
+type ParamsIn struct {
+	// Domains is the list of domain names to compare
+	Domains []string `json:"domains" jsonschema:"List of domains to compare across providers"`
+}
+
+// Result is the comparison of one domain across the providers.
+type Result struct {
+	// Domain is the normalized domain name
+	Domain string `json:"domain" jsonschema:"The domain that was compared"`
+	// Availability is available when any provider offers the domain, taken when every
+	// provider that answered reports it taken, and unknown when none answered
+	Availability string `json:"availability" jsonschema:"available, taken, or unknown when no provider answered"`
+	// ProviderPrices is the registration price quoted by each provider offering the domain
+	ProviderPrices map[string]float64 `json:"providerPrices,omitempty" jsonschema:"Registration price per provider"`
+	// CheapestProvider is the provider with the lowest registration price
+	CheapestProvider string `json:"cheapestProvider,omitempty" jsonschema:"Provider with the lowest registration price"`
+	// CheapestPrice is the lowest registration price
+	CheapestPrice float64 `json:"cheapestPrice,omitempty" jsonschema:"The lowest registration price"`
+	// Currency is the ISO 4217 code of the compared prices
+	Currency string `json:"currency,omitempty" jsonschema:"ISO 4217 currency of the compared prices"`
+}
+
+// ParamsOut represents the comparison results, in input order.
+type ParamsOut struct {
+	// Results holds one comparison per requested domain
+	Results []Result `json:"results" jsonschema:"One comparison per domain, in input order"`
+	// TimedOut lists the providers abandoned because they didn't answer in time
+	TimedOut []string `json:"timedOut,omitempty" jsonschema:"Providers left out because they didn't answer in time"`
+}
+
+// MultiProviderService compares availability and prices across several providers.
+type MultiProviderService struct {
+	checkers []namecheap.DomainChecker
+	currency string
+	// providerTimeout bounds each provider's check; zero leaves it unbounded
+	providerTimeout time.Duration
+	// timeout bounds the whole comparison; zero leaves it unbounded
+	timeout time.Duration
+}
+
+// NewMultiProviderService creates a comparison tool over checkers.
+func NewMultiProviderService(checkers []namecheap.DomainChecker) *MultiProviderService {
+	return &MultiProviderService{
+		checkers:        checkers,
+		currency:        namecheap.CurrencyUSD,
+		providerTimeout: 0,
+		timeout:         0,
+	}
+}
+
+// WithCurrency sets the currency prices are compared in, e.g. the PRICE_CURRENCY the
+// checkers convert their prices into. The default is US dollars.
+func (m *MultiProviderService) WithCurrency(currency string) *MultiProviderService {
+	m.currency = strings.ToUpper(currency)
+
+	return m
+}
+
+// WithTimeouts bounds each provider's check by providerTimeout and all of them by the
+// overall timeout; zero leaves either unbounded. A provider that doesn't answer in time
+// is abandoned and reported in TimedOut, so one slow provider can't hold up the others.
+func (m *MultiProviderService) WithTimeouts(providerTimeout, timeout time.Duration) *MultiProviderService {
+	m.providerTimeout = providerTimeout
+	m.timeout = timeout
+
+	return m
+}
+
+// Name returns the name of the comparison service.
+func (m *MultiProviderService) Name() string {
+	return "compare_prices"
+}
+
+// Description returns a description of the comparison service.
+func (m *MultiProviderService) Description() string {
+	return "Check domains with every configured provider at once and return, per domain, the availability " +
+		"and the cheapest registration price with the provider offering it"
+}
+
+// providerResults are the answers of one provider, one per checked domain in order, or
+// the error that failed its check.
+type providerResults struct {
+	name    string
+	results []namecheap.Result
+	err     error
+}
+
+// Execute checks the domains with all providers concurrently and compares their answers.
+// A provider that fails the whole check, or errors for a domain, is left out of that
+// comparison; only when every provider fails is the error returned. Providers that
+// time out are listed in TimedOut. Each provider's
+// price is its premium price for premium names and its standard price otherwise. Prices
+// are only compared in one currency, US dollars unless WithCurrency sets another, so
+// quotes in other currencies are left out.
+func (m *MultiProviderService) Execute(ctx context.Context, in ParamsIn) (ParamsOut, error) {
+	domains := make([]string, 0, len(in.Domains))
+
+	for _, domain := range in.Domains {
+		if domain = namecheap.NormalizeDomain(domain); domain != "" {
+			domains = append(domains, domain)
+		}
+	}
+
+	if len(domains) == 0 {
+		return ParamsOut{}, fmt.Errorf("%w: %w", tool.ErrInvalidInput, namecheap.ErrMissingDomains)
+	}
+
+	answers := m.checkAll(ctx, domains)
+
+	if ctx.Err() != nil {
+		return ParamsOut{}, ctx.Err()
+	}
+
+	var (
+		errs     []error
+		timedOut []string
+	)
+
+	for _, answer := range answers {
+		if answer.err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", answer.name, answer.err))
+		}
+
+		if errors.Is(answer.err, ErrProviderTimeout) {
+			timedOut = append(timedOut, answer.name)
+		}
+	}
+
+	if len(errs) == len(answers) {
+		return ParamsOut{}, errors.Join(errs...)
+	}
+
+	results := make([]Result, 0, len(domains))
+	for i, domain := range domains {
+		results = append(results, compareDomain(domain, i, answers, m.currency))
+	}
+
+	return ParamsOut{Results: results, TimedOut: timedOut}, nil
+}
+
+// checkAll checks domains with every provider concurrently, within the timeouts.
+func (m *MultiProviderService) checkAll(ctx context.Context, domains []string) []providerResults {
+	answers := make([]providerResults, len(m.checkers))
+
+	if m.timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, m.timeout)
+		defer cancel()
+	}
+
+	var wg sync.WaitGroup
+
+	for i, checker := range m.checkers {
+		wg.Go(func() {
+			results, err := m.checkProvider(ctx, checker, domains)
+			if namecheap.IsPartial(err) {
+				// The failed domains carry their error in the results.
+				err = nil
+			}
+
+			if err == nil {
+				// Providers may answer in their own order or leave domains out.
+				results = namecheap.MatchResults(domains, results)
+			}
+
+			answers[i] = providerResults{
+				name:    strings.TrimPrefix(checker.Name(), toolPrefix),
+				results: results,
+				err:     err,
+			}
+		})
+	}
+
+	wg.Wait()
+
+	return answers
+}
+
+// checkProvider checks domains with checker, abandoning it with ErrProviderTimeout when
+// it doesn't answer within the provider timeout or before ctx is done. An abandoned
+// check runs on with its context cancelled, and its answer is dropped.
+func (m *MultiProviderService) checkProvider(
+	ctx context.Context,
+	checker namecheap.DomainChecker,
+	domains []string,
+) ([]namecheap.Result, error) {
+	if m.providerTimeout <= 0 && m.timeout <= 0 {
+		return checker.DomainsCheck(ctx, domains)
+	}
+
+	var (
+		checkCtx context.Context
+		cancel   context.CancelFunc
+	)
+
+	if m.providerTimeout > 0 {
+		checkCtx, cancel = context.WithTimeout(ctx, m.providerTimeout)
+	} else {
+		checkCtx, cancel = context.WithCancel(ctx)
+	}
+
+	defer cancel()
+
+	type answer struct {
+		results []namecheap.Result
+		err     error
+	}
+
+	done := make(chan answer, 1)
+
+	go func() {
+		results, err := checker.DomainsCheck(checkCtx, domains)
+		done <- answer{results: results, err: err}
+	}()
+
+	select {
+	case answer := <-done:
+		if answer.err != nil && checkCtx.Err() != nil {
+			// The provider gave up on its cancelled context just in time.
+			return nil, ErrProviderTimeout
+		}
+
+		return answer.results, answer.err
+	case <-checkCtx.Done():
+		return nil, ErrProviderTimeout
+	}
+}
+
+// compareDomain combines the answers of every provider for domains[index], comparing
+// the prices quoted in currency.
+func compareDomain(domain string, index int, answers []providerResults, currency string) Result {
+	result := Result{
+		Domain:           domain,
+		Availability:     namecheap.AvailabilityUnknown,
+		ProviderPrices:   nil,
+		CheapestProvider: "",
+		CheapestPrice:    0,
+		Currency:         "",
+	}
+
+	for _, answer := range answers {
+		if answer.err != nil {
+			continue
+		}
+
+		providerResult := answer.results[index]
+
+		switch {
+		case providerResult.Error != "":
+			continue
+		case !providerResult.Available:
+			if result.Availability == namecheap.AvailabilityUnknown {
+				result.Availability = namecheap.AvailabilityTaken
+			}
+
+			continue
+		}
+
+		result.Availability = namecheap.AvailabilityAvailable
+
+		// Backends that don't set a currency quote in US dollars.
+		quoted := providerResult.Currency
+		if quoted == "" {
+			quoted = namecheap.CurrencyUSD
+		}
+
+		price := providerResult.RegistrationPrice()
+		if price <= 0 || !strings.EqualFold(quoted, currency) {
+			continue
+		}
+
+		if result.ProviderPrices == nil {
+			result.ProviderPrices = make(map[string]float64)
+		}
+
+		result.ProviderPrices[answer.name] = price
+
+		if result.CheapestProvider == "" || price < result.CheapestPrice {
+			result.CheapestProvider = answer.name
+			result.CheapestPrice = price
+			result.Currency = currency
+		}
+	}
+
+	return result
+}
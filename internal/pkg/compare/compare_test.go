@@ -0,0 +1,287 @@
+package compare_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/compare"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap/namecheaptest"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/tool"
+)
+
+// errUpstream is the failure of fakeProvider checks with err set.
+var errUpstream = errors.New("upstream unavailable")
+
+// quote is a fake provider answer for one domain.
+type quote struct {
+	available bool
+	price     float64
+	standard  float64
+	currency  string
+	err       string
+}
+
+// fakeProvider returns a checker named like a provider's tool that answers with canned
+// quotes, or fails the whole check when err is set.
+func fakeProvider(name string, err error, quotes map[string]quote) *namecheaptest.Checker {
+	answers := make(map[string]namecheap.Result, len(quotes))
+
+	for domain, q := range quotes {
+		result := namecheaptest.Result(domain, q.available)
+		result.PremiumRegistrationPrice = q.price
+		result.StandardRegistrationPrice = q.standard
+		result.Currency = q.currency
+		result.Error = q.err
+		answers[domain] = result
+	}
+
+	return &namecheaptest.Checker{ //nolint:exhaustruct
+		CheckerName: "check_availability_" + name,
+		Answers:     answers,
+		Err:         err,
+	}
+}
+
+func TestMultiProviderService_Execute(t *testing.T) {
+	t.Parallel()
+
+	service := compare.NewMultiProviderService([]namecheap.DomainChecker{
+		fakeProvider("porkbun", nil, map[string]quote{
+			// Non-premium names are compared by their standard price.
+			"brand.com": {available: true, price: 0, standard: 11.08, currency: "USD", err: ""},
+			"brand.io":  {available: true, price: 0, standard: 0, currency: "", err: "rate limited"},
+			"taken.com": {available: false, price: 0, standard: 0, currency: "", err: ""},
+		}),
+		fakeProvider("godaddy", nil, map[string]quote{
+			"brand.com": {available: true, price: 9.99, standard: 0, currency: "", err: ""},
+			"brand.io":  {available: true, price: 35, standard: 0, currency: "EUR", err: ""},
+			"taken.com": {available: false, price: 0, standard: 0, currency: "", err: ""},
+		}),
+		fakeProvider("broken", errUpstream, nil),
+	})
+
+	out, err := service.Execute(context.Background(), compare.ParamsIn{
+		Domains: []string{"https://Brand.com/", "brand.io", "taken.com"},
+	})
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+
+	want := []compare.Result{
+		{
+			Domain:           "brand.com",
+			Availability:     namecheap.AvailabilityAvailable,
+			ProviderPrices:   map[string]float64{"porkbun": 11.08, "godaddy": 9.99},
+			CheapestProvider: "godaddy",
+			CheapestPrice:    9.99,
+			Currency:         "USD",
+		},
+		{
+			Domain:           "brand.io",
+			Availability:     namecheap.AvailabilityAvailable,
+			ProviderPrices:   nil,
+			CheapestProvider: "",
+			CheapestPrice:    0,
+			Currency:         "",
+		},
+		{
+			Domain:           "taken.com",
+			Availability:     namecheap.AvailabilityTaken,
+			ProviderPrices:   nil,
+			CheapestProvider: "",
+			CheapestPrice:    0,
+			Currency:         "",
+		},
+	}
+
+	if !reflect.DeepEqual(out.Results, want) {
+		t.Errorf("Results = %+v, want %+v", out.Results, want)
+	}
+}
+
+func TestMultiProviderService_Errors(t *testing.T) {
+	t.Parallel()
+
+	service := compare.NewMultiProviderService([]namecheap.DomainChecker{
+		fakeProvider("porkbun", errUpstream, nil),
+		fakeProvider("godaddy", errUpstream, nil),
+	})
+
+	_, err := service.Execute(context.Background(), compare.ParamsIn{Domains: []string{"brand.com"}})
+	if !errors.Is(err, errUpstream) {
+		t.Errorf("Execute() error = %v, want %v when every provider fails", err, errUpstream)
+	}
+
+	_, err = service.Execute(context.Background(), compare.ParamsIn{Domains: []string{" "}})
+	if !errors.Is(err, namecheap.ErrMissingDomains) || !errors.Is(err, tool.ErrInvalidInput) {
+		t.Errorf("Execute() error = %v, want %v", err, namecheap.ErrMissingDomains)
+	}
+}
+
+func TestMultiProviderService_WithCurrency(t *testing.T) {
+	t.Parallel()
+
+	service := compare.NewMultiProviderService([]namecheap.DomainChecker{
+		fakeProvider("porkbun", nil, map[string]quote{
+			"brand.io": {available: true, price: 30, standard: 0, currency: "EUR", err: ""},
+		}),
+		fakeProvider("godaddy", nil, map[string]quote{
+			"brand.io": {available: true, price: 29, standard: 0, currency: "USD", err: ""},
+		}),
+	}).WithCurrency("eur")
+
+	out, err := service.Execute(context.Background(), compare.ParamsIn{Domains: []string{"brand.io"}})
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+
+	want := []compare.Result{{
+		Domain:           "brand.io",
+		Availability:     namecheap.AvailabilityAvailable,
+		ProviderPrices:   map[string]float64{"porkbun": 30},
+		CheapestProvider: "porkbun",
+		CheapestPrice:    30,
+		Currency:         "EUR",
+	}}
+
+	if !reflect.DeepEqual(out.Results, want) {
+		t.Errorf("Results = %+v, want %+v", out.Results, want)
+	}
+}
+
+func TestMultiProviderService_MatchesResultsByDomain(t *testing.T) {
+	t.Parallel()
+
+	// reversed answers in reverse order and leaves the first domain out.
+	reversed := checkerFunc(func(domains []string) []namecheap.Result {
+		results := make([]namecheap.Result, 0, len(domains))
+		for i := len(domains) - 1; i > 0; i-- {
+			results = append(results, namecheap.Result{ //nolint:exhaustruct
+				Domain:                   domains[i],
+				Available:                true,
+				PremiumRegistrationPrice: float64(10 + i),
+				Currency:                 "USD",
+			})
+		}
+
+		return results
+	})
+
+	service := compare.NewMultiProviderService([]namecheap.DomainChecker{reversed})
+
+	out, err := service.Execute(context.Background(), compare.ParamsIn{
+		Domains: []string{"first.com", "second.com", "third.com"},
+	})
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+
+	want := map[string]float64{"first.com": 0, "second.com": 11, "third.com": 12}
+
+	for _, result := range out.Results {
+		if result.CheapestPrice != want[result.Domain] {
+			t.Errorf("%s: CheapestPrice = %v, want %v", result.Domain, result.CheapestPrice, want[result.Domain])
+		}
+	}
+
+	if out.Results[0].Availability != namecheap.AvailabilityUnknown {
+		t.Errorf("first.com: Availability = %q, want %q for a missing result",
+			out.Results[0].Availability, namecheap.AvailabilityUnknown)
+	}
+}
+
+// checkerFunc is a DomainChecker answering with a function of the domains.
+type checkerFunc func(domains []string) []namecheap.Result
+
+func (f checkerFunc) DomainsCheck(_ context.Context, domains []string) ([]namecheap.Result, error) {
+	return f(domains), nil
+}
+
+func (f checkerFunc) Name() string {
+	return "check_availability_func"
+}
+
+func (f checkerFunc) Description() string {
+	return "func"
+}
+
+// slowProvider is a DomainChecker that ignores its context and only answers once release
+// is closed, like a hung upstream.
+type slowProvider struct {
+	release chan struct{}
+}
+
+func (s slowProvider) DomainsCheck(_ context.Context, domains []string) ([]namecheap.Result, error) {
+	<-s.release
+
+	return []namecheap.Result{namecheaptest.Available(domains[0])}, nil
+}
+
+func (s slowProvider) Name() string {
+	return "check_availability_slow"
+}
+
+func (s slowProvider) Description() string {
+	return "slow"
+}
+
+func TestMultiProviderService_AbandonsSlowProviders(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		providerTimeout time.Duration
+		timeout         time.Duration
+	}{
+		{name: "provider timeout", providerTimeout: 50 * time.Millisecond, timeout: 0},
+		{name: "overall deadline", providerTimeout: time.Minute, timeout: 50 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			slow := slowProvider{release: make(chan struct{})}
+			t.Cleanup(func() { close(slow.release) })
+
+			service := compare.NewMultiProviderService([]namecheap.DomainChecker{
+				slow,
+				fakeProvider("fast", nil, map[string]quote{
+					"brand.com": {available: true, price: 0, standard: 9.99, currency: "USD", err: ""},
+				}),
+			}).WithTimeouts(tt.providerTimeout, tt.timeout)
+
+			start := time.Now()
+
+			out, err := service.Execute(context.Background(), compare.ParamsIn{Domains: []string{"brand.com"}})
+			if err != nil {
+				t.Fatalf("Execute() unexpected error: %v", err)
+			}
+
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("Execute() took %v, want it to return within the budget", elapsed)
+			}
+
+			if !reflect.DeepEqual(out.TimedOut, []string{"slow"}) {
+				t.Errorf("TimedOut = %v, want [slow]", out.TimedOut)
+			}
+
+			want := []compare.Result{{
+				Domain:           "brand.com",
+				Availability:     namecheap.AvailabilityAvailable,
+				ProviderPrices:   map[string]float64{"fast": 9.99},
+				CheapestProvider: "fast",
+				CheapestPrice:    9.99,
+				Currency:         "USD",
+			}}
+
+			if !reflect.DeepEqual(out.Results, want) {
+				t.Errorf("Results = %+v, want %+v", out.Results, want)
+			}
+		})
+	}
+}
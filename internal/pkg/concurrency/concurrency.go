@@ -0,0 +1,113 @@
+// Package concurrency provides bounded fan-out helpers for checking large candidate sets.
+package concurrency
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Errors is the error MapBounded returns when fn failed for some items. It is indexed
+// like the items, holding nil for the items that succeeded.
+type Errors []error
+
+// Error reports how many items failed and the first failure.
+func (e Errors) Error() string {
+	failed := e.Unwrap()
+	if len(failed) == 0 {
+		return "no items failed"
+	}
+
+	return fmt.Sprintf("%d of %d items failed, first: %v", len(failed), len(e), failed[0])
+}
+
+// Unwrap returns the non-nil item errors, so errors.Is and errors.As see through Errors.
+func (e Errors) Unwrap() []error {
+	failed := make([]error, 0, len(e))
+
+	for _, err := range e {
+		if err != nil {
+			failed = append(failed, err)
+		}
+	}
+
+	return failed
+}
+
+// Err returns the error of item i, nil when it succeeded. It is safe on a nil Errors.
+func (e Errors) Err(i int) error {
+	if i < 0 || i >= len(e) {
+		return nil
+	}
+
+	return e[i]
+}
+
+// MapBounded calls fn for every item with at most limit calls in flight, and returns the
+// results in input order. A limit of zero or less runs every item at once.
+//
+// Once ctx is done no further calls are started; the items left out get ctx.Err() as
+// their error. When any item failed, the returned error is an Errors indexed like items
+// and the failed items' results are zero, while the others' results are still returned.
+func MapBounded[T, R any](
+	ctx context.Context,
+	items []T,
+	limit int,
+	fn func(context.Context, T) (R, error),
+) ([]R, error) {
+	if limit <= 0 || limit > len(items) {
+		limit = len(items)
+	}
+
+	results := make([]R, len(items))
+	errs := make(Errors, len(items))
+	slots := make(chan struct{}, limit)
+
+	var wg sync.WaitGroup
+
+	for i, item := range items {
+		if !acquire(ctx, slots) {
+			errs[i] = ctx.Err()
+
+			continue
+		}
+
+		wg.Go(func() {
+			defer func() { <-slots }()
+
+			results[i], errs[i] = fn(ctx, item)
+		})
+	}
+
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return results, errs
+		}
+	}
+
+	return results, nil
+}
+
+// acquire takes a slot, giving up when ctx is done first.
+func acquire(ctx context.Context, slots chan struct{}) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	select {
+	case slots <- struct{}{}:
+	case <-ctx.Done():
+		return false
+	}
+
+	// Both cases may have been ready; never start an item after cancellation.
+	if ctx.Err() != nil {
+		<-slots
+
+		return false
+	}
+
+	return true
+}
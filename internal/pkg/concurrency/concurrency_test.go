@@ -0,0 +1,225 @@
+package concurrency_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/concurrency"
+)
+
+var errOdd = errors.New("odd item")
+
+func TestMapBounded(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		items       []int
+		limit       int
+		fn          func(context.Context, int) (int, error)
+		want        []int
+		wantFailed  []bool
+		wantErrIs   error
+		wantNoError bool
+	}{
+		{
+			name:        "results in input order",
+			items:       []int{1, 2, 3, 4, 5},
+			limit:       2,
+			fn:          func(_ context.Context, n int) (int, error) { return n * n, nil },
+			want:        []int{1, 4, 9, 16, 25},
+			wantFailed:  []bool{false, false, false, false, false},
+			wantErrIs:   nil,
+			wantNoError: true,
+		},
+		{
+			name:        "no limit",
+			items:       []int{3, 2, 1},
+			limit:       0,
+			fn:          func(_ context.Context, n int) (int, error) { return n + 1, nil },
+			want:        []int{4, 3, 2},
+			wantFailed:  []bool{false, false, false},
+			wantErrIs:   nil,
+			wantNoError: true,
+		},
+		{
+			name:  "per-item errors are collected",
+			items: []int{1, 2, 3, 4},
+			limit: 2,
+			fn: func(_ context.Context, n int) (int, error) {
+				if n%2 == 1 {
+					return 0, errOdd
+				}
+
+				return n, nil
+			},
+			want:        []int{0, 2, 0, 4},
+			wantFailed:  []bool{true, false, true, false},
+			wantErrIs:   errOdd,
+			wantNoError: false,
+		},
+		{
+			name:        "empty input",
+			items:       nil,
+			limit:       4,
+			fn:          func(_ context.Context, n int) (int, error) { return n, nil },
+			want:        []int{},
+			wantFailed:  []bool{},
+			wantErrIs:   nil,
+			wantNoError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := concurrency.MapBounded(context.Background(), tt.items, tt.limit, tt.fn)
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MapBounded() = %v, want %v", got, tt.want)
+			}
+
+			if tt.wantNoError {
+				if err != nil {
+					t.Fatalf("MapBounded() unexpected error: %v", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("MapBounded() error = %v, want %v", err, tt.wantErrIs)
+			}
+
+			var errs concurrency.Errors
+			if !errors.As(err, &errs) {
+				t.Fatalf("MapBounded() error = %T, want concurrency.Errors", err)
+			}
+
+			for i, wantFailed := range tt.wantFailed {
+				if failed := errs.Err(i) != nil; failed != wantFailed {
+					t.Errorf("item %d failed = %v, want %v", i, failed, wantFailed)
+				}
+			}
+		})
+	}
+}
+
+func TestMapBounded_Limit(t *testing.T) {
+	t.Parallel()
+
+	const limit = 3
+
+	var inFlight, peak atomic.Int32
+
+	items := make([]int, 10)
+	started := make(chan struct{}, len(items))
+	release := make(chan struct{})
+	done := make(chan error)
+
+	go func() {
+		_, err := concurrency.MapBounded(context.Background(), items, limit, func(_ context.Context, n int) (int, error) {
+			current := inFlight.Add(1)
+			defer inFlight.Add(-1)
+
+			for seen := peak.Load(); current > seen && !peak.CompareAndSwap(seen, current); seen = peak.Load() {
+				// Retry until peak holds the highest count seen.
+			}
+
+			started <- struct{}{}
+			<-release
+
+			return n, nil
+		})
+		done <- err
+	}()
+
+	// Hold the first calls until the limit is reached, then let every call through.
+	for range limit {
+		<-started
+	}
+
+	close(release)
+
+	err := <-done
+	if err != nil {
+		t.Fatalf("MapBounded() unexpected error: %v", err)
+	}
+
+	if got := peak.Load(); got != limit {
+		t.Errorf("peak concurrency = %d, want %d", got, limit)
+	}
+}
+
+func TestMapBounded_Cancellation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		cancelAt   int
+		wantCalled int32
+		// failedFrom is the first item expected to fail; all items after it fail too
+		failedFrom int
+	}{
+		{name: "cancelled before the start", cancelAt: -1, wantCalled: 0, failedFrom: 0},
+		{name: "cancelled mid-flight", cancelAt: 2, wantCalled: 3, failedFrom: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			if tt.cancelAt < 0 {
+				cancel()
+			}
+
+			var called atomic.Int32
+
+			items := []int{0, 1, 2, 3, 4, 5}
+
+			// A limit of one runs the items one after another, so the cancellation point is exact.
+			got, err := concurrency.MapBounded(ctx, items, 1, func(ctx context.Context, n int) (int, error) {
+				called.Add(1)
+
+				if n == tt.cancelAt {
+					cancel()
+
+					return 0, ctx.Err()
+				}
+
+				return n + 1, nil
+			})
+
+			if called.Load() != tt.wantCalled {
+				t.Errorf("fn called %d times, want %d", called.Load(), tt.wantCalled)
+			}
+
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("MapBounded() error = %v, want %v", err, context.Canceled)
+			}
+
+			var errs concurrency.Errors
+			if !errors.As(err, &errs) {
+				t.Fatalf("MapBounded() error = %T, want concurrency.Errors", err)
+			}
+
+			for i := range items {
+				wantErr := i >= tt.failedFrom
+				if gotErr := errs.Err(i) != nil; gotErr != wantErr {
+					t.Errorf("item %d failed = %v, want %v", i, gotErr, wantErr)
+				}
+
+				if !wantErr && got[i] != i+1 {
+					t.Errorf("result %d = %d, want %d", i, got[i], i+1)
+				}
+			}
+		})
+	}
+}
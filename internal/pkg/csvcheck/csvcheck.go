@@ -0,0 +1,199 @@
+// Package csvcheck provides bulk domain availability checking for CSV documents.
+// It checks the domains found in a configurable column and appends the results
+// to every row, leaving the original columns and row order untouched.
+package csvcheck
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/tool"
+)
+
+// defaultDomainColumn is the header used when the caller doesn't name one.
+const defaultDomainColumn = "domain"
+
+var (
+	// ErrMissingCSV is returned when the CSV input is empty.
+	ErrMissingCSV = errors.New("missing CSV input")
+	// ErrDomainColumnNotFound is returned when the header has no matching domain column.
+	ErrDomainColumnNotFound = errors.New("domain column not found in CSV header")
+)
+
+// resultColumns are the headers appended to the CSV, in order.
+//
+//nolint:gochecknoglobals
+var resultColumns = []string{
+	"available",
+	"is_premium",
+	"premium_registration_price",
+	"premium_renewal_price",
+	"icann_fee",
+	"eap_fee",
+	"standard_registration_price",
+	"currency",
+	"error",
+}
+
+// ParamsIn represents the input parameters for CSV domain checking.
+type ParamsIn struct {
+	// CSV is the document to annotate, including its header row
+	CSV string `json:"csv" jsonschema:"CSV document with a header row"`
+	// DomainColumn is the header of the column holding the domains
+	DomainColumn string `json:"domainColumn,omitempty" jsonschema:"Header of the domain column (default: domain)"`
+}
+
+// ParamsOut represents the annotated CSV document.
+type ParamsOut struct {
+	// CSV is the original document with availability and price columns appended
+	CSV string `json:"csv" jsonschema:"The original CSV with availability and price columns appended"`
+}
+
+// Service checks the domains listed in a CSV document using a DomainChecker.
+type Service struct {
+	checker namecheap.DomainChecker
+}
+
+// NewService creates a new CSV checking service backed by the given checker.
+func NewService(checker namecheap.DomainChecker) *Service {
+	return &Service{
+		checker: checker,
+	}
+}
+
+// Name returns the name of the CSV checking service.
+func (s *Service) Name() string {
+	return "check_availability_csv"
+}
+
+// Description returns a description of the CSV checking service.
+func (s *Service) Description() string {
+	return "Check the domains in a CSV column and return the CSV with availability and price columns appended"
+}
+
+// Execute checks the domains in the CSV document and returns it annotated with the results.
+// The cells are normalized like the list tool's domains, so "https://Example.com/" is
+// checked as example.com. Rows without a domain keep their original values and get empty
+// result columns.
+func (s *Service) Execute(ctx context.Context, in ParamsIn) (ParamsOut, error) {
+	if strings.TrimSpace(in.CSV) == "" {
+		return ParamsOut{}, fmt.Errorf("%w: %w", tool.ErrInvalidInput, ErrMissingCSV)
+	}
+
+	records, err := csv.NewReader(strings.NewReader(in.CSV)).ReadAll()
+	if err != nil {
+		return ParamsOut{}, fmt.Errorf("%w: failed to parse CSV: %w", tool.ErrInvalidInput, err)
+	}
+
+	column := in.DomainColumn
+	if column == "" {
+		column = defaultDomainColumn
+	}
+
+	index := columnIndex(records[0], column)
+	if index < 0 {
+		return ParamsOut{}, fmt.Errorf("%w: %w: %q", tool.ErrInvalidInput, ErrDomainColumnNotFound, column)
+	}
+
+	domains := uniqueDomains(records[1:], index)
+
+	resultsByDomain := make(map[string]namecheap.Result, len(domains))
+
+	if len(domains) > 0 {
+		results, err := s.checker.DomainsCheck(ctx, domains)
+		if err != nil && !namecheap.IsPartial(err) {
+			return ParamsOut{}, err
+		}
+
+		for _, result := range results {
+			resultsByDomain[namecheap.NormalizeDomain(result.Domain)] = result
+		}
+	}
+
+	records[0] = append(records[0], resultColumns...)
+
+	for i, row := range records[1:] {
+		records[i+1] = append(row, resultCells(row[index], resultsByDomain)...)
+	}
+
+	var out strings.Builder
+
+	writer := csv.NewWriter(&out)
+
+	err = writer.WriteAll(records)
+	if err != nil {
+		return ParamsOut{}, fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	return ParamsOut{CSV: out.String()}, nil
+}
+
+func columnIndex(header []string, column string) int {
+	for i, name := range header {
+		if strings.EqualFold(strings.TrimSpace(name), strings.TrimSpace(column)) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// uniqueDomains collects the normalized non-empty domains in the given column, in
+// first-seen order, so repeated rows only cost a single check.
+func uniqueDomains(rows [][]string, index int) []string {
+	seen := make(map[string]struct{}, len(rows))
+	domains := make([]string, 0, len(rows))
+
+	for _, row := range rows {
+		domain := namecheap.NormalizeDomain(row[index])
+		if domain == "" {
+			continue
+		}
+
+		if _, ok := seen[domain]; ok {
+			continue
+		}
+
+		seen[domain] = struct{}{}
+
+		domains = append(domains, domain)
+	}
+
+	return domains
+}
+
+func resultCells(domain string, resultsByDomain map[string]namecheap.Result) []string {
+	domain = namecheap.NormalizeDomain(domain)
+	if domain == "" {
+		return make([]string, len(resultColumns))
+	}
+
+	result, ok := resultsByDomain[domain]
+	if !ok {
+		cells := make([]string, len(resultColumns))
+		cells[len(cells)-1] = "no result returned"
+
+		return cells
+	}
+
+	return []string{
+		strconv.FormatBool(result.Available),
+		strconv.FormatBool(result.IsPremiumName),
+		formatPrice(result.PremiumRegistrationPrice),
+		formatPrice(result.PremiumRenewalPrice),
+		formatPrice(result.IcannFee),
+		formatPrice(result.EapFee),
+		formatPrice(result.StandardRegistrationPrice),
+		result.Currency,
+		result.Error,
+	}
+}
+
+func formatPrice(price float64) string {
+	return strconv.FormatFloat(price, 'f', -1, 64)
+}
@@ -0,0 +1,166 @@
+package csvcheck_test
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/csvcheck"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap/namecheaptest"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/tool"
+)
+
+func TestExecute_AppendsResultColumns(t *testing.T) {
+	t.Parallel()
+
+	checker := &namecheaptest.Checker{ //nolint:exhaustruct
+		Answers: map[string]namecheap.Result{
+			"example.com": {
+				Domain:                    "example.com",
+				Available:                 false,
+				Availability:              "",
+				Status:                    "",
+				IsPremiumName:             false,
+				PremiumRegistrationPrice:  0,
+				PremiumRenewalPrice:       0,
+				IcannFee:                  0,
+				EapFee:                    0,
+				StandardRegistrationPrice: 0,
+				Currency:                  "",
+				Error:                     "",
+				ErrorCode:                 0,
+				RegisterURL:               "",
+				Blocked:                   false,
+				BlockReason:               "",
+				CorrelationID:             "",
+				Input:                     "",
+				ReferencePrice:            0,
+				ReferencePriceDelta:       0,
+				Raw:                       nil,
+			},
+			"fresh.io": {
+				Domain:                    "fresh.io",
+				Available:                 true,
+				Availability:              "",
+				Status:                    "",
+				IsPremiumName:             true,
+				PremiumRegistrationPrice:  120.5,
+				PremiumRenewalPrice:       40,
+				IcannFee:                  0.18,
+				EapFee:                    0,
+				StandardRegistrationPrice: 0,
+				Currency:                  "USD",
+				Error:                     "",
+				ErrorCode:                 0,
+				RegisterURL:               "",
+				Blocked:                   false,
+				BlockReason:               "",
+				CorrelationID:             "",
+				Input:                     "",
+				ReferencePrice:            0,
+				ReferencePriceDelta:       0,
+				Raw:                       nil,
+			},
+			"plain.net": {
+				Domain:                    "plain.net",
+				Available:                 true,
+				Availability:              "",
+				Status:                    "",
+				IsPremiumName:             false,
+				PremiumRegistrationPrice:  0,
+				PremiumRenewalPrice:       0,
+				IcannFee:                  0,
+				EapFee:                    0,
+				StandardRegistrationPrice: 12.48,
+				Currency:                  "EUR",
+				Error:                     "",
+				ErrorCode:                 0,
+				RegisterURL:               "",
+				Blocked:                   false,
+				BlockReason:               "",
+				CorrelationID:             "",
+				Input:                     "",
+				ReferencePrice:            0,
+				ReferencePriceDelta:       0,
+				Raw:                       nil,
+			},
+		},
+	}
+
+	service := csvcheck.NewService(checker)
+
+	input := "owner,Name,notes\n" +
+		"alice,example.com,\"first, idea\"\n" +
+		"bob,,skip me\n" +
+		"carol,fresh.io,second\n" +
+		"dave,https://Example.com/,repeat\n" +
+		"erin,plain.net,third\n"
+
+	out, err := service.Execute(context.Background(), csvcheck.ParamsIn{CSV: input, DomainColumn: "name"})
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+
+	want := "owner,Name,notes,available,is_premium,premium_registration_price," +
+		"premium_renewal_price,icann_fee,eap_fee,standard_registration_price,currency,error\n" +
+		"alice,example.com,\"first, idea\",false,false,0,0,0,0,0,,\n" +
+		"bob,,skip me,,,,,,,,,\n" +
+		"carol,fresh.io,second,true,true,120.5,40,0.18,0,0,USD,\n" +
+		"dave,https://Example.com/,repeat,false,false,0,0,0,0,0,,\n" +
+		"erin,plain.net,third,true,false,0,0,0,0,12.48,EUR,\n"
+
+	if out.CSV != want {
+		t.Errorf("Execute() CSV =\n%s\nwant\n%s", out.CSV, want)
+	}
+
+	if got := strings.Join(checker.Checked(), ","); got != "example.com,fresh.io,plain.net" {
+		t.Errorf("checked domains = %q, want each domain checked once", got)
+	}
+}
+
+func TestExecute_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		in      csvcheck.ParamsIn
+		wantErr error
+	}{
+		{
+			name:    "empty CSV",
+			in:      csvcheck.ParamsIn{CSV: "  ", DomainColumn: ""},
+			wantErr: csvcheck.ErrMissingCSV,
+		},
+		{
+			name:    "default column missing",
+			in:      csvcheck.ParamsIn{CSV: "name\nexample.com\n", DomainColumn: ""},
+			wantErr: csvcheck.ErrDomainColumnNotFound,
+		},
+		{
+			name:    "named column missing",
+			in:      csvcheck.ParamsIn{CSV: "domain\nexample.com\n", DomainColumn: "url"},
+			wantErr: csvcheck.ErrDomainColumnNotFound,
+		},
+		{
+			name:    "malformed CSV",
+			in:      csvcheck.ParamsIn{CSV: "domain\nexa\"mple.com\n", DomainColumn: ""},
+			wantErr: csv.ErrBareQuote,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			service := csvcheck.NewService(&namecheaptest.Checker{}) //nolint:exhaustruct
+
+			_, err := service.Execute(context.Background(), tt.in)
+			if !errors.Is(err, tt.wantErr) || !errors.Is(err, tool.ErrInvalidInput) {
+				t.Errorf("Execute() error = %v, wantErr %v and %v", err, tt.wantErr, tool.ErrInvalidInput)
+			}
+		})
+	}
+}
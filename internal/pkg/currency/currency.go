@@ -0,0 +1,158 @@
+// Package currency converts the prices and fees of check results into another currency,
+// e.g. so international users see premium prices in their local currency. Checker
+// decorates any namecheap.DomainChecker; the exchange rates come from an
+// ExchangeRateProvider, either a static table or rates fetched from an HTTP API.
+package currency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"go.uber.org/zap"
+)
+
+// ErrUnknownCurrency is returned for a currency the rates don't cover.
+var ErrUnknownCurrency = errors.New("no exchange rate for currency")
+
+// ExchangeRateProvider returns exchange rates between ISO 4217 currencies.
+type ExchangeRateProvider interface {
+	// Rate returns how many units of to one unit of from is worth.
+	Rate(ctx context.Context, from, to string) (float64, error)
+}
+
+// StaticRates maps uppercase ISO 4217 codes to their units per US dollar, e.g.
+// "EUR": 0.92. USD itself is always 1 and needn't be listed.
+type StaticRates map[string]float64
+
+// Rate returns the exchange rate from one currency to another through their US dollar rates.
+func (s StaticRates) Rate(_ context.Context, from, to string) (float64, error) {
+	return crossRate(s, namecheap.CurrencyUSD, from, to)
+}
+
+// crossRate returns the rate from one currency to another, given rates as units per unit
+// of base.
+func crossRate(rates map[string]float64, base, from, to string) (float64, error) {
+	lookup := func(code string) (float64, error) {
+		code = strings.ToUpper(code)
+		if code == base {
+			return 1, nil
+		}
+
+		rate, ok := rates[code]
+		if !ok || rate <= 0 {
+			return 0, fmt.Errorf("%w: %s", ErrUnknownCurrency, code)
+		}
+
+		return rate, nil
+	}
+
+	fromRate, err := lookup(from)
+	if err != nil {
+		return 0, err
+	}
+
+	toRate, err := lookup(to)
+	if err != nil {
+		return 0, err
+	}
+
+	return toRate / fromRate, nil
+}
+
+// Checker is a DomainChecker that converts the prices and fees of the results into a
+// target currency and sets their Currency accordingly. Results without prices, already
+// in the target currency, or whose currency has no rate are returned untouched, so a
+// result's prices always match its Currency.
+type Checker struct {
+	logger  *zap.Logger
+	checker namecheap.DomainChecker
+	rates   ExchangeRateProvider
+	target  string
+}
+
+// NewChecker wraps checker so its prices are converted into target using rates.
+func NewChecker(logger *zap.Logger, checker namecheap.DomainChecker, rates ExchangeRateProvider,
+	target string,
+) *Checker {
+	return &Checker{
+		logger:  logger,
+		checker: checker,
+		rates:   rates,
+		target:  strings.ToUpper(target),
+	}
+}
+
+// Name returns the name of the wrapped checker.
+func (c *Checker) Name() string {
+	return c.checker.Name()
+}
+
+// Description returns the description of the wrapped checker.
+func (c *Checker) Description() string {
+	return c.checker.Description()
+}
+
+// DomainsCheck checks domains with the wrapped checker and converts the priced results
+// into the target currency. Each source currency's rate is looked up once per check.
+func (c *Checker) DomainsCheck(ctx context.Context, domains []string) ([]namecheap.Result, error) {
+	results, err := c.checker.DomainsCheck(ctx, domains)
+	if err != nil && !namecheap.IsPartial(err) {
+		return nil, err
+	}
+
+	// rates holds the rate of each source currency seen; zero marks a failed lookup.
+	rates := make(map[string]float64)
+
+	for i := range results {
+		from := strings.ToUpper(results[i].Currency)
+		if from == "" || from == c.target {
+			continue
+		}
+
+		rate, ok := rates[from]
+		if !ok {
+			var rateErr error
+
+			rate, rateErr = c.rates.Rate(ctx, from, c.target)
+			if rateErr != nil {
+				c.logger.Warn("Failed to get exchange rate; prices left unconverted",
+					zap.String("from", from),
+					zap.String("to", c.target),
+					zap.Error(rateErr),
+				)
+
+				rate = 0
+			}
+
+			rates[from] = rate
+		}
+
+		if rate > 0 {
+			convert(&results[i], rate, c.target)
+		}
+	}
+
+	return results, err
+}
+
+// convert multiplies every amount of result by rate and sets its Currency to target. The
+// reference prices are in the result's currency, as refprice only compares US dollar
+// prices with its US dollar table, so they are converted alike.
+func convert(result *namecheap.Result, rate float64, target string) {
+	for _, amount := range []*float64{
+		&result.PremiumRegistrationPrice,
+		&result.PremiumRenewalPrice,
+		&result.IcannFee,
+		&result.EapFee,
+		&result.StandardRegistrationPrice,
+		&result.ReferencePrice,
+		&result.ReferencePriceDelta,
+	} {
+		*amount = namecheap.RoundCents(*amount * rate)
+	}
+
+	result.Currency = target
+}
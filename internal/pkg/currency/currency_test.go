@@ -0,0 +1,162 @@
+package currency_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/currency"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap/namecheaptest"
+	"go.uber.org/zap"
+)
+
+func TestStaticRates_Rate(t *testing.T) {
+	t.Parallel()
+
+	rates := currency.StaticRates{"EUR": 0.5, "GBP": 0.8}
+
+	tests := []struct {
+		from    string
+		to      string
+		want    float64
+		wantErr error
+	}{
+		{from: "USD", to: "EUR", want: 0.5, wantErr: nil},
+		{from: "eur", to: "usd", want: 2, wantErr: nil},
+		{from: "EUR", to: "GBP", want: 1.6, wantErr: nil},
+		{from: "USD", to: "USD", want: 1, wantErr: nil},
+		{from: "USD", to: "JPY", want: 0, wantErr: currency.ErrUnknownCurrency},
+	}
+
+	for _, tt := range tests {
+		got, err := rates.Rate(context.Background(), tt.from, tt.to)
+		if !errors.Is(err, tt.wantErr) || got != tt.want {
+			t.Errorf("Rate(%s, %s) = %v, %v; want %v, %v", tt.from, tt.to, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestChecker_ConvertsPrices(t *testing.T) {
+	t.Parallel()
+
+	fake := &namecheaptest.Checker{Answers: map[string]namecheap.Result{ //nolint:exhaustruct
+		"premium.com": { //nolint:exhaustruct
+			PremiumRegistrationPrice: 100,
+			PremiumRenewalPrice:      50,
+			IcannFee:                 0.18,
+			EapFee:                   10,
+			Currency:                 namecheap.CurrencyUSD,
+		},
+		"local.eu": { //nolint:exhaustruct
+			PremiumRegistrationPrice: 7,
+			Currency:                 "EUR",
+		},
+		"odd.jp": { //nolint:exhaustruct
+			PremiumRegistrationPrice: 1500,
+			Currency:                 "JPY",
+		},
+		"free.com": {}, //nolint:exhaustruct
+	}}
+	checker := currency.NewChecker(zap.NewNop(), fake, currency.StaticRates{"EUR": 0.9}, "eur")
+
+	results, err := checker.DomainsCheck(context.Background(), []string{"premium.com", "local.eu", "odd.jp", "free.com"})
+	if err != nil {
+		t.Fatalf("DomainsCheck() unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		domain       string
+		wantPrice    float64
+		wantRenewal  float64
+		wantIcann    float64
+		wantEap      float64
+		wantCurrency string
+	}{
+		{domain: "premium.com", wantPrice: 90, wantRenewal: 45, wantIcann: 0.16, wantEap: 9, wantCurrency: "EUR"},
+		{domain: "local.eu", wantPrice: 7, wantRenewal: 0, wantIcann: 0, wantEap: 0, wantCurrency: "EUR"},
+		// Without a rate the prices stay in their own currency.
+		{domain: "odd.jp", wantPrice: 1500, wantRenewal: 0, wantIcann: 0, wantEap: 0, wantCurrency: "JPY"},
+		{domain: "free.com", wantPrice: 0, wantRenewal: 0, wantIcann: 0, wantEap: 0, wantCurrency: ""},
+	}
+
+	for i, tt := range tests {
+		got := results[i]
+		if got.Domain != tt.domain || got.PremiumRegistrationPrice != tt.wantPrice ||
+			got.PremiumRenewalPrice != tt.wantRenewal || got.IcannFee != tt.wantIcann || got.EapFee != tt.wantEap ||
+			got.Currency != tt.wantCurrency {
+			t.Errorf("%s: got %+v, want %+v", got.Domain, got, tt)
+		}
+	}
+}
+
+func TestLiveRates_FetchesAndCaches(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"amount":1.0,"base":"USD","rates":{"EUR":0.5,"GBP":0.8}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	rates := currency.NewLiveRates(zap.NewNop(), currency.LiveRatesConfig{
+		URL:            server.URL,
+		TTL:            0,
+		RequestTimeout: 0,
+	})
+
+	var wg sync.WaitGroup
+
+	// Concurrent first calls share one fetch.
+	for range 8 {
+		wg.Go(func() {
+			got, err := rates.Rate(context.Background(), "EUR", "GBP")
+			if err != nil || got != 1.6 {
+				t.Errorf("Rate(EUR, GBP) = %v, %v; want 1.6, nil", got, err)
+			}
+		})
+	}
+
+	wg.Wait()
+
+	if got := requests.Load(); got != 1 {
+		t.Errorf("rates fetched %d times, want 1", got)
+	}
+}
+
+func TestLiveRates_APIError(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	rates := currency.NewLiveRates(zap.NewNop(), currency.LiveRatesConfig{
+		URL:            server.URL,
+		TTL:            0,
+		RequestTimeout: 0,
+	})
+
+	for range 2 {
+		_, err := rates.Rate(context.Background(), "USD", "EUR")
+		if !errors.Is(err, currency.ErrRatesAPIFailed) {
+			t.Errorf("Rate() error = %v, want %v", err, currency.ErrRatesAPIFailed)
+		}
+	}
+
+	// The failure is reported without asking the API again.
+	if got := requests.Load(); got != 1 {
+		t.Errorf("rates fetched %d times, want 1", got)
+	}
+}
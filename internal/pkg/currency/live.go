@@ -0,0 +1,197 @@
+package currency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultLiveRatesURL serves the European Central Bank reference rates against the US
+	// dollar, without an API key.
+	DefaultLiveRatesURL = "https://api.frankfurter.app/latest?from=USD"
+
+	// defaultLiveRatesTTL is how long fetched rates are reused when LiveRatesConfig.TTL is unset.
+	defaultLiveRatesTTL = time.Hour
+	// defaultRequestTimeout is the HTTP timeout when LiveRatesConfig.RequestTimeout is unset.
+	defaultRequestTimeout = 10 * time.Second
+	// failureBackoff is how long a failed fetch is reported before the API is tried again,
+	// so an unreachable API doesn't cost every check a request timeout.
+	failureBackoff = time.Minute
+)
+
+// ErrRatesAPIFailed is returned when the exchange rate API can't be reached or answers
+// with an error status.
+var ErrRatesAPIFailed = errors.New("exchange rate API failed")
+
+// LiveRatesConfig holds the configuration for fetching exchange rates over HTTP.
+type LiveRatesConfig struct {
+	// URL answers GET with {"base": "USD", "rates": {"EUR": 0.92, ...}}; empty uses DefaultLiveRatesURL
+	URL string
+	// TTL is how long fetched rates are reused; zero uses one hour
+	TTL time.Duration
+	// RequestTimeout bounds each request; zero uses a 10 second default
+	RequestTimeout time.Duration
+}
+
+// ratesResponse is the answer of the exchange rate API.
+type ratesResponse struct {
+	Base  string             `json:"base"`
+	Rates map[string]float64 `json:"rates"`
+}
+
+// LiveRates is an ExchangeRateProvider fetching its rates from an HTTP API. The rates
+// are fetched on first use and refetched once they are older than the TTL. Concurrent
+// callers share a single fetch, and a failed fetch is reported for a minute before the
+// API is tried again.
+type LiveRates struct {
+	logger *zap.Logger
+	config LiveRatesConfig
+	client *http.Client
+
+	mu        sync.Mutex
+	base      string
+	rates     map[string]float64
+	fetchedAt time.Time
+	// fetching is closed when the fetch in flight completes; nil when none is
+	fetching chan struct{}
+	// fetchErr is the error of the last fetch, reported until failedAt plus failureBackoff
+	fetchErr error
+	failedAt time.Time
+}
+
+// NewLiveRates creates a provider fetching rates as configured. Nothing is fetched
+// until the first Rate call.
+func NewLiveRates(logger *zap.Logger, config LiveRatesConfig) *LiveRates {
+	if config.URL == "" {
+		config.URL = DefaultLiveRatesURL
+	}
+
+	if config.TTL <= 0 {
+		config.TTL = defaultLiveRatesTTL
+	}
+
+	if config.RequestTimeout <= 0 {
+		config.RequestTimeout = defaultRequestTimeout
+	}
+
+	return &LiveRates{
+		logger:    logger,
+		config:    config,
+		client:    &http.Client{Timeout: config.RequestTimeout}, //nolint:exhaustruct
+		mu:        sync.Mutex{},
+		base:      "",
+		rates:     nil,
+		fetchedAt: time.Time{},
+		fetching:  nil,
+		fetchErr:  nil,
+		failedAt:  time.Time{},
+	}
+}
+
+// Rate returns the exchange rate from one currency to another, fetching the rates
+// first when none are cached or they expired. Callers arriving during a fetch wait for
+// it instead of starting their own.
+func (l *LiveRates) Rate(ctx context.Context, from, to string) (float64, error) {
+	for {
+		l.mu.Lock()
+
+		switch {
+		case l.rates != nil && time.Since(l.fetchedAt) < l.config.TTL:
+			rates, base := l.rates, l.base
+			l.mu.Unlock()
+
+			return crossRate(rates, base, from, to)
+		case l.fetchErr != nil && time.Since(l.failedAt) < failureBackoff:
+			err := l.fetchErr
+			l.mu.Unlock()
+
+			return 0, err
+		case l.fetching != nil:
+			fetching := l.fetching
+			l.mu.Unlock()
+
+			select {
+			case <-fetching:
+				continue
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			}
+		}
+
+		fetching := make(chan struct{})
+		l.fetching = fetching
+		l.mu.Unlock()
+
+		// The fetch is shared, so it isn't cut short when this caller gives up.
+		base, rates, err := l.fetch(context.WithoutCancel(ctx))
+
+		l.mu.Lock()
+
+		if err != nil {
+			l.fetchErr = err
+			l.failedAt = time.Now()
+		} else {
+			l.base = base
+			l.rates = rates
+			l.fetchedAt = time.Now()
+			l.fetchErr = nil
+		}
+
+		l.fetching = nil
+		close(fetching)
+		l.mu.Unlock()
+	}
+}
+
+// fetch returns the base currency and rates of the API.
+func (l *LiveRates) fetch(ctx context.Context) (string, map[string]float64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, l.config.URL, nil)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %w", ErrRatesAPIFailed, err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", nil, fmt.Errorf("%w: HTTP %d", ErrRatesAPIFailed, resp.StatusCode)
+	}
+
+	var ratesResp ratesResponse
+
+	err = json.NewDecoder(resp.Body).Decode(&ratesResp)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: failed to decode JSON response: %w", ErrRatesAPIFailed, err)
+	}
+
+	if ratesResp.Base == "" || len(ratesResp.Rates) == 0 {
+		return "", nil, fmt.Errorf("%w: response has no base or rates", ErrRatesAPIFailed)
+	}
+
+	rates := make(map[string]float64, len(ratesResp.Rates))
+	for code, rate := range ratesResp.Rates {
+		rates[strings.ToUpper(code)] = rate
+	}
+
+	base := strings.ToUpper(ratesResp.Base)
+
+	l.logger.Debug("Fetched exchange rates", zap.String("base", base), zap.Int("rate_count", len(rates)))
+
+	return base, rates, nil
+}
@@ -0,0 +1,137 @@
+// Package dnsprecheck saves registrar API calls by looking domains up in DNS first. A
+// domain that has nameservers is almost certainly registered, so Checker reports it taken
+// without asking the wrapped checker; everything else, NXDOMAIN and lookup failures
+// alike, is left to the wrapped checker. Parked or CDN-fronted domains that were dropped
+// but still resolve can be reported taken by mistake, which is why the pre-check is opt-in.
+package dnsprecheck
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"go.uber.org/zap"
+)
+
+// maxConcurrentLookups bounds the DNS lookups in flight for one DomainsCheck call.
+const maxConcurrentLookups = 8
+
+// Resolver looks up the nameservers of a domain. *net.Resolver implements it.
+type Resolver interface {
+	LookupNS(ctx context.Context, name string) ([]*net.NS, error)
+}
+
+// Checker is a DomainChecker that reports domains with nameservers as taken and sends
+// only the rest to the wrapped checker.
+type Checker struct {
+	logger   *zap.Logger
+	checker  namecheap.DomainChecker
+	resolver Resolver
+}
+
+// NewChecker wraps checker with a DNS pre-check made with resolver.
+func NewChecker(logger *zap.Logger, checker namecheap.DomainChecker, resolver Resolver) *Checker {
+	return &Checker{
+		logger:   logger,
+		checker:  checker,
+		resolver: resolver,
+	}
+}
+
+// Name returns the name of the wrapped checker.
+func (c *Checker) Name() string {
+	return c.checker.Name()
+}
+
+// Description returns the description of the wrapped checker.
+func (c *Checker) Description() string {
+	return c.checker.Description()
+}
+
+// DomainsCheck resolves the nameservers of every domain, bounded by ctx, and checks the
+// domains without any with the wrapped checker. Results are returned in input order.
+func (c *Checker) DomainsCheck(ctx context.Context, domains []string) ([]namecheap.Result, error) {
+	if len(domains) == 0 {
+		return nil, namecheap.ErrMissingDomains
+	}
+
+	delegated := c.lookupAll(ctx, domains)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	results := make([]namecheap.Result, len(domains))
+
+	// pending holds the indexes of the domains left to the wrapped checker.
+	var pending []int
+
+	for i, domain := range domains {
+		if delegated[i] {
+			results[i] = takenResult(domain)
+
+			continue
+		}
+
+		pending = append(pending, i)
+	}
+
+	c.logger.Debug("DNS pre-check completed",
+		zap.Int("registered", len(domains)-len(pending)),
+		zap.Int("pending", len(pending)),
+	)
+
+	if len(pending) == 0 {
+		return results, nil
+	}
+
+	names := make([]string, 0, len(pending))
+	for _, i := range pending {
+		names = append(names, domains[i])
+	}
+
+	checked, err := c.checker.DomainsCheck(ctx, names)
+	if err != nil && !namecheap.IsPartial(err) {
+		return nil, err
+	}
+
+	// The wrapped checker may answer in its own order or leave domains out.
+	for j, result := range namecheap.MatchResults(names, checked) {
+		results[pending[j]] = result
+	}
+
+	return results, err
+}
+
+// lookupAll reports, per domain, whether it has nameservers. Failed lookups count as
+// no nameservers so the wrapped checker decides.
+func (c *Checker) lookupAll(ctx context.Context, domains []string) []bool {
+	delegated := make([]bool, len(domains))
+	slots := make(chan struct{}, maxConcurrentLookups)
+
+	var wg sync.WaitGroup
+
+	for i, domain := range domains {
+		wg.Go(func() {
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			servers, err := c.resolver.LookupNS(ctx, namecheap.NormalizeDomain(domain))
+			delegated[i] = err == nil && len(servers) > 0
+		})
+	}
+
+	wg.Wait()
+
+	return delegated
+}
+
+// takenResult is the result of a domain the DNS shows as registered.
+func takenResult(domain string) namecheap.Result {
+	return namecheap.Result{ //nolint:exhaustruct
+		Domain:       domain,
+		Available:    false,
+		Availability: namecheap.AvailabilityTaken,
+		Status:       namecheap.StatusRegistered,
+	}
+}
@@ -0,0 +1,109 @@
+package dnsprecheck_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/dnsprecheck"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap/namecheaptest"
+	"go.uber.org/zap"
+)
+
+// fakeResolver implements dnsprecheck.Resolver; domains in registered have a nameserver,
+// the others fail like NXDOMAIN.
+type fakeResolver struct {
+	registered map[string]bool
+}
+
+func (f fakeResolver) LookupNS(ctx context.Context, name string) ([]*net.NS, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	if f.registered[name] {
+		return []*net.NS{{Host: "ns1.example.net."}}, nil
+	}
+
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true} //nolint:exhaustruct
+}
+
+func TestChecker_SkipsRegisteredDomains(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		domains       []string
+		wantChecked   []string
+		wantAvailable []bool
+	}{
+		{
+			name:          "only NXDOMAIN domains reach the checker",
+			domains:       []string{"google.com", "fresh-name.com", "Example.org", "another-fresh.io"},
+			wantChecked:   []string{"fresh-name.com", "another-fresh.io"},
+			wantAvailable: []bool{false, true, false, true},
+		},
+		{
+			name:          "all registered skips the checker",
+			domains:       []string{"google.com", "example.org"},
+			wantChecked:   nil,
+			wantAvailable: []bool{false, false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			inner := &namecheaptest.Checker{} //nolint:exhaustruct
+			resolver := fakeResolver{registered: map[string]bool{"google.com": true, "example.org": true}}
+			checker := dnsprecheck.NewChecker(zap.NewNop(), inner, resolver)
+
+			results, err := checker.DomainsCheck(context.Background(), tt.domains)
+			if err != nil {
+				t.Fatalf("DomainsCheck() unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(inner.Checked(), tt.wantChecked) {
+				t.Errorf("checked = %v, want %v", inner.Checked(), tt.wantChecked)
+			}
+
+			if len(results) != len(tt.domains) {
+				t.Fatalf("got %d results, want %d", len(results), len(tt.domains))
+			}
+
+			for i, result := range results {
+				if result.Domain != tt.domains[i] || result.Available != tt.wantAvailable[i] {
+					t.Errorf("results[%d] = %s available %v, want %s available %v",
+						i, result.Domain, result.Available, tt.domains[i], tt.wantAvailable[i])
+				}
+
+				if !result.Available && result.Status != namecheap.StatusRegistered {
+					t.Errorf("results[%d].Status = %q, want %q", i, result.Status, namecheap.StatusRegistered)
+				}
+			}
+		})
+	}
+}
+
+func TestChecker_ContextCancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	inner := &namecheaptest.Checker{} //nolint:exhaustruct
+	checker := dnsprecheck.NewChecker(zap.NewNop(), inner, fakeResolver{registered: nil})
+
+	_, err := checker.DomainsCheck(ctx, []string{"fresh-name.com"})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("DomainsCheck() error = %v, want %v", err, context.Canceled)
+	}
+
+	if len(inner.Checked()) != 0 {
+		t.Errorf("checked = %v, want no checker call after cancellation", inner.Checked())
+	}
+}
@@ -0,0 +1,363 @@
+// Package dynadot provides domain availability and registration prices through the
+// Dynadot API3 XML interface. Its Service implements namecheap.DomainChecker, so it plugs
+// into the same tools and decorators as the other providers.
+package dynadot
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/limit"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/metrics"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/requestid"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/retry"
+	"go.uber.org/zap"
+)
+
+const (
+	// metricsProvider labels the metrics of this backend.
+	metricsProvider = "dynadot"
+
+	// DefaultEndpoint is the Dynadot API3 XML endpoint.
+	DefaultEndpoint = "https://api.dynadot.com/api3.xml"
+
+	// defaultRequestTimeout is the per-request HTTP timeout when Config.RequestTimeout is unset.
+	defaultRequestTimeout = 30 * time.Second
+	// retryBackoff is the delay before the first retry.
+	retryBackoff = 250 * time.Millisecond
+	// defaultMaxDomainsPerCheck is the batch size when Config.MaxDomainsPerCheck is unset,
+	// the most domains Dynadot accepts in one search.
+	defaultMaxDomainsPerCheck = 100
+
+	// commandSearch is the API command that checks domain availability.
+	commandSearch = "search"
+	// successCode is the SuccessCode and ResponseCode of a successful answer.
+	successCode = "0"
+	// availableYes is the Available value of an available domain.
+	availableYes = "yes"
+)
+
+var (
+	// ErrMissingAPIKey is returned when the API key is missing.
+	ErrMissingAPIKey = errors.New("missing Dynadot API key")
+	// ErrAPIError is returned when the Dynadot API rejects a request.
+	ErrAPIError = errors.New("dynadot API error")
+	// ErrNotInResponse is reported per domain when the answer leaves it out.
+	ErrNotInResponse = errors.New("domain missing from Dynadot response")
+)
+
+// pricePattern matches the amount and currency of a Price such as "8.99 in USD".
+//
+//nolint:gochecknoglobals
+var pricePattern = regexp.MustCompile(`([0-9][0-9,]*(?:\.[0-9]+)?) in ([A-Z]{3})`)
+
+// Config holds the configuration for the Dynadot API client.
+type Config struct {
+	// APIKey is the Dynadot API key
+	APIKey string
+	// Endpoint is the URL of the API3 XML endpoint; empty uses DefaultEndpoint
+	Endpoint string
+	// MaxDomainsPerCheck is how many domains each search request checks; zero or less uses 100
+	MaxDomainsPerCheck int
+	// RequestTimeout bounds each API request; zero uses a 30 second default
+	RequestTimeout time.Duration
+	// MaxRetries is how many times a transient network failure is retried; zero disables retries
+	MaxRetries int
+	// RateLimitPerSecond caps outbound API requests per second; zero or less means unlimited
+	RateLimitPerSecond float64
+	// IncludeRaw attaches the raw Available and Price values of each answer to its Result
+	IncludeRaw bool
+}
+
+// SearchResults is the answer of a search command. A request Dynadot rejects as a whole,
+// e.g. for a wrong key, is answered with a ResponseHeader instead of SearchResponses.
+type SearchResults struct {
+	SearchResponses []SearchResponse `xml:"SearchResponse"`
+	ResponseHeader  ResponseHeader   `xml:"ResponseHeader"`
+}
+
+// SearchResponse wraps the answer for one domain.
+type SearchResponse struct {
+	SearchHeader SearchHeader `xml:"SearchHeader"`
+}
+
+// SearchHeader is the availability and price of one domain.
+type SearchHeader struct {
+	SuccessCode string `xml:"SuccessCode"`
+	DomainName  string `xml:"DomainName"`
+	Available   string `xml:"Available"`
+	Price       string `xml:"Price"`
+	Error       string `xml:"Error"`
+}
+
+// ResponseHeader reports the outcome of a request rejected as a whole.
+type ResponseHeader struct {
+	ResponseCode string `xml:"ResponseCode"`
+	Error        string `xml:"Error"`
+}
+
+// Service checks domain availability using the Dynadot API.
+type Service struct {
+	logger *zap.Logger
+	config Config
+	client *http.Client
+}
+
+// NewService creates a new Dynadot service with the given configuration.
+// Returns ErrMissingAPIKey if the API key is missing.
+func NewService(logger *zap.Logger, config Config) (*Service, error) {
+	if config.APIKey == "" {
+		return nil, ErrMissingAPIKey
+	}
+
+	if config.Endpoint == "" {
+		config.Endpoint = DefaultEndpoint
+	}
+
+	if config.MaxDomainsPerCheck <= 0 {
+		config.MaxDomainsPerCheck = defaultMaxDomainsPerCheck
+	}
+
+	timeout := config.RequestTimeout
+	if timeout <= 0 {
+		timeout = defaultRequestTimeout
+	}
+
+	return &Service{
+		logger: logger,
+		config: config,
+		client: &http.Client{ //nolint:exhaustruct
+			Timeout: timeout,
+			Transport: retry.NewTransport(
+				limit.NewTransport(http.DefaultTransport, config.RateLimitPerSecond), config.MaxRetries, retryBackoff),
+		},
+	}, nil
+}
+
+// Name returns the name of the Dynadot service.
+func (s *Service) Name() string {
+	return "check_availability_dynadot"
+}
+
+// Description returns a description of the Dynadot service.
+func (s *Service) Description() string {
+	return "Check domain availability and one-year prices using Dynadot API"
+}
+
+// DomainsCheck checks domains with search requests of up to Config.MaxDomainsPerCheck
+// domains, returning the results in input order. A domain Dynadot couldn't check is
+// reported in its Result.Error; a failed request fails the whole check.
+func (s *Service) DomainsCheck(ctx context.Context, domains []string) ([]namecheap.Result, error) {
+	if len(domains) == 0 {
+		return nil, namecheap.ErrMissingDomains
+	}
+
+	metrics.DomainsCheck(metricsProvider, len(domains))
+
+	results := make([]namecheap.Result, 0, len(domains))
+
+	for start := 0; start < len(domains); start += s.config.MaxDomainsPerCheck {
+		batch := domains[start:min(start+s.config.MaxDomainsPerCheck, len(domains))]
+
+		resp, err := s.search(ctx, batch)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, s.batchResults(batch, resp)...)
+	}
+
+	return results, nil
+}
+
+// VerifyCredentials makes a single search and returns the API error when the key is
+// rejected.
+func (s *Service) VerifyCredentials(ctx context.Context) error {
+	_, err := s.search(ctx, []string{"dynadot.com"})
+	if err != nil {
+		return fmt.Errorf("failed to verify credentials: %w", err)
+	}
+
+	return nil
+}
+
+// batchResults maps a search answer back to batch, in order.
+func (s *Service) batchResults(batch []string, resp SearchResults) []namecheap.Result {
+	headers := make(map[string]SearchHeader, len(resp.SearchResponses))
+	for _, entry := range resp.SearchResponses {
+		headers[strings.ToLower(entry.SearchHeader.DomainName)] = entry.SearchHeader
+	}
+
+	results := make([]namecheap.Result, 0, len(batch))
+
+	for _, domain := range batch {
+		header, ok := headers[strings.ToLower(domain)]
+
+		switch {
+		case !ok:
+			results = append(results, errorResult(domain, ErrNotInResponse.Error()))
+		case header.SuccessCode != successCode:
+			results = append(results, errorResult(domain, header.Error))
+		default:
+			results = append(results, s.result(domain, header))
+		}
+	}
+
+	return results
+}
+
+// result maps a search answer to a Result. Dynadot doesn't flag premium names, so the
+// quoted price of an available domain is reported as its registration price.
+func (s *Service) result(domain string, header SearchHeader) namecheap.Result {
+	available := strings.EqualFold(header.Available, availableYes)
+
+	result := namecheap.Result{
+		Domain:                    domain,
+		Available:                 available,
+		Availability:              namecheap.AvailabilityTaken,
+		Status:                    "",
+		IsPremiumName:             false,
+		PremiumRegistrationPrice:  0,
+		PremiumRenewalPrice:       0,
+		IcannFee:                  0,
+		EapFee:                    0,
+		StandardRegistrationPrice: 0,
+		Currency:                  "",
+		Error:                     "",
+		ErrorCode:                 0,
+		RegisterURL:               "",
+		Blocked:                   false,
+		BlockReason:               "",
+		CorrelationID:             "",
+		Input:                     "",
+		ReferencePrice:            0,
+		ReferencePriceDelta:       0,
+		Raw:                       nil,
+	}
+
+	if available {
+		result.Availability = namecheap.AvailabilityAvailable
+		result.PremiumRegistrationPrice, result.Currency = parsePrice(header.Price)
+	}
+
+	result.Status = namecheap.StatusOf(result)
+
+	if s.config.IncludeRaw {
+		result.Raw = map[string]string{
+			"available": header.Available,
+			"price":     header.Price,
+		}
+	}
+
+	return result
+}
+
+// errorResult is the Result of a domain Dynadot couldn't check.
+func errorResult(domain, message string) namecheap.Result {
+	if message == "" {
+		message = "unknown error"
+	}
+
+	return namecheap.Result{ //nolint:exhaustruct
+		Domain:       domain,
+		Availability: namecheap.AvailabilityUnknown,
+		Status:       namecheap.StatusError,
+		Error:        message,
+	}
+}
+
+// parsePrice returns the first amount and currency quoted in price, or zero and "" when
+// it quotes none.
+func parsePrice(price string) (float64, string) {
+	match := pricePattern.FindStringSubmatch(price)
+	if match == nil {
+		return 0, ""
+	}
+
+	amount, err := strconv.ParseFloat(strings.ReplaceAll(match[1], ",", ""), 64)
+	if err != nil {
+		return 0, ""
+	}
+
+	return amount, match[2]
+}
+
+// search checks domains with one search request.
+func (s *Service) search(ctx context.Context, domains []string) (SearchResults, error) {
+	reqURL, err := s.searchURL(domains)
+	if err != nil {
+		return SearchResults{}, err
+	}
+
+	s.logger.Debug("Making Dynadot API call", zap.Int("domain_count", len(domains)), requestid.Field(ctx))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return SearchResults{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	start := time.Now()
+	resp, err := s.client.Do(req)
+	metrics.UpstreamRequest(metricsProvider, time.Since(start), resp, err)
+
+	if err != nil {
+		// The error quotes the request URL, key included.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			urlErr.URL = s.config.Endpoint
+		}
+
+		return SearchResults{}, fmt.Errorf("HTTP request failed: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return SearchResults{}, fmt.Errorf("%w: HTTP %d", ErrAPIError, resp.StatusCode)
+	}
+
+	var results SearchResults
+
+	err = xml.NewDecoder(resp.Body).Decode(&results)
+	if err != nil {
+		return SearchResults{}, fmt.Errorf("failed to decode XML response: %w", err)
+	}
+
+	if results.ResponseHeader.ResponseCode != "" && results.ResponseHeader.ResponseCode != successCode {
+		return SearchResults{}, fmt.Errorf("%w: %s", ErrAPIError, results.ResponseHeader.Error)
+	}
+
+	return results, nil
+}
+
+// searchURL builds the search request URL, numbering the domains domain0, domain1 and so on.
+func (s *Service) searchURL(domains []string) (string, error) {
+	endpoint, err := url.Parse(s.config.Endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid endpoint: %w", err)
+	}
+
+	params := url.Values{}
+	params.Set("key", s.config.APIKey)
+	params.Set("command", commandSearch)
+	params.Set("show_price", "1")
+
+	for i, domain := range domains {
+		params.Set("domain"+strconv.Itoa(i), domain)
+	}
+
+	endpoint.RawQuery = params.Encode()
+
+	return endpoint.String(), nil
+}
@@ -0,0 +1,210 @@
+package dynadot_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/dynadot"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"go.uber.org/zap"
+)
+
+// answers are the stubbed SearchHeader bodies, keyed by domain. Domains not listed are
+// left out of the answer.
+//
+//nolint:gochecknoglobals
+var answers = map[string]string{
+	"fresh.com": `<SuccessCode>0</SuccessCode><DomainName>fresh.com</DomainName>` +
+		`<Available>yes</Available><Price>1,208.99 in USD</Price>`,
+	"taken.com": `<SuccessCode>0</SuccessCode><DomainName>taken.com</DomainName><Available>no</Available>`,
+	"bad.zz":    `<SuccessCode>-1</SuccessCode><DomainName>bad.zz</DomainName><Error>unsupported tld</Error>`,
+}
+
+// newTestServer stubs the search command, recording the number of domains of each request.
+func newTestServer(t *testing.T, sizes *[]int) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		if query.Get("key") != "key" {
+			_, _ = w.Write([]byte(`<Response><ResponseHeader><ResponseCode>-1</ResponseCode>` +
+				`<Error>invalid key</Error></ResponseHeader></Response>`))
+
+			return
+		}
+
+		if query.Get("command") != "search" {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		var body strings.Builder
+
+		body.WriteString("<Results>")
+
+		count := 0
+
+		for ; query.Has("domain" + strconv.Itoa(count)); count++ {
+			if answer, ok := answers[query.Get("domain"+strconv.Itoa(count))]; ok {
+				body.WriteString("<SearchResponse><SearchHeader>" + answer + "</SearchHeader></SearchResponse>")
+			}
+		}
+
+		body.WriteString("</Results>")
+
+		if sizes != nil {
+			*sizes = append(*sizes, count)
+		}
+
+		_, _ = w.Write([]byte(body.String()))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func newTestService(t *testing.T, endpoint, apiKey string, maxDomainsPerCheck int) *dynadot.Service {
+	t.Helper()
+
+	service, err := dynadot.NewService(zap.NewNop(), dynadot.Config{
+		APIKey:             apiKey,
+		Endpoint:           endpoint,
+		MaxDomainsPerCheck: maxDomainsPerCheck,
+		RequestTimeout:     0,
+		MaxRetries:         0,
+		RateLimitPerSecond: 0,
+		IncludeRaw:         true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+
+	return service
+}
+
+func TestNewService_MissingAPIKey(t *testing.T) {
+	t.Parallel()
+
+	_, err := dynadot.NewService(zap.NewNop(), dynadot.Config{
+		APIKey:             "",
+		Endpoint:           "",
+		MaxDomainsPerCheck: 0,
+		RequestTimeout:     0,
+		MaxRetries:         0,
+		RateLimitPerSecond: 0,
+		IncludeRaw:         false,
+	})
+	if !errors.Is(err, dynadot.ErrMissingAPIKey) {
+		t.Errorf("NewService() error = %v, want %v", err, dynadot.ErrMissingAPIKey)
+	}
+}
+
+func TestDomainsCheck(t *testing.T) {
+	t.Parallel()
+
+	domains := []string{"fresh.com", "taken.com", "bad.zz", "missing.com"}
+
+	want := []struct {
+		available    bool
+		availability string
+		price        float64
+		wantError    bool
+	}{
+		{available: true, availability: namecheap.AvailabilityAvailable, price: 1208.99, wantError: false},
+		{available: false, availability: namecheap.AvailabilityTaken, price: 0, wantError: false},
+		{available: false, availability: namecheap.AvailabilityUnknown, price: 0, wantError: true},
+		{available: false, availability: namecheap.AvailabilityUnknown, price: 0, wantError: true},
+	}
+
+	server := newTestServer(t, nil)
+
+	results, err := newTestService(t, server.URL, "key", 0).DomainsCheck(context.Background(), domains)
+	if err != nil {
+		t.Fatalf("DomainsCheck() unexpected error: %v", err)
+	}
+
+	if len(results) != len(domains) {
+		t.Fatalf("DomainsCheck() returned %d results, want %d", len(results), len(domains))
+	}
+
+	for i, got := range results {
+		expected := want[i]
+
+		if got.Domain != domains[i] || got.Available != expected.available ||
+			got.Availability != expected.availability || got.PremiumRegistrationPrice != expected.price ||
+			(got.Error != "") != expected.wantError {
+			t.Errorf("results[%d] = %+v, want %+v", i, got, expected)
+		}
+	}
+
+	if results[0].Currency != "USD" || results[0].Raw["available"] != "yes" {
+		t.Errorf("Currency = %q, Raw available = %q, want USD and yes", results[0].Currency, results[0].Raw["available"])
+	}
+}
+
+func TestDomainsCheck_MaxDomainsPerCheck(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		maxDomainsPerCheck int
+		wantSizes          []int
+	}{
+		{name: "default", maxDomainsPerCheck: 0, wantSizes: []int{100, 1}},
+		{name: "configured", maxDomainsPerCheck: 40, wantSizes: []int{40, 40, 21}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var sizes []int
+
+			server := newTestServer(t, &sizes)
+
+			domains := make([]string, 0, 101)
+			for i := range 101 {
+				domains = append(domains, fmt.Sprintf("domain%d.com", i))
+			}
+
+			service := newTestService(t, server.URL, "key", tt.maxDomainsPerCheck)
+
+			results, err := service.DomainsCheck(context.Background(), domains)
+			if err != nil {
+				t.Fatalf("DomainsCheck() unexpected error: %v", err)
+			}
+
+			if len(results) != len(domains) || results[100].Domain != "domain100.com" {
+				t.Errorf("DomainsCheck() returned %d results, want %d in order", len(results), len(domains))
+			}
+
+			if fmt.Sprint(sizes) != fmt.Sprint(tt.wantSizes) {
+				t.Errorf("search request sizes = %v, want %v", sizes, tt.wantSizes)
+			}
+		})
+	}
+}
+
+func TestVerifyCredentials(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer(t, nil)
+
+	err := newTestService(t, server.URL, "key", 0).VerifyCredentials(context.Background())
+	if err != nil {
+		t.Errorf("VerifyCredentials() unexpected error: %v", err)
+	}
+
+	err = newTestService(t, server.URL, "wrong", 0).VerifyCredentials(context.Background())
+	if !errors.Is(err, dynadot.ErrAPIError) {
+		t.Errorf("VerifyCredentials() error = %v, want %v", err, dynadot.ErrAPIError)
+	}
+}
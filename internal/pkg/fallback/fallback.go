@@ -0,0 +1,161 @@
+// Package fallback combines availability providers in priority order. Checker asks each
+// provider in turn only about the domains the ones before it couldn't answer, so a cheap
+// checker such as RDAP can go first and paid API quota is only spent on the rest.
+package fallback
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"go.uber.org/zap"
+)
+
+// ErrNoCheckers is returned by NewChecker when no checkers are given.
+var ErrNoCheckers = errors.New("no checkers to fall back on")
+
+// Checker is a DomainChecker that tries its checkers in priority order. Each domain gets
+// the first result that answers it; a domain no checker could answer keeps the result of
+// the last one, with its error.
+type Checker struct {
+	logger   *zap.Logger
+	checkers []namecheap.DomainChecker
+}
+
+// NewChecker creates a Checker trying checkers in the given order. It returns
+// ErrNoCheckers when checkers is empty.
+func NewChecker(logger *zap.Logger, checkers []namecheap.DomainChecker) (*Checker, error) {
+	if len(checkers) == 0 {
+		return nil, ErrNoCheckers
+	}
+
+	return &Checker{
+		logger:   logger,
+		checkers: checkers,
+	}, nil
+}
+
+// Name returns the name of the fallback checker.
+func (c *Checker) Name() string {
+	return "check_availability_fallback"
+}
+
+// Description returns a description of the fallback checker.
+func (c *Checker) Description() string {
+	return "Check domain availability with the configured providers in priority order, asking each " +
+		"provider only about the domains the previous ones couldn't answer"
+}
+
+// DomainsCheck checks domains with the first checker and hands the domains it couldn't
+// answer, because the check failed or the result carries an error, to the next one,
+// until every domain is answered or no checker is left. The results are in input order.
+// A failing checker only leaves its domains to the next one; the check fails when no
+// checker returned any results, or when ctx is done.
+func (c *Checker) DomainsCheck(ctx context.Context, domains []string) ([]namecheap.Result, error) {
+	if len(domains) == 0 {
+		return nil, namecheap.ErrMissingDomains
+	}
+
+	results := make([]namecheap.Result, len(domains))
+
+	// pending holds the indexes of the domains no checker has answered yet.
+	pending := make([]int, len(domains))
+	for i := range pending {
+		pending[i] = i
+	}
+
+	var (
+		firstErr error
+		answered bool
+	)
+
+	for _, checker := range c.checkers {
+		names := make([]string, 0, len(pending))
+		for _, i := range pending {
+			names = append(names, domains[i])
+		}
+
+		checked, err := checker.DomainsCheck(ctx, names)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		if err != nil && !namecheap.IsPartial(err) {
+			c.logger.Warn("Fallback checker failed, trying the next one",
+				zap.String("checker", checker.Name()),
+				zap.Int("domain_count", len(names)),
+				zap.Error(err),
+			)
+
+			if firstErr == nil {
+				firstErr = err
+			}
+
+			for _, i := range pending {
+				results[i] = errorResult(domains[i], err)
+			}
+
+			continue
+		}
+
+		// The checker may answer in its own order or leave domains out.
+		checked = namecheap.MatchResults(names, checked)
+		answered = true
+		pending = c.collect(checker, domains, pending, checked, results)
+
+		if len(pending) == 0 {
+			break
+		}
+	}
+
+	if !answered {
+		return nil, firstErr
+	}
+
+	return results, nil
+}
+
+// collect stores checked, the results of checker for domains[i] of every i in pending,
+// in results and returns the indexes of the domains it couldn't answer. checked holds one
+// result per pending domain, in order.
+func (c *Checker) collect(
+	checker namecheap.DomainChecker,
+	domains []string,
+	pending []int,
+	checked []namecheap.Result,
+	results []namecheap.Result,
+) []int {
+	var unanswered []int
+
+	for j, i := range pending {
+		results[i] = checked[j]
+
+		if !answers(checked[j]) {
+			unanswered = append(unanswered, i)
+		}
+	}
+
+	if len(unanswered) > 0 {
+		c.logger.Debug("Falling back for unanswered domains",
+			zap.String("checker", checker.Name()),
+			zap.Int("unanswered", len(unanswered)),
+		)
+	}
+
+	return unanswered
+}
+
+// answers reports whether result settles the availability of its domain.
+func answers(result namecheap.Result) bool {
+	return result.Error == "" && result.Availability != namecheap.AvailabilityUnknown
+}
+
+// errorResult is the result of a domain whose availability couldn't be determined.
+func errorResult(domain string, err error) namecheap.Result {
+	return namecheap.Result{ //nolint:exhaustruct
+		Domain:       domain,
+		Availability: namecheap.AvailabilityUnknown,
+		Status:       namecheap.StatusError,
+		Error:        err.Error(),
+	}
+}
@@ -0,0 +1,232 @@
+package fallback_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/fallback"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap/namecheaptest"
+	"go.uber.org/zap"
+)
+
+var errUpstream = errors.New("upstream down")
+
+// stubProvider returns a checker named name. Domains listed in answers get that
+// availability; the others get an error result, as a provider does for TLDs it doesn't
+// support. With err set, the whole check fails, or with partial, fails for those others.
+func stubProvider(name string, answers map[string]string, err error, partial bool) *namecheaptest.Checker {
+	checker := &namecheaptest.Checker{ //nolint:exhaustruct
+		CheckerName: name,
+		Answers:     make(map[string]namecheap.Result, len(answers)),
+		Answer: func(domain string) namecheap.Result {
+			result := namecheaptest.Result(domain, false)
+			result.Availability = namecheap.AvailabilityUnknown
+			result.Error = name + ": unsupported TLD"
+
+			return result
+		},
+	}
+
+	for domain, availability := range answers {
+		result := namecheaptest.Result(domain, availability == namecheap.AvailabilityAvailable)
+		result.Availability = availability
+		checker.Answers[domain] = result
+	}
+
+	if partial {
+		checker.PartialErr = err
+	} else {
+		checker.Err = err
+	}
+
+	return checker
+}
+
+func TestChecker_FallsBackPerDomain(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		first     *namecheaptest.Checker
+		wantFirst []string
+	}{
+		{
+			name:      "first checker answers some domains",
+			first:     stubProvider("rdap", map[string]string{"taken.com": namecheap.AvailabilityTaken}, nil, false),
+			wantFirst: []string{"taken.com", "fresh.io", "weird.zz"},
+		},
+		{
+			name:      "first checker partially fails",
+			first:     stubProvider("rdap", map[string]string{"taken.com": namecheap.AvailabilityTaken}, errUpstream, true),
+			wantFirst: []string{"taken.com", "fresh.io", "weird.zz"},
+		},
+		{
+			name:      "first checker fails entirely",
+			first:     stubProvider("rdap", nil, errUpstream, false),
+			wantFirst: []string{"taken.com", "fresh.io", "weird.zz"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			second := stubProvider("namecheap", map[string]string{
+				"taken.com": namecheap.AvailabilityTaken,
+				"fresh.io":  namecheap.AvailabilityAvailable,
+			}, nil, false)
+
+			checker, err := fallback.NewChecker(zap.NewNop(), []namecheap.DomainChecker{tt.first, second})
+			if err != nil {
+				t.Fatalf("NewChecker() unexpected error: %v", err)
+			}
+
+			results, err := checker.DomainsCheck(context.Background(), []string{"taken.com", "fresh.io", "weird.zz"})
+			if err != nil {
+				t.Fatalf("DomainsCheck() unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(tt.first.Checked(), tt.wantFirst) {
+				t.Errorf("first checker asked for %v, want %v", tt.first.Checked(), tt.wantFirst)
+			}
+
+			// Only what the first checker couldn't answer reaches the second.
+			wantSecond := []string{"fresh.io", "weird.zz"}
+			if len(tt.first.Answers) == 0 {
+				wantSecond = []string{"taken.com", "fresh.io", "weird.zz"}
+			}
+
+			if !reflect.DeepEqual(second.Checked(), wantSecond) {
+				t.Errorf("second checker asked for %v, want %v", second.Checked(), wantSecond)
+			}
+
+			if results[0].Availability != namecheap.AvailabilityTaken || results[0].Error != "" {
+				t.Errorf("results[0] = %+v, want taken", results[0])
+			}
+
+			if !results[1].Available || results[1].Error != "" {
+				t.Errorf("results[1] = %+v, want available from the second checker", results[1])
+			}
+
+			// No checker answers weird.zz, so it keeps the last checker's error.
+			if results[2].Availability != namecheap.AvailabilityUnknown || results[2].Error != "namecheap: unsupported TLD" {
+				t.Errorf("results[2] = %+v, want the second checker's error", results[2])
+			}
+		})
+	}
+}
+
+// unorderedChecker answers the domains listed in answers in reverse order and leaves
+// the others out of its results.
+type unorderedChecker struct {
+	answers map[string]string
+}
+
+func (u unorderedChecker) DomainsCheck(_ context.Context, domains []string) ([]namecheap.Result, error) {
+	var results []namecheap.Result
+
+	for i := len(domains) - 1; i >= 0; i-- {
+		if availability, ok := u.answers[domains[i]]; ok {
+			results = append(results, namecheap.Result{ //nolint:exhaustruct
+				Domain:       domains[i],
+				Available:    availability == namecheap.AvailabilityAvailable,
+				Availability: availability,
+			})
+		}
+	}
+
+	return results, nil
+}
+
+func (u unorderedChecker) Name() string {
+	return "unordered"
+}
+
+func (u unorderedChecker) Description() string {
+	return "unordered"
+}
+
+func TestChecker_MatchesResultsByDomain(t *testing.T) {
+	t.Parallel()
+
+	first := unorderedChecker{answers: map[string]string{
+		"taken.com": namecheap.AvailabilityTaken,
+		"fresh.io":  namecheap.AvailabilityAvailable,
+	}}
+	second := stubProvider("namecheap", nil, nil, false)
+
+	checker, err := fallback.NewChecker(zap.NewNop(), []namecheap.DomainChecker{first, second})
+	if err != nil {
+		t.Fatalf("NewChecker() unexpected error: %v", err)
+	}
+
+	results, err := checker.DomainsCheck(context.Background(), []string{"taken.com", "weird.zz", "fresh.io"})
+	if err != nil {
+		t.Fatalf("DomainsCheck() unexpected error: %v", err)
+	}
+
+	if results[0].Domain != "taken.com" || results[0].Availability != namecheap.AvailabilityTaken {
+		t.Errorf("results[0] = %+v, want taken.com taken", results[0])
+	}
+
+	if results[2].Domain != "fresh.io" || results[2].Availability != namecheap.AvailabilityAvailable {
+		t.Errorf("results[2] = %+v, want fresh.io available", results[2])
+	}
+
+	// The domain the first checker left out goes to the second.
+	if !reflect.DeepEqual(second.Checked(), []string{"weird.zz"}) {
+		t.Errorf("second checker asked for %v, want [weird.zz]", second.Checked())
+	}
+
+	if results[1].Domain != "weird.zz" || results[1].Error == "" {
+		t.Errorf("results[1] = %+v, want weird.zz with an error", results[1])
+	}
+}
+
+func TestChecker_LastCheckerLeavesDomainOut(t *testing.T) {
+	t.Parallel()
+
+	only := unorderedChecker{answers: map[string]string{"taken.com": namecheap.AvailabilityTaken}}
+
+	checker, err := fallback.NewChecker(zap.NewNop(), []namecheap.DomainChecker{only})
+	if err != nil {
+		t.Fatalf("NewChecker() unexpected error: %v", err)
+	}
+
+	results, err := checker.DomainsCheck(context.Background(), []string{"taken.com", "weird.zz"})
+	if err != nil {
+		t.Fatalf("DomainsCheck() unexpected error: %v", err)
+	}
+
+	if results[1].Domain != "weird.zz" || results[1].Error != namecheap.ErrMissingResult.Error() {
+		t.Errorf("results[1] = %+v, want weird.zz with %q", results[1], namecheap.ErrMissingResult)
+	}
+}
+
+func TestChecker_AllCheckersFail(t *testing.T) {
+	t.Parallel()
+
+	failing := stubProvider("rdap", nil, errUpstream, false)
+
+	checker, err := fallback.NewChecker(zap.NewNop(), []namecheap.DomainChecker{failing, failing})
+	if err != nil {
+		t.Fatalf("NewChecker() unexpected error: %v", err)
+	}
+
+	_, err = checker.DomainsCheck(context.Background(), []string{"example.com"})
+	if !errors.Is(err, errUpstream) {
+		t.Errorf("DomainsCheck() error = %v, want %v", err, errUpstream)
+	}
+}
+
+func TestNewChecker_NoCheckers(t *testing.T) {
+	t.Parallel()
+
+	_, err := fallback.NewChecker(zap.NewNop(), nil)
+	if !errors.Is(err, fallback.ErrNoCheckers) {
+		t.Errorf("NewChecker() error = %v, want %v", err, fallback.ErrNoCheckers)
+	}
+}
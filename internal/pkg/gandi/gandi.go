@@ -0,0 +1,353 @@
+// Package gandi provides domain availability and registration prices through the Gandi
+// v5 Domain API. Its Service implements namecheap.DomainChecker, so it plugs into the
+// same tools and decorators as the other providers.
+package gandi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/limit"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/metrics"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/requestid"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/retry"
+	"go.uber.org/zap"
+)
+
+const (
+	// metricsProvider labels the metrics of this backend.
+	metricsProvider = "gandi"
+
+	// DefaultEndpoint is the Gandi production API.
+	DefaultEndpoint = "https://api.gandi.net"
+
+	// AuthSchemeAPIKey sends the key as "Apikey {key}", the scheme of legacy API keys.
+	AuthSchemeAPIKey = "apikey"
+	// AuthSchemeBearer sends the key as "Bearer {key}", the scheme of personal access tokens.
+	AuthSchemeBearer = "bearer"
+
+	// defaultRequestTimeout is the per-request HTTP timeout when Config.RequestTimeout is unset.
+	defaultRequestTimeout = 30 * time.Second
+	// retryBackoff is the delay before the first retry.
+	retryBackoff = 250 * time.Millisecond
+
+	// checkPath is the availability endpoint, checking one domain per request.
+	checkPath = "/v5/domain/check"
+	// defaultGrid is the public price grid, the one prices are quoted from.
+	defaultGrid = "A"
+	// statusAvailable and statusUnavailable are the product statuses of a checked domain.
+	statusAvailable   = "available"
+	statusUnavailable = "unavailable"
+	// durationUnitYear is the duration unit of yearly prices.
+	durationUnitYear = "y"
+)
+
+var (
+	// ErrMissingAPIKey is returned when the API key is missing.
+	ErrMissingAPIKey = errors.New("missing Gandi API key")
+	// ErrUnknownAuthScheme is returned when Config.AuthScheme is neither apikey nor bearer.
+	ErrUnknownAuthScheme = errors.New("unknown Gandi auth scheme")
+	// ErrAPIError is returned when the Gandi API answers with an error status.
+	ErrAPIError = errors.New("gandi API error")
+	// ErrNotInResponse is reported per domain when the answer has no product for it.
+	ErrNotInResponse = errors.New("domain missing from Gandi response")
+	// ErrUnexpectedStatus is reported per domain for a status other than available or unavailable.
+	ErrUnexpectedStatus = errors.New("unexpected Gandi domain status")
+)
+
+// Config holds the configuration for the Gandi API client.
+type Config struct {
+	// APIKey is the Gandi API key or personal access token
+	APIKey string
+	// AuthScheme is how APIKey is sent, AuthSchemeAPIKey or AuthSchemeBearer; empty uses AuthSchemeAPIKey
+	AuthScheme string
+	// Endpoint is the base URL of the API; empty uses DefaultEndpoint
+	Endpoint string
+	// RequestTimeout bounds each API request; zero uses a 30 second default
+	RequestTimeout time.Duration
+	// MaxRetries is how many times a transient network failure is retried; zero disables retries
+	MaxRetries int
+	// RateLimitPerSecond caps outbound API requests per second; zero or less means unlimited
+	RateLimitPerSecond float64
+	// IncludeRaw attaches the raw status, price and currency of each answer to its Result
+	IncludeRaw bool
+}
+
+// CheckResponse is the answer of an availability check.
+type CheckResponse struct {
+	Currency string    `json:"currency"`
+	Grid     string    `json:"grid"`
+	Products []Product `json:"products"`
+}
+
+// Product is the availability and prices of one domain.
+type Product struct {
+	Name    string  `json:"name"`
+	Status  string  `json:"status"`
+	Process string  `json:"process"`
+	Prices  []Price `json:"prices"`
+}
+
+// Price is the price of registering a domain for a range of durations.
+type Price struct {
+	MinDuration      int     `json:"min_duration"`
+	MaxDuration      int     `json:"max_duration"`
+	DurationUnit     string  `json:"duration_unit"`
+	PriceBeforeTaxes float64 `json:"price_before_taxes"`
+	PriceAfterTaxes  float64 `json:"price_after_taxes"`
+}
+
+// apiError is the body of a failed request.
+type apiError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Service checks domain availability using the Gandi Domain API.
+type Service struct {
+	logger        *zap.Logger
+	config        Config
+	client        *http.Client
+	authorization string
+}
+
+// NewService creates a new Gandi service with the given configuration.
+// Returns ErrMissingAPIKey if the API key is missing and ErrUnknownAuthScheme for an
+// unsupported auth scheme.
+func NewService(logger *zap.Logger, config Config) (*Service, error) {
+	if config.APIKey == "" {
+		return nil, ErrMissingAPIKey
+	}
+
+	var authorization string
+
+	switch strings.ToLower(config.AuthScheme) {
+	case "", AuthSchemeAPIKey:
+		authorization = "Apikey " + config.APIKey
+	case AuthSchemeBearer:
+		authorization = "Bearer " + config.APIKey
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownAuthScheme, config.AuthScheme)
+	}
+
+	if config.Endpoint == "" {
+		config.Endpoint = DefaultEndpoint
+	}
+
+	timeout := config.RequestTimeout
+	if timeout <= 0 {
+		timeout = defaultRequestTimeout
+	}
+
+	return &Service{
+		logger: logger,
+		config: config,
+		client: &http.Client{ //nolint:exhaustruct
+			Timeout: timeout,
+			Transport: retry.NewTransport(
+				limit.NewTransport(http.DefaultTransport, config.RateLimitPerSecond), config.MaxRetries, retryBackoff),
+		},
+		authorization: authorization,
+	}, nil
+}
+
+// Name returns the name of the Gandi service.
+func (s *Service) Name() string {
+	return "check_availability_gandi"
+}
+
+// Description returns a description of the Gandi service.
+func (s *Service) Description() string {
+	return "Check domain availability and one-year prices using Gandi API"
+}
+
+// DomainsCheck checks each domain with the check endpoint, one request per domain. A
+// failed request or an unexpected status is reported in that domain's Result.Error; only
+// a cancelled or expired ctx aborts the whole check.
+func (s *Service) DomainsCheck(ctx context.Context, domains []string) ([]namecheap.Result, error) {
+	if len(domains) == 0 {
+		return nil, namecheap.ErrMissingDomains
+	}
+
+	metrics.DomainsCheck(metricsProvider, len(domains))
+
+	results := make([]namecheap.Result, 0, len(domains))
+
+	for _, domain := range domains {
+		result, err := s.checkDomain(ctx, domain)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+
+			result = namecheap.Result{ //nolint:exhaustruct
+				Domain:       domain,
+				Availability: namecheap.AvailabilityUnknown,
+				Status:       namecheap.StatusError,
+				Error:        err.Error(),
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// VerifyCredentials makes a single availability check and returns the API error when
+// the key is rejected.
+func (s *Service) VerifyCredentials(ctx context.Context) error {
+	_, err := s.check(ctx, "gandi.net")
+	if err != nil {
+		return fmt.Errorf("failed to verify credentials: %w", err)
+	}
+
+	return nil
+}
+
+// checkDomain checks one domain and maps its product to a Result.
+func (s *Service) checkDomain(ctx context.Context, domain string) (namecheap.Result, error) {
+	resp, err := s.check(ctx, domain)
+	if err != nil {
+		return namecheap.Result{}, err
+	}
+
+	product, ok := findProduct(resp.Products, domain)
+	if !ok {
+		return namecheap.Result{}, ErrNotInResponse
+	}
+
+	if product.Status != statusAvailable && product.Status != statusUnavailable {
+		return namecheap.Result{}, fmt.Errorf("%w: %q", ErrUnexpectedStatus, product.Status)
+	}
+
+	return s.result(domain, resp.Currency, product), nil
+}
+
+// result maps a product to a Result. The one-year price of an available domain, before
+// taxes, comes from Gandi's public price grid, so it is reported as the standard price.
+func (s *Service) result(domain, currency string, product Product) namecheap.Result {
+	available := product.Status == statusAvailable
+
+	result := namecheap.Result{
+		Domain:                    domain,
+		Available:                 available,
+		Availability:              namecheap.AvailabilityTaken,
+		Status:                    "",
+		IsPremiumName:             false,
+		PremiumRegistrationPrice:  0,
+		PremiumRenewalPrice:       0,
+		IcannFee:                  0,
+		EapFee:                    0,
+		StandardRegistrationPrice: 0,
+		Currency:                  "",
+		Error:                     "",
+		ErrorCode:                 0,
+		RegisterURL:               "",
+		Blocked:                   false,
+		BlockReason:               "",
+		CorrelationID:             "",
+		Input:                     "",
+		ReferencePrice:            0,
+		ReferencePriceDelta:       0,
+		Raw:                       nil,
+	}
+
+	price, hasPrice := oneYearPrice(product.Prices)
+
+	if available {
+		result.Availability = namecheap.AvailabilityAvailable
+
+		if hasPrice {
+			result.StandardRegistrationPrice = price.PriceBeforeTaxes
+			result.Currency = currency
+		}
+	}
+
+	result.Status = namecheap.StatusOf(result)
+
+	if s.config.IncludeRaw {
+		result.Raw = map[string]string{
+			"status":   product.Status,
+			"price":    strconv.FormatFloat(price.PriceBeforeTaxes, 'f', -1, 64),
+			"currency": currency,
+		}
+	}
+
+	return result
+}
+
+// findProduct returns the product of domain, matched case-insensitively.
+func findProduct(products []Product, domain string) (Product, bool) {
+	for _, product := range products {
+		if strings.EqualFold(product.Name, domain) {
+			return product, true
+		}
+	}
+
+	return Product{}, false
+}
+
+// oneYearPrice returns the first yearly price whose duration range includes one year.
+func oneYearPrice(prices []Price) (Price, bool) {
+	for _, price := range prices {
+		if price.DurationUnit == durationUnitYear && price.MinDuration <= 1 && price.MaxDuration >= 1 {
+			return price, true
+		}
+	}
+
+	return Price{}, false
+}
+
+// check asks for the availability and default-grid prices of one domain.
+func (s *Service) check(ctx context.Context, domain string) (CheckResponse, error) {
+	query := url.Values{"name": {domain}, "grid": {defaultGrid}}
+	reqURL := strings.TrimSuffix(s.config.Endpoint, "/") + checkPath + "?" + query.Encode()
+
+	s.logger.Debug("Making Gandi API call", zap.String("domain", domain), requestid.Field(ctx))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return CheckResponse{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", s.authorization)
+
+	start := time.Now()
+	resp, err := s.client.Do(req)
+	metrics.UpstreamRequest(metricsProvider, time.Since(start), resp, err)
+
+	if err != nil {
+		return CheckResponse{}, fmt.Errorf("HTTP request failed: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr apiError
+
+		_ = json.NewDecoder(resp.Body).Decode(&apiErr)
+
+		return CheckResponse{}, fmt.Errorf("%w: %d %s", ErrAPIError, resp.StatusCode, apiErr.Message)
+	}
+
+	var checkResp CheckResponse
+
+	err = json.NewDecoder(resp.Body).Decode(&checkResp)
+	if err != nil {
+		return CheckResponse{}, fmt.Errorf("failed to decode JSON response: %w", err)
+	}
+
+	return checkResp, nil
+}
@@ -0,0 +1,157 @@
+package gandi_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/gandi"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"go.uber.org/zap"
+)
+
+// products are the stubbed products, keyed by domain. Domains not listed get an answer
+// without products.
+//
+//nolint:gochecknoglobals
+var products = map[string]gandi.Product{
+	"fresh.com": {Name: "fresh.com", Status: "available", Process: "create", Prices: []gandi.Price{
+		{MinDuration: 2, MaxDuration: 10, DurationUnit: "y", PriceBeforeTaxes: 30, PriceAfterTaxes: 36},
+		{MinDuration: 1, MaxDuration: 1, DurationUnit: "y", PriceBeforeTaxes: 15.5, PriceAfterTaxes: 18.6},
+	}},
+	"taken.com":   {Name: "taken.com", Status: "unavailable", Process: "create", Prices: nil},
+	"pending.com": {Name: "pending.com", Status: "pending", Process: "create", Prices: nil},
+}
+
+func newTestService(t *testing.T, apiKey, authScheme, wantAuthorization string) *gandi.Service {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != wantAuthorization {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"code":401,"message":"The server could not verify that you authorized"}`))
+
+			return
+		}
+
+		if r.URL.Path != "/v5/domain/check" || r.URL.Query().Get("grid") != "A" {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		resp := gandi.CheckResponse{Currency: "EUR", Grid: "A", Products: nil}
+		if product, ok := products[r.URL.Query().Get("name")]; ok {
+			resp.Products = []gandi.Product{product}
+		}
+
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+
+	service, err := gandi.NewService(zap.NewNop(), gandi.Config{
+		APIKey:             apiKey,
+		AuthScheme:         authScheme,
+		Endpoint:           server.URL,
+		RequestTimeout:     0,
+		MaxRetries:         0,
+		RateLimitPerSecond: 0,
+		IncludeRaw:         true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+
+	return service
+}
+
+func TestNewService_InvalidConfiguration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		apiKey     string
+		authScheme string
+		wantErr    error
+	}{
+		{name: "missing key", apiKey: "", authScheme: "", wantErr: gandi.ErrMissingAPIKey},
+		{name: "unknown scheme", apiKey: "key", authScheme: "basic", wantErr: gandi.ErrUnknownAuthScheme},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := gandi.NewService(zap.NewNop(), gandi.Config{
+				APIKey:             tt.apiKey,
+				AuthScheme:         tt.authScheme,
+				Endpoint:           "",
+				RequestTimeout:     0,
+				MaxRetries:         0,
+				RateLimitPerSecond: 0,
+				IncludeRaw:         false,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewService() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDomainsCheck(t *testing.T) {
+	t.Parallel()
+
+	domains := []string{"fresh.com", "taken.com", "pending.com", "missing.com"}
+
+	want := []struct {
+		available    bool
+		availability string
+		price        float64
+		wantError    bool
+	}{
+		{available: true, availability: namecheap.AvailabilityAvailable, price: 15.5, wantError: false},
+		{available: false, availability: namecheap.AvailabilityTaken, price: 0, wantError: false},
+		{available: false, availability: namecheap.AvailabilityUnknown, price: 0, wantError: true},
+		{available: false, availability: namecheap.AvailabilityUnknown, price: 0, wantError: true},
+	}
+
+	results, err := newTestService(t, "key", "", "Apikey key").DomainsCheck(context.Background(), domains)
+	if err != nil {
+		t.Fatalf("DomainsCheck() unexpected error: %v", err)
+	}
+
+	if len(results) != len(domains) {
+		t.Fatalf("DomainsCheck() returned %d results, want %d", len(results), len(domains))
+	}
+
+	for i, got := range results {
+		expected := want[i]
+
+		if got.Domain != domains[i] || got.Available != expected.available ||
+			got.Availability != expected.availability || got.StandardRegistrationPrice != expected.price ||
+			(got.Error != "") != expected.wantError {
+			t.Errorf("results[%d] = %+v, want %+v", i, got, expected)
+		}
+	}
+
+	if results[0].Currency != "EUR" || results[0].Raw["status"] != "available" {
+		t.Errorf("Currency = %q, Raw status = %q, want EUR and available", results[0].Currency, results[0].Raw["status"])
+	}
+}
+
+func TestVerifyCredentials(t *testing.T) {
+	t.Parallel()
+
+	err := newTestService(t, "token", "Bearer", "Bearer token").VerifyCredentials(context.Background())
+	if err != nil {
+		t.Errorf("VerifyCredentials() unexpected error: %v", err)
+	}
+
+	err = newTestService(t, "wrong", "bearer", "Bearer token").VerifyCredentials(context.Background())
+	if !errors.Is(err, gandi.ErrAPIError) {
+		t.Errorf("VerifyCredentials() error = %v, want %v", err, gandi.ErrAPIError)
+	}
+}
@@ -0,0 +1,372 @@
+// Package godaddy provides domain availability checking through the GoDaddy Domains API.
+// Its Service implements namecheap.DomainChecker, so it plugs into the same tools and
+// decorators as the other providers.
+package godaddy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/limit"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/metrics"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/requestid"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/retry"
+	"go.uber.org/zap"
+)
+
+const (
+	// metricsProvider labels the metrics of this backend.
+	metricsProvider = "godaddy"
+
+	// DefaultEndpoint is the GoDaddy production API.
+	DefaultEndpoint = "https://api.godaddy.com"
+
+	// defaultRequestTimeout is the per-request HTTP timeout when Config.RequestTimeout is unset.
+	defaultRequestTimeout = 30 * time.Second
+	// retryBackoff is the delay before the first retry.
+	retryBackoff = 250 * time.Millisecond
+
+	// availablePath is the availability endpoint: GET checks one domain, POST a list.
+	availablePath = "/v1/domains/available"
+	// checkTypeFast asks for the quicker, possibly non-definitive, availability check.
+	checkTypeFast = "FAST"
+	// defaultMaxDomainsPerCheck is the bulk request size when Config.MaxDomainsPerCheck is
+	// unset, the most domains GoDaddy accepts in one bulk request.
+	defaultMaxDomainsPerCheck = 500
+	// microsPerUnit converts GoDaddy's prices, in millionths of the currency unit.
+	microsPerUnit = 1_000_000
+)
+
+var (
+	// ErrMissingAPICredentials is returned when the API key or secret is missing.
+	ErrMissingAPICredentials = errors.New("missing GoDaddy API credentials")
+	// ErrAPIError is returned when the GoDaddy API answers with an error status.
+	ErrAPIError = errors.New("GoDaddy API error")
+	// ErrNotInResponse is reported per domain when a bulk answer leaves it out.
+	ErrNotInResponse = errors.New("domain missing from GoDaddy response")
+)
+
+// Config holds the configuration for the GoDaddy API client.
+type Config struct {
+	// APIKey is the GoDaddy API key
+	APIKey string
+	// APISecret is the GoDaddy API secret
+	APISecret string
+	// Endpoint is the base URL of the API; empty uses DefaultEndpoint
+	Endpoint string
+	// RequestTimeout bounds each API request; zero uses a 30 second default
+	RequestTimeout time.Duration
+	// MaxRetries is how many times a transient network failure is retried; zero disables retries
+	MaxRetries int
+	// RateLimitPerSecond caps outbound API requests per second; zero or less means unlimited
+	RateLimitPerSecond float64
+	// IncludeRaw attaches the raw price, currency and period of each answer to its Result
+	IncludeRaw bool
+	// MaxDomainsPerCheck is how many domains each bulk request checks; zero or less uses 500
+	MaxDomainsPerCheck int
+}
+
+// Availability is the availability of one domain as GoDaddy reports it.
+type Availability struct {
+	Domain     string `json:"domain"`
+	Available  bool   `json:"available"`
+	Definitive bool   `json:"definitive"`
+	Price      int64  `json:"price"`
+	Currency   string `json:"currency"`
+	Period     int    `json:"period"`
+}
+
+// BulkAvailabilityResponse is the answer of a bulk availability request. Domains that
+// couldn't be checked are listed in Errors.
+type BulkAvailabilityResponse struct {
+	Domains []Availability `json:"domains"`
+	Errors  []DomainError  `json:"errors"`
+}
+
+// DomainError is a per-domain failure in a bulk answer.
+type DomainError struct {
+	Domain  string `json:"domain"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// apiError is the body of a failed request.
+type apiError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Service checks domain availability using the GoDaddy Domains API.
+type Service struct {
+	logger *zap.Logger
+	config Config
+	client *http.Client
+}
+
+// NewService creates a new GoDaddy service with the given configuration.
+// Returns ErrMissingAPICredentials if the API key or secret is missing.
+func NewService(logger *zap.Logger, config Config) (*Service, error) {
+	if config.APIKey == "" || config.APISecret == "" {
+		return nil, ErrMissingAPICredentials
+	}
+
+	if config.Endpoint == "" {
+		config.Endpoint = DefaultEndpoint
+	}
+
+	if config.MaxDomainsPerCheck <= 0 {
+		config.MaxDomainsPerCheck = defaultMaxDomainsPerCheck
+	}
+
+	timeout := config.RequestTimeout
+	if timeout <= 0 {
+		timeout = defaultRequestTimeout
+	}
+
+	return &Service{
+		logger: logger,
+		config: config,
+		client: &http.Client{ //nolint:exhaustruct
+			Timeout: timeout,
+			Transport: retry.NewTransport(
+				limit.NewTransport(http.DefaultTransport, config.RateLimitPerSecond), config.MaxRetries, retryBackoff),
+		},
+	}, nil
+}
+
+// Name returns the name of the GoDaddy service.
+func (s *Service) Name() string {
+	return "check_availability_godaddy"
+}
+
+// Description returns a description of the GoDaddy service.
+func (s *Service) Description() string {
+	return "Check domain availability and one-year prices using GoDaddy API"
+}
+
+// DomainsCheck checks a single domain with the GET endpoint and several with bulk POSTs
+// of up to Config.MaxDomainsPerCheck domains, returning the results in input order. A domain GoDaddy couldn't
+// check is reported in its Result.Error; a failed request fails the whole check.
+func (s *Service) DomainsCheck(ctx context.Context, domains []string) ([]namecheap.Result, error) {
+	if len(domains) == 0 {
+		return nil, namecheap.ErrMissingDomains
+	}
+
+	metrics.DomainsCheck(metricsProvider, len(domains))
+
+	if len(domains) == 1 {
+		availability, err := s.checkOne(ctx, domains[0])
+		if err != nil {
+			return nil, err
+		}
+
+		return []namecheap.Result{s.result(domains[0], availability)}, nil
+	}
+
+	results := make([]namecheap.Result, 0, len(domains))
+
+	for start := 0; start < len(domains); start += s.config.MaxDomainsPerCheck {
+		batch := domains[start:min(start+s.config.MaxDomainsPerCheck, len(domains))]
+
+		resp, err := s.checkBulk(ctx, batch)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, s.bulkResults(batch, resp)...)
+	}
+
+	return results, nil
+}
+
+// VerifyCredentials makes a single availability check and returns the API error when
+// the keys are rejected.
+func (s *Service) VerifyCredentials(ctx context.Context) error {
+	_, err := s.checkOne(ctx, "godaddy.com")
+	if err != nil {
+		return fmt.Errorf("failed to verify credentials: %w", err)
+	}
+
+	return nil
+}
+
+// bulkResults maps a bulk answer back to batch, in order.
+func (s *Service) bulkResults(batch []string, resp BulkAvailabilityResponse) []namecheap.Result {
+	available := make(map[string]Availability, len(resp.Domains))
+	for _, entry := range resp.Domains {
+		available[strings.ToLower(entry.Domain)] = entry
+	}
+
+	failed := make(map[string]DomainError, len(resp.Errors))
+	for _, entry := range resp.Errors {
+		failed[strings.ToLower(entry.Domain)] = entry
+	}
+
+	results := make([]namecheap.Result, 0, len(batch))
+
+	for _, domain := range batch {
+		key := strings.ToLower(domain)
+
+		if entry, ok := available[key]; ok {
+			results = append(results, s.result(domain, entry))
+
+			continue
+		}
+
+		message := ErrNotInResponse.Error()
+		if entry, ok := failed[key]; ok {
+			message = fmt.Sprintf("%s: %s", entry.Code, entry.Message)
+		}
+
+		results = append(results, namecheap.Result{ //nolint:exhaustruct
+			Domain:       domain,
+			Availability: namecheap.AvailabilityUnknown,
+			Status:       namecheap.StatusError,
+			Error:        message,
+		})
+	}
+
+	return results
+}
+
+// result maps an availability answer to a Result. GoDaddy doesn't flag premium names, so
+// the quoted price of an available domain is reported as its standard registration price,
+// in the currency of the account.
+func (s *Service) result(domain string, entry Availability) namecheap.Result {
+	result := namecheap.Result{
+		Domain:                    domain,
+		Available:                 entry.Available,
+		Availability:              namecheap.AvailabilityTaken,
+		Status:                    "",
+		IsPremiumName:             false,
+		PremiumRegistrationPrice:  0,
+		PremiumRenewalPrice:       0,
+		IcannFee:                  0,
+		EapFee:                    0,
+		StandardRegistrationPrice: 0,
+		Currency:                  "",
+		Error:                     "",
+		ErrorCode:                 0,
+		RegisterURL:               "",
+		Blocked:                   false,
+		BlockReason:               "",
+		CorrelationID:             "",
+		Input:                     "",
+		ReferencePrice:            0,
+		ReferencePriceDelta:       0,
+		Raw:                       nil,
+	}
+
+	if entry.Available {
+		result.Availability = namecheap.AvailabilityAvailable
+		result.StandardRegistrationPrice = float64(entry.Price) / microsPerUnit
+		result.Currency = entry.Currency
+	}
+
+	result.Status = namecheap.StatusOf(result)
+
+	if s.config.IncludeRaw {
+		result.Raw = map[string]string{
+			"available":  strconv.FormatBool(entry.Available),
+			"definitive": strconv.FormatBool(entry.Definitive),
+			"price":      strconv.FormatInt(entry.Price, 10),
+			"currency":   entry.Currency,
+			"period":     strconv.Itoa(entry.Period),
+		}
+	}
+
+	return result
+}
+
+// checkOne checks a single domain with the GET endpoint.
+func (s *Service) checkOne(ctx context.Context, domain string) (Availability, error) {
+	query := url.Values{"domain": {domain}, "checkType": {checkTypeFast}}
+
+	var availability Availability
+
+	err := s.call(ctx, http.MethodGet, availablePath+"?"+query.Encode(), nil, &availability)
+	if err != nil {
+		return Availability{}, err
+	}
+
+	return availability, nil
+}
+
+// checkBulk checks domains with one bulk POST.
+func (s *Service) checkBulk(ctx context.Context, domains []string) (BulkAvailabilityResponse, error) {
+	body, err := json.Marshal(domains)
+	if err != nil {
+		return BulkAvailabilityResponse{}, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	query := url.Values{"checkType": {checkTypeFast}}
+
+	var resp BulkAvailabilityResponse
+
+	err = s.call(ctx, http.MethodPost, availablePath+"?"+query.Encode(), body, &resp)
+	if err != nil {
+		return BulkAvailabilityResponse{}, err
+	}
+
+	return resp, nil
+}
+
+// call sends an authenticated request to path under the configured endpoint and decodes
+// a successful answer into out. GoDaddy answers a partly failed bulk check with 203.
+func (s *Service) call(ctx context.Context, method, path string, body []byte, out any) error {
+	reqURL := strings.TrimSuffix(s.config.Endpoint, "/") + path
+
+	s.logger.Debug("Making GoDaddy API call",
+		zap.String("method", method),
+		zap.String("path", path),
+		requestid.Field(ctx),
+	)
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "sso-key "+s.config.APIKey+":"+s.config.APISecret)
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	start := time.Now()
+	resp, err := s.client.Do(req)
+	metrics.UpstreamRequest(metricsProvider, time.Since(start), resp, err)
+
+	if err != nil {
+		return fmt.Errorf("HTTP request failed: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNonAuthoritativeInfo {
+		var apiErr apiError
+
+		_ = json.NewDecoder(resp.Body).Decode(&apiErr)
+
+		return fmt.Errorf("%w: %d %s: %s", ErrAPIError, resp.StatusCode, apiErr.Code, apiErr.Message)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(out)
+	if err != nil {
+		return fmt.Errorf("failed to decode JSON response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,251 @@
+package godaddy_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/godaddy"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"go.uber.org/zap"
+)
+
+// answers are the stubbed availability answers, keyed by domain. Domains not listed are
+// reported as failed in bulk answers.
+//
+//nolint:gochecknoglobals
+var answers = map[string]godaddy.Availability{
+	"fresh.com": {Domain: "fresh.com", Available: true, Definitive: true, Price: 11990000, Currency: "USD", Period: 1},
+	"taken.com": {Domain: "taken.com", Available: false, Definitive: true, Price: 0, Currency: "USD", Period: 1},
+}
+
+func newTestService(t *testing.T, apiKey string) *godaddy.Service {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "sso-key key:secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"code":"UNABLE_TO_AUTHENTICATE","message":"Unable to authenticate"}`))
+
+			return
+		}
+
+		if r.URL.Path != "/v1/domains/available" || r.URL.Query().Get("checkType") != "FAST" {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		if r.Method == http.MethodGet {
+			_ = json.NewEncoder(w).Encode(answers[r.URL.Query().Get("domain")])
+
+			return
+		}
+
+		var domains []string
+
+		_ = json.NewDecoder(r.Body).Decode(&domains)
+
+		resp := godaddy.BulkAvailabilityResponse{Domains: nil, Errors: nil}
+
+		for _, domain := range domains {
+			if answer, ok := answers[domain]; ok {
+				resp.Domains = append(resp.Domains, answer)
+			} else {
+				resp.Errors = append(resp.Errors, godaddy.DomainError{
+					Domain: domain, Code: "UNSUPPORTED_TLD", Message: "TLD not supported",
+				})
+			}
+		}
+
+		w.WriteHeader(http.StatusNonAuthoritativeInfo)
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+
+	service, err := godaddy.NewService(zap.NewNop(), godaddy.Config{
+		APIKey:             apiKey,
+		APISecret:          "secret",
+		Endpoint:           server.URL,
+		RequestTimeout:     0,
+		MaxRetries:         0,
+		RateLimitPerSecond: 0,
+		IncludeRaw:         true,
+		MaxDomainsPerCheck: 0,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+
+	return service
+}
+
+func TestNewService_MissingCredentials(t *testing.T) {
+	t.Parallel()
+
+	_, err := godaddy.NewService(zap.NewNop(), godaddy.Config{
+		APIKey:             "key",
+		APISecret:          "",
+		Endpoint:           "",
+		RequestTimeout:     0,
+		MaxRetries:         0,
+		RateLimitPerSecond: 0,
+		IncludeRaw:         false,
+		MaxDomainsPerCheck: 0,
+	})
+	if !errors.Is(err, godaddy.ErrMissingAPICredentials) {
+		t.Errorf("NewService() error = %v, want %v", err, godaddy.ErrMissingAPICredentials)
+	}
+}
+
+func TestDomainsCheck(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		domains []string
+	}{
+		{name: "single", domains: []string{"fresh.com"}},
+		{name: "bulk", domains: []string{"fresh.com", "taken.com", "bad.zz"}},
+	}
+
+	want := map[string]struct {
+		available    bool
+		availability string
+		status       string
+		price        float64
+		wantError    bool
+	}{
+		"fresh.com": {
+			available: true, availability: namecheap.AvailabilityAvailable, status: namecheap.StatusAvailable,
+			price: 11.99, wantError: false,
+		},
+		"taken.com": {
+			available: false, availability: namecheap.AvailabilityTaken, status: namecheap.StatusRegistered,
+			price: 0, wantError: false,
+		},
+		"bad.zz": {
+			available: false, availability: namecheap.AvailabilityUnknown, status: namecheap.StatusError,
+			price: 0, wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			results, err := newTestService(t, "key").DomainsCheck(context.Background(), tt.domains)
+			if err != nil {
+				t.Fatalf("DomainsCheck() unexpected error: %v", err)
+			}
+
+			if len(results) != len(tt.domains) {
+				t.Fatalf("DomainsCheck() returned %d results, want %d", len(results), len(tt.domains))
+			}
+
+			for i, got := range results {
+				expected := want[tt.domains[i]]
+
+				if got.Domain != tt.domains[i] || got.Available != expected.available ||
+					got.Availability != expected.availability || got.Status != expected.status ||
+					got.StandardRegistrationPrice != expected.price ||
+					got.PremiumRegistrationPrice != 0 || got.IsPremiumName ||
+					(got.Error != "") != expected.wantError {
+					t.Errorf("results[%d] = %+v, want %+v", i, got, expected)
+				}
+			}
+
+			if results[0].Currency != "USD" || results[0].Raw["currency"] != "USD" {
+				t.Errorf("Currency = %q, Raw currency = %q, want %q", results[0].Currency, results[0].Raw["currency"], "USD")
+			}
+		})
+	}
+}
+
+func TestDomainsCheck_SplitsBulkRequests(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		maxDomainsPerCheck int
+		wantSizes          []int
+	}{
+		{name: "default", maxDomainsPerCheck: 0, wantSizes: []int{500, 1}},
+		{name: "configured", maxDomainsPerCheck: 200, wantSizes: []int{200, 200, 101}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var sizes []int
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var domains []string
+
+				_ = json.NewDecoder(r.Body).Decode(&domains)
+				sizes = append(sizes, len(domains))
+
+				resp := godaddy.BulkAvailabilityResponse{Domains: nil, Errors: nil}
+				for _, domain := range domains {
+					resp.Domains = append(resp.Domains, godaddy.Availability{
+						Domain: domain, Available: false, Definitive: true, Price: 0, Currency: "USD", Period: 1,
+					})
+				}
+
+				_ = json.NewEncoder(w).Encode(resp)
+			}))
+			t.Cleanup(server.Close)
+
+			service, err := godaddy.NewService(zap.NewNop(), godaddy.Config{
+				APIKey:             "key",
+				APISecret:          "secret",
+				Endpoint:           server.URL,
+				RequestTimeout:     0,
+				MaxRetries:         0,
+				RateLimitPerSecond: 0,
+				IncludeRaw:         false,
+				MaxDomainsPerCheck: tt.maxDomainsPerCheck,
+			})
+			if err != nil {
+				t.Fatalf("Failed to create service: %v", err)
+			}
+
+			domains := make([]string, 0, 501)
+			for i := range 501 {
+				domains = append(domains, fmt.Sprintf("domain%d.com", i))
+			}
+
+			results, err := service.DomainsCheck(context.Background(), domains)
+			if err != nil {
+				t.Fatalf("DomainsCheck() unexpected error: %v", err)
+			}
+
+			if len(results) != len(domains) || results[500].Domain != "domain500.com" {
+				t.Errorf("DomainsCheck() returned %d results, want %d in order", len(results), len(domains))
+			}
+
+			if fmt.Sprint(sizes) != fmt.Sprint(tt.wantSizes) {
+				t.Errorf("bulk request sizes = %v, want %v", sizes, tt.wantSizes)
+			}
+		})
+	}
+}
+
+func TestVerifyCredentials(t *testing.T) {
+	t.Parallel()
+
+	err := newTestService(t, "key").VerifyCredentials(context.Background())
+	if err != nil {
+		t.Errorf("VerifyCredentials() unexpected error: %v", err)
+	}
+
+	err = newTestService(t, "wrong").VerifyCredentials(context.Background())
+	if !errors.Is(err, godaddy.ErrAPIError) {
+		t.Errorf("VerifyCredentials() error = %v, want %v", err, godaddy.ErrAPIError)
+	}
+}
@@ -0,0 +1,313 @@
+// Package jobs runs large domain checks asynchronously. A submitted job gets an
+// ID straight away, is checked in the background and has its results written to
+// a JSON file in the configured output directory, where they can be retrieved
+// by job ID. Finished jobs are forgotten, and their files removed, after a
+// retention period.
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultMaxRunning is how many jobs may run at once when Config.MaxRunning is unset.
+	DefaultMaxRunning = 4
+	// DefaultMaxDomains is how many domains a job may hold when Config.MaxDomains is unset.
+	DefaultMaxDomains = 10000
+	// DefaultRetention is how long finished jobs are kept when Config.Retention is unset.
+	DefaultRetention = 24 * time.Hour
+
+	// idBytes is the number of random bytes in a job ID.
+	idBytes = 8
+	// filePermissions is the mode used for result files.
+	filePermissions = 0o600
+	// dirPermissions is the mode used when creating the output directory.
+	dirPermissions = 0o750
+)
+
+// Status values reported for a job.
+const (
+	StatusRunning   = "running"
+	StatusCompleted = "completed"
+	StatusFailed    = "failed"
+)
+
+var (
+	// ErrJobNotFound is returned when no job exists for the given ID.
+	ErrJobNotFound = errors.New("job not found")
+	// ErrJobNotCompleted is returned when results are requested for an unfinished job.
+	ErrJobNotCompleted = errors.New("job has not completed")
+	// ErrTooManyDomains is returned when a job holds more domains than allowed.
+	ErrTooManyDomains = errors.New("too many domains for one job")
+	// ErrTooManyJobs is returned when the maximum number of jobs is already running.
+	ErrTooManyJobs = errors.New("too many jobs running")
+)
+
+// Config holds the limits of a Manager.
+type Config struct {
+	// OutputDir is the directory the result files are written to
+	OutputDir string
+	// MaxRunning is how many jobs may run at once; zero or less uses DefaultMaxRunning
+	MaxRunning int
+	// MaxDomains is how many domains one job may hold; zero or less uses DefaultMaxDomains
+	MaxDomains int
+	// Retention is how long finished jobs and their result files are kept; zero or less
+	// uses DefaultRetention
+	Retention time.Duration
+}
+
+// Job describes the state of an asynchronous domain check.
+type Job struct {
+	// ID identifies the job
+	ID string `json:"id" jsonschema:"The job ID"`
+	// Status is running, completed or failed
+	Status string `json:"status" jsonschema:"The job status: running, completed or failed"`
+	// DomainCount is the number of domains submitted
+	DomainCount int `json:"domainCount" jsonschema:"Number of domains submitted"`
+	// Error is the failure reason for failed jobs
+	Error string `json:"error,omitempty" jsonschema:"Error message if the job failed"`
+	// SubmittedAt is when the job was submitted
+	SubmittedAt time.Time `json:"submittedAt" jsonschema:"When the job was submitted"`
+	// FinishedAt is when the job completed or failed
+	FinishedAt *time.Time `json:"finishedAt,omitempty" jsonschema:"When the job completed or failed"`
+}
+
+// Manager runs jobs against a DomainChecker and tracks their state in memory. Jobs run
+// under the context the Manager was created with, so they stop when it is done.
+type Manager struct {
+	ctx     context.Context //nolint:containedctx // jobs outlive the tool calls submitting them
+	logger  *zap.Logger
+	checker namecheap.DomainChecker
+	config  Config
+	wg      sync.WaitGroup
+
+	mu      sync.RWMutex
+	jobs    map[string]Job
+	running int
+}
+
+// NewManager creates a job manager whose jobs run under ctx, typically the server's, and
+// write their result files to config.OutputDir.
+func NewManager(ctx context.Context, logger *zap.Logger, checker namecheap.DomainChecker, config Config) *Manager {
+	if config.MaxRunning <= 0 {
+		config.MaxRunning = DefaultMaxRunning
+	}
+
+	if config.MaxDomains <= 0 {
+		config.MaxDomains = DefaultMaxDomains
+	}
+
+	if config.Retention <= 0 {
+		config.Retention = DefaultRetention
+	}
+
+	return &Manager{
+		ctx:     ctx,
+		logger:  logger,
+		checker: checker,
+		config:  config,
+		wg:      sync.WaitGroup{},
+		mu:      sync.RWMutex{},
+		jobs:    make(map[string]Job),
+		running: 0,
+	}
+}
+
+// Submit registers a job for domains and starts checking them in the background. It
+// fails with ErrTooManyDomains for a job over the size limit and with ErrTooManyJobs
+// while the maximum number of jobs is running.
+func (m *Manager) Submit(domains []string) (Job, error) {
+	if len(domains) == 0 {
+		return Job{}, namecheap.ErrMissingDomains
+	}
+
+	if len(domains) > m.config.MaxDomains {
+		return Job{}, fmt.Errorf("%w: got %d, the limit is %d", ErrTooManyDomains, len(domains), m.config.MaxDomains)
+	}
+
+	id, err := newID()
+	if err != nil {
+		return Job{}, err
+	}
+
+	job := Job{
+		ID:          id,
+		Status:      StatusRunning,
+		DomainCount: len(domains),
+		Error:       "",
+		SubmittedAt: time.Now().UTC(),
+		FinishedAt:  nil,
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.evictFinished(job.SubmittedAt)
+
+	if m.running >= m.config.MaxRunning {
+		return Job{}, fmt.Errorf("%w: %d of %d; try again once one finishes",
+			ErrTooManyJobs, m.running, m.config.MaxRunning)
+	}
+
+	m.jobs[id] = job
+	m.running++
+
+	domains = append([]string(nil), domains...)
+
+	m.wg.Go(func() { m.run(id, domains) })
+
+	return job, nil
+}
+
+// Wait blocks until every started job has finished, e.g. after the Manager's context
+// was cancelled on shutdown.
+func (m *Manager) Wait() {
+	m.wg.Wait()
+}
+
+// Get returns the current state of the job with the given ID.
+func (m *Manager) Get(id string) (Job, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	job, ok := m.jobs[id]
+	if !ok {
+		return Job{}, fmt.Errorf("%w: %q", ErrJobNotFound, id)
+	}
+
+	return job, nil
+}
+
+// Results reads the results file of a completed job.
+func (m *Manager) Results(id string) ([]namecheap.Result, error) {
+	job, err := m.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.Status != StatusCompleted {
+		return nil, fmt.Errorf("%w: %q is %s", ErrJobNotCompleted, id, job.Status)
+	}
+
+	data, err := os.ReadFile(m.resultFile(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read job results: %w", err)
+	}
+
+	var results []namecheap.Result
+
+	err = json.Unmarshal(data, &results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode job results: %w", err)
+	}
+
+	return results, nil
+}
+
+func (m *Manager) run(id string, domains []string) {
+	m.logger.Info("Job started", zap.String("job_id", id), zap.Int("domain_count", len(domains)))
+
+	err := m.check(id, domains)
+
+	finishedAt := time.Now().UTC()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.running--
+
+	job := m.jobs[id]
+	job.FinishedAt = &finishedAt
+
+	if err != nil {
+		job.Status = StatusFailed
+		job.Error = err.Error()
+
+		m.logger.Warn("Job failed", zap.String("job_id", id), zap.Error(err))
+	} else {
+		job.Status = StatusCompleted
+
+		m.logger.Info("Job completed", zap.String("job_id", id), zap.String("result_file", m.resultFile(id)))
+	}
+
+	m.jobs[id] = job
+}
+
+// check runs the domains through the checker, which batches them as its backend needs,
+// and writes the results file. The file is written to a temporary name and renamed, so
+// a reader never sees it half written.
+func (m *Manager) check(id string, domains []string) error {
+	results, err := m.checker.DomainsCheck(m.ctx, domains)
+	if err != nil && !namecheap.IsPartial(err) {
+		return err
+	}
+
+	data, err := json.Marshal(results)
+	if err != nil {
+		return fmt.Errorf("failed to encode job results: %w", err)
+	}
+
+	err = os.MkdirAll(m.config.OutputDir, dirPermissions)
+	if err != nil {
+		return fmt.Errorf("failed to create job output directory: %w", err)
+	}
+
+	resultFile := m.resultFile(id)
+
+	err = os.WriteFile(resultFile+".tmp", data, filePermissions)
+	if err != nil {
+		return fmt.Errorf("failed to write job results: %w", err)
+	}
+
+	err = os.Rename(resultFile+".tmp", resultFile)
+	if err != nil {
+		return fmt.Errorf("failed to write job results: %w", err)
+	}
+
+	return nil
+}
+
+// evictFinished drops the jobs that finished more than the retention period before now,
+// with their result files. Callers hold m.mu.
+func (m *Manager) evictFinished(now time.Time) {
+	for id, job := range m.jobs {
+		if job.FinishedAt == nil || now.Sub(*job.FinishedAt) < m.config.Retention {
+			continue
+		}
+
+		delete(m.jobs, id)
+
+		err := os.Remove(m.resultFile(id))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			m.logger.Warn("Failed to remove expired job results", zap.String("job_id", id), zap.Error(err))
+		}
+	}
+}
+
+// resultFile returns the path of the results file of the job with the given ID.
+func (m *Manager) resultFile(id string) string {
+	return filepath.Join(m.config.OutputDir, id+".json")
+}
+
+func newID() (string, error) {
+	b := make([]byte, idBytes)
+
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate job ID: %w", err)
+	}
+
+	return hex.EncodeToString(b), nil
+}
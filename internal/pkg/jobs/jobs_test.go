@@ -0,0 +1,262 @@
+package jobs_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/jobs"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap/namecheaptest"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/tool"
+	"go.uber.org/zap"
+)
+
+var errUpstream = errors.New("upstream down")
+
+// jobsConfig returns the default job limits with results written to outputDir.
+func jobsConfig(outputDir string) jobs.Config {
+	return jobs.Config{OutputDir: outputDir, MaxRunning: 0, MaxDomains: 0, Retention: 0}
+}
+
+// blockingChecker holds every check until its context is done.
+type blockingChecker struct {
+	started chan struct{}
+}
+
+func (b *blockingChecker) DomainsCheck(ctx context.Context, _ []string) ([]namecheap.Result, error) {
+	b.started <- struct{}{}
+	<-ctx.Done()
+
+	return nil, ctx.Err()
+}
+
+func (b *blockingChecker) Name() string {
+	return "blocking"
+}
+
+func (b *blockingChecker) Description() string {
+	return "blocking"
+}
+
+// waitForJob polls the status tool until the job leaves the running state.
+func waitForJob(t *testing.T, status *jobs.StatusService, id string) jobs.Job {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+
+	for time.Now().Before(deadline) {
+		out, err := status.Execute(context.Background(), jobs.JobParamsIn{JobID: id})
+		if err != nil {
+			t.Fatalf("status Execute() unexpected error: %v", err)
+		}
+
+		if out.Job.Status != jobs.StatusRunning {
+			return out.Job
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Fatalf("job %s did not finish in time", id)
+
+	return jobs.Job{}
+}
+
+func TestJob_CompletesAndWritesResults(t *testing.T) {
+	t.Parallel()
+
+	outputDir := t.TempDir()
+	checker := &namecheaptest.Checker{} //nolint:exhaustruct
+	manager := jobs.NewManager(context.Background(), zap.NewNop(), checker, jobsConfig(outputDir))
+
+	domains := make([]string, 120)
+	for i := range domains {
+		domains[i] = fmt.Sprintf("domain%d.com", i)
+	}
+
+	submitted, err := jobs.NewSubmitService(manager).Execute(context.Background(), jobs.SubmitParamsIn{Domains: domains})
+	if err != nil {
+		t.Fatalf("submit Execute() unexpected error: %v", err)
+	}
+
+	if submitted.Job.ID == "" || submitted.Job.DomainCount != len(domains) {
+		t.Fatalf("submitted job = %+v, want an ID and %d domains", submitted.Job, len(domains))
+	}
+
+	job := waitForJob(t, jobs.NewStatusService(manager), submitted.Job.ID)
+	if job.Status != jobs.StatusCompleted {
+		t.Fatalf("job status = %q (%s), want %q", job.Status, job.Error, jobs.StatusCompleted)
+	}
+
+	_, err = os.Stat(filepath.Join(outputDir, job.ID+".json"))
+	if err != nil {
+		t.Errorf("result file not written: %v", err)
+	}
+
+	out, err := jobs.NewResultsService(manager).Execute(context.Background(), jobs.JobParamsIn{JobID: job.ID})
+	if err != nil {
+		t.Fatalf("results Execute() unexpected error: %v", err)
+	}
+
+	if len(out.Results) != len(domains) || out.Results[119].Domain != "domain119.com" {
+		t.Errorf("got %d results, want %d in submission order", len(out.Results), len(domains))
+	}
+
+	// The checker batches for its backend, so the job hands it every domain at once.
+	if checker.Calls() != 1 {
+		t.Errorf("checker called %d times, want 1", checker.Calls())
+	}
+}
+
+func TestJob_ResultsKeepAvailableWhenOmitted(t *testing.T) {
+	t.Parallel()
+
+	checker := namecheap.NewOmitAvailableChecker(&namecheaptest.Checker{}) //nolint:exhaustruct
+	manager := jobs.NewManager(context.Background(), zap.NewNop(), checker, jobsConfig(t.TempDir()))
+
+	submitted, err := jobs.NewSubmitService(manager).Execute(context.Background(),
+		jobs.SubmitParamsIn{Domains: []string{"free.com"}})
+	if err != nil {
+		t.Fatalf("submit Execute() unexpected error: %v", err)
+	}
+
+	job := waitForJob(t, jobs.NewStatusService(manager), submitted.Job.ID)
+	if job.Status != jobs.StatusCompleted {
+		t.Fatalf("job status = %q (%s), want %q", job.Status, job.Error, jobs.StatusCompleted)
+	}
+
+	out, err := jobs.NewResultsService(manager).Execute(context.Background(), jobs.JobParamsIn{JobID: job.ID})
+	if err != nil {
+		t.Fatalf("results Execute() unexpected error: %v", err)
+	}
+
+	if len(out.Results) != 1 || !out.Results[0].Available ||
+		out.Results[0].Availability != namecheap.AvailabilityAvailable {
+		t.Fatalf("Results = %+v, want free.com available", out.Results)
+	}
+
+	data, err := json.Marshal(out.Results[0])
+	if err != nil {
+		t.Fatalf("Marshal() unexpected error: %v", err)
+	}
+
+	if !strings.Contains(string(data), `"available":true`) {
+		t.Errorf("re-encoded result = %s, want \"available\":true", data)
+	}
+}
+
+func TestJob_Failure(t *testing.T) {
+	t.Parallel()
+
+	checker := &namecheaptest.Checker{Err: errUpstream} //nolint:exhaustruct
+	manager := jobs.NewManager(context.Background(), zap.NewNop(), checker, jobsConfig(t.TempDir()))
+
+	submitted, err := jobs.NewSubmitService(manager).Execute(context.Background(),
+		jobs.SubmitParamsIn{Domains: []string{"example.com"}})
+	if err != nil {
+		t.Fatalf("submit Execute() unexpected error: %v", err)
+	}
+
+	job := waitForJob(t, jobs.NewStatusService(manager), submitted.Job.ID)
+	if job.Status != jobs.StatusFailed || job.Error != errUpstream.Error() {
+		t.Errorf("job = %+v, want failed with %q", job, errUpstream)
+	}
+
+	_, err = jobs.NewResultsService(manager).Execute(context.Background(), jobs.JobParamsIn{JobID: job.ID})
+	if !errors.Is(err, jobs.ErrJobNotCompleted) {
+		t.Errorf("results Execute() error = %v, want %v", err, jobs.ErrJobNotCompleted)
+	}
+}
+
+func TestJob_UnknownID(t *testing.T) {
+	t.Parallel()
+
+	manager := jobs.NewManager(context.Background(), zap.NewNop(), &namecheaptest.Checker{}, //nolint:exhaustruct
+		jobsConfig(t.TempDir()))
+
+	_, err := jobs.NewStatusService(manager).Execute(context.Background(), jobs.JobParamsIn{JobID: "missing"})
+	if !errors.Is(err, jobs.ErrJobNotFound) {
+		t.Errorf("status Execute() error = %v, want %v", err, jobs.ErrJobNotFound)
+	}
+}
+
+func TestJob_Limits(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	checker := &blockingChecker{started: make(chan struct{}, 1)}
+	manager := jobs.NewManager(ctx, zap.NewNop(), checker, jobs.Config{
+		OutputDir:  t.TempDir(),
+		MaxRunning: 1,
+		MaxDomains: 2,
+		Retention:  0,
+	})
+	submit := jobs.NewSubmitService(manager)
+
+	_, err := submit.Execute(context.Background(), jobs.SubmitParamsIn{Domains: []string{"a.com", "b.com", "c.com"}})
+	if !errors.Is(err, jobs.ErrTooManyDomains) || !errors.Is(err, tool.ErrInvalidInput) {
+		t.Errorf("Execute() error = %v, want %v", err, jobs.ErrTooManyDomains)
+	}
+
+	running, err := submit.Execute(context.Background(), jobs.SubmitParamsIn{Domains: []string{"a.com"}})
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+
+	<-checker.started
+
+	_, err = submit.Execute(context.Background(), jobs.SubmitParamsIn{Domains: []string{"b.com"}})
+	if !errors.Is(err, jobs.ErrTooManyJobs) {
+		t.Errorf("Execute() error = %v, want %v while a job runs", err, jobs.ErrTooManyJobs)
+	}
+
+	// Shutting down cancels the running job, and Wait returns once it has stopped.
+	cancel()
+	manager.Wait()
+
+	job, err := manager.Get(running.Job.ID)
+	if err != nil || job.Status != jobs.StatusFailed || job.Error != context.Canceled.Error() {
+		t.Errorf("Get() = %+v, %v; want failed with %q", job, err, context.Canceled)
+	}
+}
+
+func TestJob_EvictsFinishedJobs(t *testing.T) {
+	t.Parallel()
+
+	outputDir := t.TempDir()
+	manager := jobs.NewManager(context.Background(), zap.NewNop(), &namecheaptest.Checker{}, //nolint:exhaustruct
+		jobs.Config{OutputDir: outputDir, MaxRunning: 0, MaxDomains: 0, Retention: time.Nanosecond})
+	submit := jobs.NewSubmitService(manager)
+
+	first, err := submit.Execute(context.Background(), jobs.SubmitParamsIn{Domains: []string{"a.com"}})
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+
+	waitForJob(t, jobs.NewStatusService(manager), first.Job.ID)
+
+	// Submitting drops the jobs whose retention has passed.
+	_, err = submit.Execute(context.Background(), jobs.SubmitParamsIn{Domains: []string{"b.com"}})
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+
+	_, err = manager.Get(first.Job.ID)
+	if !errors.Is(err, jobs.ErrJobNotFound) {
+		t.Errorf("Get() error = %v, want %v for an expired job", err, jobs.ErrJobNotFound)
+	}
+
+	_, err = os.Stat(filepath.Join(outputDir, first.Job.ID+".json"))
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expired result file still present: %v", err)
+	}
+
+	manager.Wait()
+}
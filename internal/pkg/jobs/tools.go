@@ -0,0 +1,153 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/tool"
+)
+
+// ErrMissingJobID is returned when no job ID is provided.
+var ErrMissingJobID = errors.New("missing job ID")
+
+// SubmitParamsIn represents the input parameters for submitting a job.
+type SubmitParamsIn struct {
+	// Domains is the list of domain names to check, up to the server's job size limit
+	Domains []string `json:"domains" jsonschema:"The domains to check, e.g. example.com,example.org"`
+}
+
+// DomainCount returns the number of domains submitted, for logging.
+func (p SubmitParamsIn) DomainCount() int {
+	return len(p.Domains)
+}
+
+// JobParamsIn identifies a job.
+type JobParamsIn struct {
+	// JobID is the ID returned when the job was submitted
+	JobID string `json:"jobId" jsonschema:"The job ID returned by submit_check_job"`
+}
+
+// JobParamsOut reports the state of a job.
+type JobParamsOut struct {
+	// Job is the job state
+	Job Job `json:"job" jsonschema:"The job state"`
+}
+
+// ResultsParamsOut contains the results of a completed job.
+type ResultsParamsOut struct {
+	// Results contains the availability information for each checked domain
+	Results []namecheap.Result `json:"results" jsonschema:"The results of the domain checks"`
+}
+
+// SubmitService exposes job submission as an MCP tool.
+type SubmitService struct {
+	manager *Manager
+}
+
+// NewSubmitService creates the job submission tool service.
+func NewSubmitService(manager *Manager) *SubmitService {
+	return &SubmitService{manager: manager}
+}
+
+// Name returns the name of the job submission service.
+func (s *SubmitService) Name() string {
+	return "submit_check_job"
+}
+
+// Description returns a description of the job submission service.
+func (s *SubmitService) Description() string {
+	return "Submit a large list of domains to check in the background. Returns a job ID to poll " +
+		"with check_job_status and fetch with get_job_results"
+}
+
+// Execute submits the domains as a new job.
+func (s *SubmitService) Execute(_ context.Context, in SubmitParamsIn) (JobParamsOut, error) {
+	if len(in.Domains) == 0 {
+		return JobParamsOut{}, fmt.Errorf("%w: %w", tool.ErrInvalidInput, namecheap.ErrMissingDomains)
+	}
+
+	job, err := s.manager.Submit(in.Domains)
+	if errors.Is(err, ErrTooManyDomains) {
+		return JobParamsOut{}, fmt.Errorf("%w: %w", tool.ErrInvalidInput, err)
+	}
+
+	if err != nil {
+		return JobParamsOut{}, err
+	}
+
+	return JobParamsOut{Job: job}, nil
+}
+
+// StatusService exposes job status lookups as an MCP tool.
+type StatusService struct {
+	manager *Manager
+}
+
+// NewStatusService creates the job status tool service.
+func NewStatusService(manager *Manager) *StatusService {
+	return &StatusService{manager: manager}
+}
+
+// Name returns the name of the job status service.
+func (s *StatusService) Name() string {
+	return "check_job_status"
+}
+
+// Description returns a description of the job status service.
+func (s *StatusService) Description() string {
+	return "Report whether a domain check job is running, completed or failed"
+}
+
+// Execute returns the state of the requested job.
+func (s *StatusService) Execute(_ context.Context, in JobParamsIn) (JobParamsOut, error) {
+	if in.JobID == "" {
+		return JobParamsOut{}, fmt.Errorf("%w: %w", tool.ErrInvalidInput, ErrMissingJobID)
+	}
+
+	job, err := s.manager.Get(in.JobID)
+	if err != nil {
+		return JobParamsOut{}, fmt.Errorf("%w: %w", tool.ErrInvalidInput, err)
+	}
+
+	return JobParamsOut{Job: job}, nil
+}
+
+// ResultsService exposes completed job results as an MCP tool.
+type ResultsService struct {
+	manager *Manager
+}
+
+// NewResultsService creates the job results tool service.
+func NewResultsService(manager *Manager) *ResultsService {
+	return &ResultsService{manager: manager}
+}
+
+// Name returns the name of the job results service.
+func (r *ResultsService) Name() string {
+	return "get_job_results"
+}
+
+// Description returns a description of the job results service.
+func (r *ResultsService) Description() string {
+	return "Return the results of a completed domain check job"
+}
+
+// Execute returns the results of the requested job.
+func (r *ResultsService) Execute(_ context.Context, in JobParamsIn) (ResultsParamsOut, error) {
+	if in.JobID == "" {
+		return ResultsParamsOut{}, fmt.Errorf("%w: %w", tool.ErrInvalidInput, ErrMissingJobID)
+	}
+
+	results, err := r.manager.Results(in.JobID)
+	if errors.Is(err, ErrJobNotFound) || errors.Is(err, ErrJobNotCompleted) {
+		return ResultsParamsOut{}, fmt.Errorf("%w: %w", tool.ErrInvalidInput, err)
+	}
+
+	if err != nil {
+		return ResultsParamsOut{}, err
+	}
+
+	return ResultsParamsOut{Results: results}, nil
+}
@@ -0,0 +1,62 @@
+// Package limit paces and bounds the work done with a provider. Checker decorates any
+// namecheap.DomainChecker to bound how many checks run at once, and Transport paces the
+// HTTP requests a provider sends, since one check may send a request per domain.
+package limit
+
+import (
+	"context"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+)
+
+// Config holds the limits of a Checker.
+type Config struct {
+	// MaxConcurrency is how many checks may run at once; zero or less is unlimited
+	MaxConcurrency int
+}
+
+// Checker is a DomainChecker that waits for a free concurrency slot before forwarding
+// each check to the wrapped checker.
+type Checker struct {
+	checker namecheap.DomainChecker
+	// slots holds a token per running check; nil when MaxConcurrency is unset
+	slots chan struct{}
+}
+
+// NewChecker wraps checker with the limits in config.
+func NewChecker(checker namecheap.DomainChecker, config Config) *Checker {
+	var slots chan struct{}
+	if config.MaxConcurrency > 0 {
+		slots = make(chan struct{}, config.MaxConcurrency)
+	}
+
+	return &Checker{
+		checker: checker,
+		slots:   slots,
+	}
+}
+
+// Name returns the name of the wrapped checker.
+func (c *Checker) Name() string {
+	return c.checker.Name()
+}
+
+// Description returns the description of the wrapped checker.
+func (c *Checker) Description() string {
+	return c.checker.Description()
+}
+
+// DomainsCheck waits until a concurrency slot is free, then forwards the check. A ctx
+// that ends while waiting fails the check with the context error.
+func (c *Checker) DomainsCheck(ctx context.Context, domains []string) ([]namecheap.Result, error) {
+	if c.slots != nil {
+		select {
+		case c.slots <- struct{}{}:
+			defer func() { <-c.slots }()
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	return c.checker.DomainsCheck(ctx, domains)
+}
@@ -0,0 +1,116 @@
+package limit_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/limit"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+)
+
+// blockingChecker holds every check until release is closed, tracking how many run at once.
+type blockingChecker struct {
+	release chan struct{}
+	running atomic.Int32
+	peak    atomic.Int32
+	started chan struct{}
+}
+
+func (b *blockingChecker) DomainsCheck(_ context.Context, domains []string) ([]namecheap.Result, error) {
+	running := b.running.Add(1)
+	defer b.running.Add(-1)
+
+	for {
+		peak := b.peak.Load()
+		if running <= peak || b.peak.CompareAndSwap(peak, running) {
+			break
+		}
+	}
+
+	b.started <- struct{}{}
+	<-b.release
+
+	return make([]namecheap.Result, len(domains)), nil
+}
+
+func (b *blockingChecker) Name() string {
+	return "blocking"
+}
+
+func (b *blockingChecker) Description() string {
+	return "blocking"
+}
+
+func TestChecker_MaxConcurrency(t *testing.T) {
+	t.Parallel()
+
+	const (
+		maxConcurrency = 2
+		checks         = 5
+	)
+
+	upstream := &blockingChecker{
+		release: make(chan struct{}),
+		running: atomic.Int32{},
+		peak:    atomic.Int32{},
+		started: make(chan struct{}, checks),
+	}
+	checker := limit.NewChecker(upstream, limit.Config{MaxConcurrency: maxConcurrency})
+
+	var wg sync.WaitGroup
+
+	for range checks {
+		wg.Go(func() {
+			_, err := checker.DomainsCheck(context.Background(), []string{"example.com"})
+			if err != nil {
+				t.Errorf("DomainsCheck() unexpected error: %v", err)
+			}
+		})
+	}
+
+	for range maxConcurrency {
+		<-upstream.started
+	}
+
+	close(upstream.release)
+	wg.Wait()
+
+	if peak := upstream.peak.Load(); peak != maxConcurrency {
+		t.Errorf("peak concurrency = %d, want %d", peak, maxConcurrency)
+	}
+}
+
+func TestChecker_ContextCancelledWhileWaiting(t *testing.T) {
+	t.Parallel()
+
+	upstream := &blockingChecker{
+		release: make(chan struct{}),
+		running: atomic.Int32{},
+		peak:    atomic.Int32{},
+		started: make(chan struct{}, 1),
+	}
+	checker := limit.NewChecker(upstream, limit.Config{MaxConcurrency: 1})
+
+	done := make(chan struct{})
+
+	go func() {
+		_, _ = checker.DomainsCheck(context.Background(), []string{"first.com"})
+		close(done)
+	}()
+
+	<-upstream.started
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := checker.DomainsCheck(ctx, []string{"second.com"})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("DomainsCheck() error = %v, want %v", err, context.Canceled)
+	}
+
+	close(upstream.release)
+	<-done
+}
@@ -0,0 +1,47 @@
+package limit
+
+import (
+	"fmt"
+	"net/http"
+
+	"golang.org/x/time/rate"
+)
+
+// Transport paces the requests sent through it, so a provider that sends one request
+// per domain can't burst past its rate limit within a single check.
+type Transport struct {
+	base http.RoundTripper
+	// limiter paces requests; nil when the rate is unset
+	limiter *rate.Limiter
+}
+
+// NewTransport wraps base so at most ratePerSecond requests start per second. A rate of
+// zero or less passes requests straight through.
+func NewTransport(base http.RoundTripper, ratePerSecond float64) *Transport {
+	var limiter *rate.Limiter
+	if ratePerSecond > 0 {
+		limiter = rate.NewLimiter(rate.Limit(ratePerSecond), 1)
+	}
+
+	return &Transport{
+		base:    base,
+		limiter: limiter,
+	}
+}
+
+// RoundTrip waits for a rate token, then sends the request. A request whose context ends
+// while waiting fails with the context error.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.limiter != nil {
+		err := t.limiter.Wait(req.Context())
+		if err != nil {
+			if req.Context().Err() != nil {
+				return nil, req.Context().Err()
+			}
+
+			return nil, fmt.Errorf("rate limit wait failed: %w", err)
+		}
+	}
+
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,78 @@
+// Package metrics holds the Prometheus metrics of the server: tool calls, domain checks per
+// provider and the latency and failures of upstream API requests. The collectors are
+// registered with the default Prometheus registry, so recording is always cheap and the
+// values are only exposed when Handler is mounted.
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace prefixes every metric name.
+const namespace = "mcp_domain_checker"
+
+//nolint:gochecknoglobals
+var (
+	toolCalls = promauto.NewCounterVec(prometheus.CounterOpts{ //nolint:exhaustruct
+		Namespace: namespace,
+		Name:      "tool_calls_total",
+		Help:      "MCP tool calls by tool and outcome (success, tool_error or error).",
+	}, []string{"tool", "outcome"})
+
+	domainChecks = promauto.NewCounterVec(prometheus.CounterOpts{ //nolint:exhaustruct
+		Namespace: namespace,
+		Name:      "domain_checks_total",
+		Help:      "Availability checks sent to a provider, each covering one or more domains.",
+	}, []string{"provider"})
+
+	domainsChecked = promauto.NewCounterVec(prometheus.CounterOpts{ //nolint:exhaustruct
+		Namespace: namespace,
+		Name:      "domains_checked_total",
+		Help:      "Domains whose availability was checked with a provider.",
+	}, []string{"provider"})
+
+	upstreamErrors = promauto.NewCounterVec(prometheus.CounterOpts{ //nolint:exhaustruct
+		Namespace: namespace,
+		Name:      "upstream_errors_total",
+		Help:      "Upstream API requests that failed or were answered with a server error status.",
+	}, []string{"provider"})
+
+	upstreamLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{ //nolint:exhaustruct
+		Namespace: namespace,
+		Name:      "upstream_request_duration_seconds",
+		Help:      "Duration of upstream API requests, retries included.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"provider"})
+)
+
+// Handler serves the metrics in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// ToolCall records a finished MCP tool call.
+func ToolCall(tool, outcome string) {
+	toolCalls.WithLabelValues(tool, outcome).Inc()
+}
+
+// DomainsCheck records one availability check of that many domains with provider.
+func DomainsCheck(provider string, domains int) {
+	domainChecks.WithLabelValues(provider).Inc()
+	domainsChecked.WithLabelValues(provider).Add(float64(domains))
+}
+
+// UpstreamRequest records an upstream API request to provider that took elapsed. It
+// counts as an error when err is set or the response status is 500 or above; client
+// error statuses are answers, e.g. RDAP reports an unregistered domain with a 404.
+func UpstreamRequest(provider string, elapsed time.Duration, resp *http.Response, err error) {
+	upstreamLatency.WithLabelValues(provider).Observe(elapsed.Seconds())
+
+	if err != nil || resp == nil || resp.StatusCode >= http.StatusInternalServerError {
+		upstreamErrors.WithLabelValues(provider).Inc()
+	}
+}
@@ -0,0 +1,56 @@
+package metrics_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/metrics"
+)
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	// Each test records under its own label values, since the collectors are shared.
+	metrics.ToolCall("test_tool", "success")
+	metrics.ToolCall("test_tool", "success")
+	metrics.ToolCall("test_tool", "error")
+	metrics.DomainsCheck("test_provider", 3)
+	metrics.DomainsCheck("test_provider", 2)
+	metrics.UpstreamRequest("test_provider", 20*time.Millisecond,
+		&http.Response{StatusCode: http.StatusOK}, nil) //nolint:exhaustruct
+	metrics.UpstreamRequest("test_provider", 20*time.Millisecond,
+		&http.Response{StatusCode: http.StatusNotFound}, nil) //nolint:exhaustruct
+	metrics.UpstreamRequest("test_provider", 20*time.Millisecond,
+		&http.Response{StatusCode: http.StatusBadGateway}, nil) //nolint:exhaustruct
+	metrics.UpstreamRequest("test_provider", 20*time.Millisecond, nil, io.ErrUnexpectedEOF)
+
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/metrics", nil)
+	rec := httptest.NewRecorder()
+
+	metrics.Handler().ServeHTTP(rec, req)
+
+	body, err := io.ReadAll(rec.Body)
+	if err != nil {
+		t.Fatalf("ReadAll() unexpected error: %v", err)
+	}
+
+	want := []string{
+		`mcp_domain_checker_tool_calls_total{outcome="success",tool="test_tool"} 2`,
+		`mcp_domain_checker_tool_calls_total{outcome="error",tool="test_tool"} 1`,
+		`mcp_domain_checker_domain_checks_total{provider="test_provider"} 2`,
+		`mcp_domain_checker_domains_checked_total{provider="test_provider"} 5`,
+		`mcp_domain_checker_upstream_errors_total{provider="test_provider"} 2`,
+		`mcp_domain_checker_upstream_request_duration_seconds_count{provider="test_provider"} 4`,
+	}
+
+	for _, line := range want {
+		if !strings.Contains(string(body), line+"\n") {
+			t.Errorf("metrics output is missing %q", line)
+		}
+	}
+}
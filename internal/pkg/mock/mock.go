@@ -0,0 +1,121 @@
+// Package mock provides a DomainChecker that answers from fixed rules instead of an API,
+// so the server can run end to end without credentials, for demos and for testing MCP
+// client integrations. Its answers say nothing about the real availability of a domain.
+package mock
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/metrics"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+)
+
+const (
+	// metricsProvider labels the metrics of this backend.
+	metricsProvider = "mock"
+
+	// PremiumPrice is the registration and renewal price of premium names.
+	PremiumPrice = 999.0
+	// StandardPrice is the registration price of the other available names.
+	StandardPrice = 10.0
+)
+
+// ErrMockFailure is the error reported for names starting with "error".
+var ErrMockFailure = errors.New("mock check failed")
+
+// Service answers availability checks from the name of each domain:
+//   - names containing "taken" are registered;
+//   - names starting with "premium" are available at PremiumPrice;
+//   - names starting with "error" fail with an error;
+//   - every other name is available at StandardPrice.
+//
+// Names are compared case-insensitively on the label before the TLD.
+type Service struct{}
+
+// NewService creates a mock service.
+func NewService() *Service {
+	return &Service{}
+}
+
+// Name returns the name of the mock service.
+func (s *Service) Name() string {
+	return "check_availability_mock"
+}
+
+// Description returns a description of the mock service.
+func (s *Service) Description() string {
+	return "Check domain availability against fixed mock rules for development; the answers aren't real: " +
+		`names containing "taken" are registered, names starting with "premium" are premium, ` +
+		`names starting with "error" fail and all others are available`
+}
+
+// DomainsCheck answers every domain from the rules of Service, in input order.
+func (s *Service) DomainsCheck(_ context.Context, domains []string) ([]namecheap.Result, error) {
+	if len(domains) == 0 {
+		return nil, namecheap.ErrMissingDomains
+	}
+
+	metrics.DomainsCheck(metricsProvider, len(domains))
+
+	results := make([]namecheap.Result, 0, len(domains))
+	for _, domain := range domains {
+		results = append(results, check(domain))
+	}
+
+	return results, nil
+}
+
+// check applies the rules to domain.
+func check(domain string) namecheap.Result {
+	name, _, _ := strings.Cut(namecheap.NormalizeDomain(domain), ".")
+
+	result := namecheap.Result{
+		Domain:                    domain,
+		Available:                 true,
+		Availability:              namecheap.AvailabilityAvailable,
+		Status:                    namecheap.StatusAvailable,
+		IsPremiumName:             false,
+		PremiumRegistrationPrice:  0,
+		PremiumRenewalPrice:       0,
+		IcannFee:                  0,
+		EapFee:                    0,
+		StandardRegistrationPrice: StandardPrice,
+		Currency:                  namecheap.CurrencyUSD,
+		Error:                     "",
+		ErrorCode:                 0,
+		RegisterURL:               "",
+		Blocked:                   false,
+		BlockReason:               "",
+		CorrelationID:             "",
+		Input:                     "",
+		ReferencePrice:            0,
+		ReferencePriceDelta:       0,
+		Raw:                       nil,
+	}
+
+	switch {
+	case strings.Contains(name, "taken"):
+		result.Available = false
+		result.Availability = namecheap.AvailabilityTaken
+		result.Status = namecheap.StatusRegistered
+		result.StandardRegistrationPrice = 0
+		result.Currency = ""
+	case strings.HasPrefix(name, "premium"):
+		result.Status = namecheap.StatusPremium
+		result.IsPremiumName = true
+		result.PremiumRegistrationPrice = PremiumPrice
+		result.PremiumRenewalPrice = PremiumPrice
+		result.StandardRegistrationPrice = 0
+	case strings.HasPrefix(name, "error"):
+		result.Available = false
+		result.Availability = namecheap.AvailabilityUnknown
+		result.Status = namecheap.StatusError
+		result.StandardRegistrationPrice = 0
+		result.Currency = ""
+		result.Error = ErrMockFailure.Error()
+	}
+
+	return result
+}
@@ -0,0 +1,66 @@
+package mock_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/mock"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+)
+
+func TestDomainsCheck(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		domain       string
+		availability string
+		status       string
+		premiumPrice float64
+		wantError    bool
+	}{
+		{domain: "example.com", availability: namecheap.AvailabilityAvailable, status: namecheap.StatusAvailable,
+			premiumPrice: 0, wantError: false},
+		{domain: "mytaken.io", availability: namecheap.AvailabilityTaken, status: namecheap.StatusRegistered,
+			premiumPrice: 0, wantError: false},
+		{domain: "PremiumShop.com", availability: namecheap.AvailabilityAvailable, status: namecheap.StatusPremium,
+			premiumPrice: mock.PremiumPrice, wantError: false},
+		{domain: "error-case.dev", availability: namecheap.AvailabilityUnknown, status: namecheap.StatusError,
+			premiumPrice: 0, wantError: true},
+		// The TLD doesn't count: only the name is compared.
+		{domain: "shop.taken", availability: namecheap.AvailabilityAvailable, status: namecheap.StatusAvailable,
+			premiumPrice: 0, wantError: false},
+	}
+
+	domains := make([]string, 0, len(tests))
+	for _, tt := range tests {
+		domains = append(domains, tt.domain)
+	}
+
+	results, err := mock.NewService().DomainsCheck(context.Background(), domains)
+	if err != nil {
+		t.Fatalf("DomainsCheck() unexpected error: %v", err)
+	}
+
+	for i, tt := range tests {
+		got := results[i]
+
+		if got.Domain != tt.domain || got.Availability != tt.availability || got.Status != tt.status ||
+			got.PremiumRegistrationPrice != tt.premiumPrice || (got.Error != "") != tt.wantError {
+			t.Errorf("DomainsCheck(%q) = %+v, want %+v", tt.domain, got, tt)
+		}
+
+		if got.Available != (tt.availability == namecheap.AvailabilityAvailable) {
+			t.Errorf("DomainsCheck(%q) Available = %v, want it to match %q", tt.domain, got.Available, tt.availability)
+		}
+	}
+}
+
+func TestDomainsCheck_NoDomains(t *testing.T) {
+	t.Parallel()
+
+	_, err := mock.NewService().DomainsCheck(context.Background(), nil)
+	if !errors.Is(err, namecheap.ErrMissingDomains) {
+		t.Errorf("DomainsCheck() error = %v, want %v", err, namecheap.ErrMissingDomains)
+	}
+}
@@ -0,0 +1,242 @@
+package namecheap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/tldtype"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/tool"
+)
+
+const (
+	// errDomainUnavailable is the Result.Error set on taken domains when TreatTakenAsError is on.
+	errDomainUnavailable = "domain unavailable"
+	// groupByTLDType nests results under their TLD type.
+	groupByTLDType = "tldType"
+)
+
+var (
+	// ErrUnknownGroupBy is returned when GroupBy names an unsupported grouping.
+	ErrUnknownGroupBy = errors.New("unknown groupBy value")
+	// ErrInvalidSLDRegex is returned when SLDRegex doesn't compile.
+	ErrInvalidSLDRegex = errors.New("invalid sldRegex")
+)
+
+// CheckService exposes a DomainChecker as the availability checking MCP tool.
+// Taking the interface rather than *Service lets decorated checkers (e.g. price
+// recording) stand in for the provider.
+type CheckService struct {
+	checker  DomainChecker
+	tldTypes tldtype.Map
+}
+
+// NewCheckService creates a tool service that checks domains with checker.
+// tldTypes classifies TLDs when results are grouped by TLD type.
+func NewCheckService(checker DomainChecker, tldTypes tldtype.Map) *CheckService {
+	return &CheckService{
+		checker:  checker,
+		tldTypes: tldTypes,
+	}
+}
+
+// Name returns the name of the underlying checker.
+func (c *CheckService) Name() string {
+	return c.checker.Name()
+}
+
+// Description returns the description of the underlying checker.
+func (c *CheckService) Description() string {
+	return c.checker.Description()
+}
+
+// Execute performs domain availability checking with the given input parameters.
+// It implements the generic Service interface for MCP tool integration.
+// Domains are normalized first, so pasted URLs are checked by their host.
+// An empty domain list is reported as tool.ErrInvalidInput with a hint so the model can retry.
+// With TreatTakenAsError, taken domains carry a "domain unavailable" error and count as errors.
+// With GroupBy "tldType", results are nested under their TLD type instead of listed flat.
+// With CorrelationID, the ID is copied onto every result and the summary.
+// Every result carries the requested string it was normalized from as Input.
+// With SLDRegex, only domains whose second-level label matches are checked; the rest are
+// dropped from the output, and a list with no match is answered without calling the checker.
+// With AvailableOnly, taken and errored domains are left out of the results; the summary
+// still counts them, and Filtered tells how many were left out.
+// With ReportDuplicates, domains requested more than once, in whatever case or form, are
+// listed under Duplicates with every string they were requested as.
+// Results follow the requested order, whatever order the checker answered in, unless
+// SortBy orders them by name, availability or price.
+// A partially failed check still returns every result, with the failed domains listed
+// under Errors.
+func (c *CheckService) Execute(ctx context.Context, in ParamsIn) (ParamsOut, error) {
+	if len(in.Domains) == 0 {
+		return ParamsOut{}, fmt.Errorf(
+			"%w: %w; supply at least one domain in the \"domains\" array, e.g. [\"example.com\"]",
+			tool.ErrInvalidInput, ErrMissingDomains)
+	}
+
+	if in.GroupBy != "" && in.GroupBy != groupByTLDType {
+		return ParamsOut{}, fmt.Errorf("%w: %w %q; the only supported value is %q",
+			tool.ErrInvalidInput, ErrUnknownGroupBy, in.GroupBy, groupByTLDType)
+	}
+
+	if !slices.Contains(sortByValues, in.SortBy) {
+		return ParamsOut{}, fmt.Errorf("%w: %w %q; use %q, %q, %q or %q", tool.ErrInvalidInput, ErrUnknownSortBy,
+			in.SortBy, sortByInput, sortByName, sortByAvailable, sortByPrice)
+	}
+
+	var sldRegex *regexp.Regexp
+
+	if in.SLDRegex != "" {
+		var err error
+
+		sldRegex, err = regexp.Compile(in.SLDRegex)
+		if err != nil {
+			return ParamsOut{}, fmt.Errorf("%w: %w: %w", tool.ErrInvalidInput, ErrInvalidSLDRegex, err)
+		}
+	}
+
+	domains := make([]string, 0, len(in.Domains))
+	// inputs maps each domain to the requested strings it was normalized from, in order,
+	// so results keep their input however the checker orders or drops them.
+	inputs := make(map[string][]string, len(in.Domains))
+
+	for _, input := range in.Domains {
+		domain := NormalizeDomain(input)
+		if sldRegex != nil && !sldRegex.MatchString(sld(domain)) {
+			continue
+		}
+
+		domains = append(domains, domain)
+		inputs[domain] = append(inputs[domain], input)
+	}
+
+	if len(domains) == 0 {
+		return ParamsOut{
+			Results: []Result{}, Groups: nil, Summary: summarize(nil, in.CorrelationID), Errors: nil, Duplicates: nil,
+		}, nil
+	}
+
+	var duplicates []Duplicate
+	if in.ReportDuplicates {
+		duplicates = duplicatesOf(domains, inputs)
+	}
+
+	results, err := c.checker.DomainsCheck(ctx, domains)
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		// A cancelled or timed-out check isn't an API failure; report the context error as is.
+		return ParamsOut{}, err
+	}
+
+	var partial *PartialError
+	if err != nil && !errors.As(err, &partial) {
+		return ParamsOut{}, apiFailed(err)
+	}
+
+	results = inInputOrder(results, domains)
+
+	for i := range results {
+		if in.TreatTakenAsError && !results[i].Available && results[i].Error == "" {
+			results[i].Error = errDomainUnavailable
+		}
+
+		results[i].CorrelationID = in.CorrelationID
+
+		key := NormalizeDomain(results[i].Domain)
+		if pending := inputs[key]; len(pending) > 0 {
+			results[i].Input = pending[0]
+			inputs[key] = pending[1:]
+		}
+	}
+
+	sortResults(results, in.SortBy)
+
+	summary := summarize(results, in.CorrelationID)
+
+	// The errors are collected before AvailableOnly drops the failed domains' results.
+	var checkErrs []CheckError
+	if partial != nil {
+		checkErrs = checkErrors(partial, results)
+	}
+
+	if in.AvailableOnly {
+		results = slices.DeleteFunc(results, func(result Result) bool {
+			return !result.Available || result.Error != ""
+		})
+
+		summary.Filtered = summary.Checked - len(results)
+	}
+
+	if in.GroupBy == groupByTLDType {
+		return ParamsOut{
+			Results: nil, Groups: c.groupByType(results), Summary: summary, Errors: checkErrs, Duplicates: duplicates,
+		}, nil
+	}
+
+	return ParamsOut{Results: results, Groups: nil, Summary: summary, Errors: checkErrs, Duplicates: duplicates}, nil
+}
+
+// duplicatesOf returns the domains requested more than once, in request order, with the
+// strings inputs maps them to.
+func duplicatesOf(domains []string, inputs map[string][]string) []Duplicate {
+	var duplicates []Duplicate
+
+	reported := make(map[string]bool)
+
+	for _, domain := range domains {
+		if len(inputs[domain]) < 2 || reported[domain] {
+			continue
+		}
+
+		reported[domain] = true
+		duplicates = append(duplicates, Duplicate{Domain: domain, Inputs: slices.Clone(inputs[domain])})
+	}
+
+	return duplicates
+}
+
+// groupByType nests results under their TLD type, in tldtype.Order, keeping the
+// input order within each group and skipping empty groups.
+func (c *CheckService) groupByType(results []Result) []ResultGroup {
+	byType := make(map[tldtype.Type][]Result, len(tldtype.Order))
+
+	for _, result := range results {
+		t := c.tldTypes.OfDomain(result.Domain)
+		byType[t] = append(byType[t], result)
+	}
+
+	groups := make([]ResultGroup, 0, len(byType))
+
+	for _, t := range tldtype.Order {
+		if len(byType[t]) > 0 {
+			groups = append(groups, ResultGroup{TLDType: string(t), Results: byType[t]})
+		}
+	}
+
+	return groups
+}
+
+// sld returns the leftmost label of domain, e.g. "example" for "example.co.uk".
+func sld(domain string) string {
+	label, _, _ := strings.Cut(domain, ".")
+
+	return label
+}
+
+// summarize counts the results of the check made with correlationID.
+func summarize(results []Result, correlationID string) Summary {
+	summary := Summary{Checked: len(results), Available: 0, Errors: 0, Filtered: 0, CorrelationID: correlationID}
+
+	for _, result := range results {
+		if result.Error != "" {
+			summary.Errors++
+		} else if result.Available {
+			summary.Available++
+		}
+	}
+
+	return summary
+}
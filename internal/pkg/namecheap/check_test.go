@@ -0,0 +1,716 @@
+package namecheap_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap/namecheaptest"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/tldtype"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/tool"
+)
+
+func checkResult(domain string, available bool, errorMessage string) namecheap.Result {
+	return namecheap.Result{
+		Domain:                    domain,
+		Available:                 available,
+		Availability:              "",
+		Status:                    "",
+		IsPremiumName:             false,
+		PremiumRegistrationPrice:  0,
+		PremiumRenewalPrice:       0,
+		IcannFee:                  0,
+		EapFee:                    0,
+		StandardRegistrationPrice: 0,
+		Currency:                  "",
+		Error:                     errorMessage,
+		ErrorCode:                 0,
+		RegisterURL:               "",
+		Blocked:                   false,
+		BlockReason:               "",
+		CorrelationID:             "",
+		Input:                     "",
+		ReferencePrice:            0,
+		ReferencePriceDelta:       0,
+		Raw:                       nil,
+	}
+}
+
+func TestCheckService_TreatTakenAsError(t *testing.T) {
+	t.Parallel()
+
+	checker := &namecheaptest.Checker{Results: []namecheap.Result{ //nolint:exhaustruct
+		checkResult("free.com", true, ""),
+		checkResult("taken.com", false, ""),
+		checkResult("broken.com", false, "upstream error"),
+	}}
+
+	tests := []struct {
+		name              string
+		treatTakenAsError bool
+		wantErrors        []string
+		wantSummary       namecheap.Summary
+	}{
+		{
+			name:              "taken domains are clean by default",
+			treatTakenAsError: false,
+			wantErrors:        []string{"", "", "upstream error"},
+			wantSummary:       namecheap.Summary{Checked: 3, Available: 1, Errors: 1, Filtered: 0, CorrelationID: ""},
+		},
+		{
+			name:              "taken domains are errors when requested",
+			treatTakenAsError: true,
+			wantErrors:        []string{"", "domain unavailable", "upstream error"},
+			wantSummary:       namecheap.Summary{Checked: 3, Available: 1, Errors: 2, Filtered: 0, CorrelationID: ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			out, err := namecheap.NewCheckService(checker, tldtype.Default()).Execute(context.Background(), namecheap.ParamsIn{
+				Domains:           []string{"free.com", "taken.com", "broken.com"},
+				TreatTakenAsError: tt.treatTakenAsError,
+				GroupBy:           "",
+				SLDRegex:          "",
+				CorrelationID:     "",
+				SortBy:            "",
+				AvailableOnly:     false,
+				ReportDuplicates:  false,
+			})
+			if err != nil {
+				t.Fatalf("Execute() unexpected error: %v", err)
+			}
+
+			for i, want := range tt.wantErrors {
+				if got := out.Results[i].Error; got != want {
+					t.Errorf("Results[%d].Error = %q, want %q", i, got, want)
+				}
+			}
+
+			if out.Summary != tt.wantSummary {
+				t.Errorf("Summary = %+v, want %+v", out.Summary, tt.wantSummary)
+			}
+		})
+	}
+}
+
+func TestCheckService_GroupByTLDType(t *testing.T) {
+	t.Parallel()
+
+	checker := &namecheaptest.Checker{Results: []namecheap.Result{ //nolint:exhaustruct
+		checkResult("brand.io", true, ""),
+		checkResult("brand.com", false, ""),
+		checkResult("brand.app", true, ""),
+		checkResult("brand.de", true, ""),
+		checkResult("brand.net", true, ""),
+	}}
+
+	// The stub classifies io as generic, overriding the two-letter country-code rule.
+	types := tldtype.Map{"com": tldtype.Generic, "net": tldtype.Generic, "io": tldtype.Generic}
+
+	out, err := namecheap.NewCheckService(checker, types).Execute(context.Background(), namecheap.ParamsIn{
+		Domains:           []string{"brand.io", "brand.com", "brand.app", "brand.de", "brand.net"},
+		TreatTakenAsError: false,
+		GroupBy:           "tldType",
+		SLDRegex:          "",
+		CorrelationID:     "",
+		SortBy:            "",
+		AvailableOnly:     false,
+		ReportDuplicates:  false,
+	})
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+
+	if out.Results != nil {
+		t.Errorf("Results = %v, want nil when grouped", out.Results)
+	}
+
+	got := make(map[string][]string, len(out.Groups))
+	order := make([]string, 0, len(out.Groups))
+
+	for _, group := range out.Groups {
+		order = append(order, group.TLDType)
+
+		for _, result := range group.Results {
+			got[group.TLDType] = append(got[group.TLDType], result.Domain)
+		}
+	}
+
+	wantOrder := []string{"gTLD", "ccTLD", "newgTLD"}
+	if !reflect.DeepEqual(order, wantOrder) {
+		t.Errorf("group order = %v, want %v", order, wantOrder)
+	}
+
+	want := map[string][]string{
+		"gTLD":    {"brand.io", "brand.com", "brand.net"},
+		"ccTLD":   {"brand.de"},
+		"newgTLD": {"brand.app"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("groups = %v, want %v", got, want)
+	}
+
+	if out.Summary.Checked != 5 || out.Summary.Available != 4 {
+		t.Errorf("Summary = %+v, want 5 checked and 4 available", out.Summary)
+	}
+}
+
+func TestCheckService_UnknownGroupBy(t *testing.T) {
+	t.Parallel()
+
+	service := namecheap.NewCheckService(&namecheaptest.Checker{}, tldtype.Default()) //nolint:exhaustruct
+
+	_, err := service.Execute(context.Background(), namecheap.ParamsIn{
+		Domains:           []string{"example.com"},
+		TreatTakenAsError: false,
+		GroupBy:           "registrar",
+		SLDRegex:          "",
+		CorrelationID:     "",
+		SortBy:            "",
+		AvailableOnly:     false,
+		ReportDuplicates:  false,
+	})
+	if !errors.Is(err, namecheap.ErrUnknownGroupBy) || !errors.Is(err, tool.ErrInvalidInput) {
+		t.Errorf("Execute() error = %v, want invalid input %v", err, namecheap.ErrUnknownGroupBy)
+	}
+}
+
+func TestCheckService_SLDRegex(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		sldRegex    string
+		wantChecked []string
+	}{
+		{
+			name:        "only matching names are checked",
+			sldRegex:    "^[a-z]{3}$",
+			wantChecked: []string{"abc.com", "xyz.co.uk"},
+		},
+		{
+			name:        "no regex checks everything",
+			sldRegex:    "",
+			wantChecked: []string{"abc.com", "abcd.com", "a1c.com", "xyz.co.uk"},
+		},
+		{
+			name:        "no match skips the checker",
+			sldRegex:    "^q",
+			wantChecked: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			checker := &namecheaptest.Checker{} //nolint:exhaustruct
+
+			out, err := namecheap.NewCheckService(checker, tldtype.Default()).Execute(context.Background(), namecheap.ParamsIn{
+				Domains:           []string{"abc.com", "abcd.com", "https://A1C.com/", "xyz.co.uk"},
+				TreatTakenAsError: false,
+				GroupBy:           "",
+				SLDRegex:          tt.sldRegex,
+				CorrelationID:     "",
+				SortBy:            "",
+				AvailableOnly:     false,
+				ReportDuplicates:  false,
+			})
+			if err != nil {
+				t.Fatalf("Execute() unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(checker.Checked(), tt.wantChecked) {
+				t.Errorf("checked = %v, want %v", checker.Checked(), tt.wantChecked)
+			}
+
+			if out.Summary.Checked != len(tt.wantChecked) {
+				t.Errorf("Summary.Checked = %d, want %d", out.Summary.Checked, len(tt.wantChecked))
+			}
+		})
+	}
+}
+
+func TestCheckService_InvalidSLDRegex(t *testing.T) {
+	t.Parallel()
+
+	service := namecheap.NewCheckService(&namecheaptest.Checker{}, tldtype.Default()) //nolint:exhaustruct
+
+	_, err := service.Execute(context.Background(), namecheap.ParamsIn{
+		Domains:           []string{"example.com"},
+		TreatTakenAsError: false,
+		GroupBy:           "",
+		SLDRegex:          "[a-z",
+		CorrelationID:     "",
+		SortBy:            "",
+		AvailableOnly:     false,
+		ReportDuplicates:  false,
+	})
+	if !errors.Is(err, namecheap.ErrInvalidSLDRegex) || !errors.Is(err, tool.ErrInvalidInput) {
+		t.Errorf("Execute() error = %v, want invalid input %v", err, namecheap.ErrInvalidSLDRegex)
+	}
+}
+
+func TestCheckService_CorrelationID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		correlationID string
+		groupBy       string
+	}{
+		{name: "flat results", correlationID: "req-42", groupBy: ""},
+		{name: "grouped results", correlationID: "req-42", groupBy: "tldType"},
+		{name: "absent when not supplied", correlationID: "", groupBy: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			checker := &namecheaptest.Checker{} //nolint:exhaustruct
+
+			out, err := namecheap.NewCheckService(checker, tldtype.Default()).Execute(context.Background(), namecheap.ParamsIn{
+				Domains:           []string{"brand.com", "brand.de", "brand.app"},
+				TreatTakenAsError: false,
+				GroupBy:           tt.groupBy,
+				SLDRegex:          "",
+				CorrelationID:     tt.correlationID,
+				SortBy:            "",
+				AvailableOnly:     false,
+				ReportDuplicates:  false,
+			})
+			if err != nil {
+				t.Fatalf("Execute() unexpected error: %v", err)
+			}
+
+			results := out.Results
+			for _, group := range out.Groups {
+				results = append(results, group.Results...)
+			}
+
+			if len(results) != 3 {
+				t.Fatalf("got %d results, want 3", len(results))
+			}
+
+			for _, result := range results {
+				if result.CorrelationID != tt.correlationID {
+					t.Errorf("%s: CorrelationID = %q, want %q", result.Domain, result.CorrelationID, tt.correlationID)
+				}
+			}
+
+			if out.Summary.CorrelationID != tt.correlationID {
+				t.Errorf("Summary.CorrelationID = %q, want %q", out.Summary.CorrelationID, tt.correlationID)
+			}
+		})
+	}
+}
+
+func TestCheckService_Input(t *testing.T) {
+	t.Parallel()
+
+	inputs := []string{" https://Brand.COM/pricing?ref=ad ", "brand.io", "BRAND.de."}
+
+	out, err := namecheap.NewCheckService(&namecheaptest.Checker{}, tldtype.Default()).Execute( //nolint:exhaustruct
+		context.Background(), namecheap.ParamsIn{
+			Domains:           inputs,
+			TreatTakenAsError: false,
+			GroupBy:           "",
+			SLDRegex:          "",
+			CorrelationID:     "",
+			SortBy:            "",
+			AvailableOnly:     false,
+			ReportDuplicates:  false,
+		})
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+
+	wantDomains := []string{"brand.com", "brand.io", "brand.de"}
+
+	if len(out.Results) != len(inputs) {
+		t.Fatalf("got %d results, want %d", len(out.Results), len(inputs))
+	}
+
+	for i, result := range out.Results {
+		if result.Domain != wantDomains[i] || result.Input != inputs[i] {
+			t.Errorf("results[%d] Domain, Input = %q, %q, want %q, %q",
+				i, result.Domain, result.Input, wantDomains[i], inputs[i])
+		}
+	}
+}
+
+func TestCheckService_InputWithDroppedDomain(t *testing.T) {
+	t.Parallel()
+
+	// The checker answers out of order and leaves brand.com out.
+	checker := &namecheaptest.Checker{Results: []namecheap.Result{ //nolint:exhaustruct
+		checkResult("brand.de", true, ""),
+		checkResult("brand.io", true, ""),
+	}}
+
+	out, err := namecheap.NewCheckService(checker, tldtype.Default()).Execute(
+		context.Background(), namecheap.ParamsIn{
+			Domains:           []string{"https://Brand.com/", "Brand.IO", "brand.de."},
+			TreatTakenAsError: false,
+			GroupBy:           "",
+			SLDRegex:          "",
+			CorrelationID:     "",
+			SortBy:            "",
+			AvailableOnly:     false,
+			ReportDuplicates:  false,
+		})
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+
+	want := map[string]string{"brand.io": "Brand.IO", "brand.de": "brand.de."}
+
+	for _, result := range out.Results {
+		if result.Input != want[result.Domain] {
+			t.Errorf("%s: Input = %q, want %q", result.Domain, result.Input, want[result.Domain])
+		}
+	}
+}
+
+func TestCheckService_PartialFailure(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		availableOnly bool
+		wantResults   int
+		wantSummary   namecheap.Summary
+	}{
+		{
+			name:          "all results",
+			availableOnly: false,
+			wantResults:   5,
+			wantSummary:   namecheap.Summary{Checked: 5, Available: 1, Errors: 3, Filtered: 0, CorrelationID: ""},
+		},
+		{
+			name:          "errors still listed with availableOnly",
+			availableOnly: true,
+			wantResults:   1,
+			wantSummary:   namecheap.Summary{Checked: 5, Available: 1, Errors: 3, Filtered: 4, CorrelationID: ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			checker := &namecheaptest.Checker{ //nolint:exhaustruct
+				Results: []namecheap.Result{
+					checkResult("free.com", true, ""),
+					checkResult("limited.com", false, "API error: Too many requests"),
+					checkResult("taken.com", false, ""),
+					checkResult("limited.net", false, "API error: Too many requests"),
+					checkResult("timeout.org", false, "HTTP request failed: timeout"),
+				},
+				PartialErr: errors.New("API error: Too many requests"), //nolint:err113
+			}
+
+			out, err := namecheap.NewCheckService(checker, tldtype.Default()).Execute(context.Background(), namecheap.ParamsIn{
+				Domains:           []string{"free.com", "limited.com", "taken.com", "limited.net", "timeout.org"},
+				TreatTakenAsError: false,
+				GroupBy:           "",
+				SLDRegex:          "",
+				CorrelationID:     "",
+				SortBy:            "",
+				AvailableOnly:     tt.availableOnly,
+				ReportDuplicates:  false,
+			})
+			if err != nil {
+				t.Fatalf("Execute() unexpected error: %v", err)
+			}
+
+			if len(out.Results) != tt.wantResults {
+				t.Fatalf("got %d results, want %d", len(out.Results), tt.wantResults)
+			}
+
+			wantErrors := []namecheap.CheckError{
+				{Error: "API error: Too many requests", Domains: []string{"limited.com", "limited.net"}},
+				{Error: "HTTP request failed: timeout", Domains: []string{"timeout.org"}},
+			}
+			if !reflect.DeepEqual(out.Errors, wantErrors) {
+				t.Errorf("Errors = %+v, want %+v", out.Errors, wantErrors)
+			}
+
+			if out.Summary != tt.wantSummary {
+				t.Errorf("Summary = %+v, want %+v", out.Summary, tt.wantSummary)
+			}
+		})
+	}
+}
+
+func TestCheckService_SortBy(t *testing.T) {
+	t.Parallel()
+
+	priced := func(domain string, price float64) namecheap.Result {
+		result := checkResult(domain, true, "")
+		result.Availability = namecheap.AvailabilityAvailable
+		result.IsPremiumName = true
+		result.PremiumRegistrationPrice = price
+
+		return result
+	}
+
+	taken := checkResult("bravo.com", false, "")
+	taken.Availability = namecheap.AvailabilityTaken
+
+	broken := checkResult("alpha.com", false, "upstream error")
+	broken.Availability = namecheap.AvailabilityUnknown
+
+	// The checker answers in its own order, not the requested one.
+	checker := &namecheaptest.Checker{Results: []namecheap.Result{ //nolint:exhaustruct
+		priced("delta.com", 25), taken, broken, priced("Charlie.com", 12), priced("echo.com", 300),
+	}}
+	requested := []string{"alpha.com", "bravo.com", "charlie.com", "delta.com", "echo.com"}
+
+	tests := []struct {
+		name   string
+		sortBy string
+		want   []string
+	}{
+		{
+			name:   "default is input order",
+			sortBy: "",
+			want:   []string{"alpha.com", "bravo.com", "Charlie.com", "delta.com", "echo.com"},
+		},
+		{
+			name:   "input",
+			sortBy: "input",
+			want:   []string{"alpha.com", "bravo.com", "Charlie.com", "delta.com", "echo.com"},
+		},
+		{
+			name:   "name",
+			sortBy: "name",
+			want:   []string{"alpha.com", "bravo.com", "Charlie.com", "delta.com", "echo.com"},
+		},
+		{
+			name:   "available first",
+			sortBy: "available",
+			want:   []string{"Charlie.com", "delta.com", "echo.com", "bravo.com", "alpha.com"},
+		},
+		{
+			name:   "cheapest first",
+			sortBy: "price",
+			want:   []string{"Charlie.com", "delta.com", "echo.com", "alpha.com", "bravo.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			service := namecheap.NewCheckService(checker, tldtype.Default())
+
+			for range 2 {
+				out, err := service.Execute(context.Background(), namecheap.ParamsIn{
+					Domains:           requested,
+					TreatTakenAsError: false,
+					GroupBy:           "",
+					SLDRegex:          "",
+					CorrelationID:     "",
+					SortBy:            tt.sortBy,
+					AvailableOnly:     false,
+					ReportDuplicates:  false,
+				})
+				if err != nil {
+					t.Fatalf("Execute() unexpected error: %v", err)
+				}
+
+				got := make([]string, 0, len(out.Results))
+				for _, result := range out.Results {
+					got = append(got, result.Domain)
+				}
+
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("order = %v, want %v", got, tt.want)
+				}
+
+				// Inputs follow their domain, not the checker's answer order.
+				for _, result := range out.Results {
+					if !strings.EqualFold(result.Input, result.Domain) {
+						t.Errorf("%s has Input %q", result.Domain, result.Input)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestMatchResults(t *testing.T) {
+	t.Parallel()
+
+	// The backend answers in its own order, repeats a domain, leaves one out and adds one.
+	results := []namecheap.Result{
+		checkResult("b.com", false, ""),
+		checkResult("A.com", true, ""),
+		checkResult("extra.com", true, ""),
+		checkResult("a.com", false, ""),
+	}
+
+	got := namecheap.MatchResults([]string{"a.com", "missing.com", "b.com", "a.com"}, results)
+
+	want := []struct {
+		domain    string
+		available bool
+		err       string
+	}{
+		{domain: "A.com", available: true, err: ""},
+		{domain: "missing.com", available: false, err: namecheap.ErrMissingResult.Error()},
+		{domain: "b.com", available: false, err: ""},
+		{domain: "a.com", available: false, err: ""},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("MatchResults() returned %d results, want %d", len(got), len(want))
+	}
+
+	for i, w := range want {
+		if got[i].Domain != w.domain || got[i].Available != w.available || got[i].Error != w.err {
+			t.Errorf("result %d = %+v, want %+v", i, got[i], w)
+		}
+	}
+}
+
+func TestCheckService_UnknownSortBy(t *testing.T) {
+	t.Parallel()
+
+	service := namecheap.NewCheckService(&namecheaptest.Checker{}, tldtype.Default()) //nolint:exhaustruct
+
+	_, err := service.Execute(context.Background(), namecheap.ParamsIn{
+		Domains:           []string{"example.com"},
+		TreatTakenAsError: false,
+		GroupBy:           "",
+		SLDRegex:          "",
+		CorrelationID:     "",
+		SortBy:            "cheapest",
+		AvailableOnly:     false,
+		ReportDuplicates:  false,
+	})
+	if !errors.Is(err, tool.ErrInvalidInput) || !errors.Is(err, namecheap.ErrUnknownSortBy) {
+		t.Errorf("Execute() error = %v, want %v and %v", err, tool.ErrInvalidInput, namecheap.ErrUnknownSortBy)
+	}
+}
+
+func TestCheckService_AvailableOnly(t *testing.T) {
+	t.Parallel()
+
+	checker := &namecheaptest.Checker{Results: []namecheap.Result{ //nolint:exhaustruct
+		checkResult("free.com", true, ""),
+		checkResult("taken.com", false, ""),
+		checkResult("broken.com", true, "upstream error"),
+		checkResult("free.net", true, ""),
+	}}
+
+	tests := []struct {
+		name          string
+		availableOnly bool
+		want          []string
+		wantSummary   namecheap.Summary
+	}{
+		{
+			name:          "all results by default",
+			availableOnly: false,
+			want:          []string{"free.com", "taken.com", "broken.com", "free.net"},
+			wantSummary:   namecheap.Summary{Checked: 4, Available: 2, Errors: 1, Filtered: 0, CorrelationID: ""},
+		},
+		{
+			name:          "taken and errored left out",
+			availableOnly: true,
+			want:          []string{"free.com", "free.net"},
+			wantSummary:   namecheap.Summary{Checked: 4, Available: 2, Errors: 1, Filtered: 2, CorrelationID: ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			out, err := namecheap.NewCheckService(checker, tldtype.Default()).Execute(context.Background(), namecheap.ParamsIn{
+				Domains:           []string{"free.com", "taken.com", "broken.com", "free.net"},
+				TreatTakenAsError: false,
+				GroupBy:           "",
+				SLDRegex:          "",
+				CorrelationID:     "",
+				SortBy:            "",
+				AvailableOnly:     tt.availableOnly,
+				ReportDuplicates:  false,
+			})
+			if err != nil {
+				t.Fatalf("Execute() unexpected error: %v", err)
+			}
+
+			got := make([]string, 0, len(out.Results))
+			for _, result := range out.Results {
+				got = append(got, result.Domain)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("results = %v, want %v", got, tt.want)
+			}
+
+			if out.Summary != tt.wantSummary {
+				t.Errorf("Summary = %+v, want %+v", out.Summary, tt.wantSummary)
+			}
+		})
+	}
+}
+
+func TestCheckService_Execute_ReportDuplicates(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		reportDuplicates bool
+		want             []namecheap.Duplicate
+	}{
+		{name: "collapsed by default", reportDuplicates: false, want: nil},
+		{
+			name:             "reported when requested",
+			reportDuplicates: true,
+			want: []namecheap.Duplicate{
+				{Domain: "example.com", Inputs: []string{"Example.com", "example.com", "https://EXAMPLE.com/"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			checker := &namecheaptest.Checker{} //nolint:exhaustruct
+
+			out, err := namecheap.NewCheckService(checker, tldtype.Default()).Execute(context.Background(), namecheap.ParamsIn{
+				Domains:           []string{"Example.com", "example.org", "example.com", "https://EXAMPLE.com/"},
+				TreatTakenAsError: false,
+				GroupBy:           "",
+				SLDRegex:          "",
+				CorrelationID:     "",
+				SortBy:            "",
+				AvailableOnly:     false,
+				ReportDuplicates:  tt.reportDuplicates,
+			})
+			if err != nil {
+				t.Fatalf("Execute() unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(out.Duplicates, tt.want) {
+				t.Errorf("Duplicates = %+v, want %+v", out.Duplicates, tt.want)
+			}
+
+			if len(out.Results) != 4 {
+				t.Errorf("got %d results, want one per requested domain", len(out.Results))
+			}
+		})
+	}
+}
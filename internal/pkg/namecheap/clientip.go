@@ -0,0 +1,73 @@
+package namecheap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"strings"
+	"time"
+)
+
+const (
+	// ClientIPAuto is the ClientIP setting that discovers the public IP at startup.
+	ClientIPAuto = "auto"
+	// DefaultIPEchoURL answers with the caller's public IP address as plain text.
+	DefaultIPEchoURL = "https://api.ipify.org"
+
+	// discoveryTimeout bounds the IP-echo request.
+	discoveryTimeout = 10 * time.Second
+	// maxIPEchoBody is more than any IP address the echo service could answer with.
+	maxIPEchoBody = 256
+)
+
+// ErrClientIPDiscovery is returned by DiscoverClientIP when the public IP can't be determined.
+var ErrClientIPDiscovery = errors.New("failed to discover the public client IP")
+
+// DiscoverClientIP asks the IP-echo service at echoURL for the caller's public IP, the
+// address Namecheap sees and expects on its whitelist. An empty echoURL uses
+// DefaultIPEchoURL and a nil client http.DefaultClient.
+func DiscoverClientIP(ctx context.Context, client *http.Client, echoURL string) (string, error) {
+	if echoURL == "" {
+		echoURL = DefaultIPEchoURL
+	}
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, discoveryTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, echoURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrClientIPDiscovery, err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrClientIPDiscovery, err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: %s answered %d", ErrClientIPDiscovery, echoURL, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxIPEchoBody))
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrClientIPDiscovery, err)
+	}
+
+	addr, err := netip.ParseAddr(strings.TrimSpace(string(body)))
+	if err != nil {
+		return "", fmt.Errorf("%w: %s answered no IP address: %w", ErrClientIPDiscovery, echoURL, err)
+	}
+
+	return addr.String(), nil
+}
@@ -0,0 +1,74 @@
+package namecheap_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+)
+
+func TestDiscoverClientIP(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    string
+		wantErr error
+	}{
+		{name: "IPv4", status: http.StatusOK, body: "203.0.113.7", want: "203.0.113.7", wantErr: nil},
+		{name: "trailing newline", status: http.StatusOK, body: "203.0.113.7\n", want: "203.0.113.7", wantErr: nil},
+		{name: "IPv6", status: http.StatusOK, body: "2001:db8::7", want: "2001:db8::7", wantErr: nil},
+		{
+			name:    "not an IP",
+			status:  http.StatusOK,
+			body:    "<html>rate limited</html>",
+			want:    "",
+			wantErr: namecheap.ErrClientIPDiscovery,
+		},
+		{
+			name:    "error status",
+			status:  http.StatusServiceUnavailable,
+			body:    "203.0.113.7",
+			want:    "",
+			wantErr: namecheap.ErrClientIPDiscovery,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			got, err := namecheap.DiscoverClientIP(context.Background(), server.Client(), server.URL)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DiscoverClientIP() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("DiscoverClientIP() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiscoverClientIP_Unreachable(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	_, err := namecheap.DiscoverClientIP(context.Background(), nil, server.URL)
+	if !errors.Is(err, namecheap.ErrClientIPDiscovery) {
+		t.Errorf("DiscoverClientIP() error = %v, want %v", err, namecheap.ErrClientIPDiscovery)
+	}
+}
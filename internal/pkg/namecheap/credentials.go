@@ -0,0 +1,21 @@
+package namecheap
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// commandGetBalances is the cheapest authenticated API command, used to verify credentials.
+const commandGetBalances = "namecheap.users.getBalances"
+
+// VerifyCredentials makes a minimal authenticated call and returns the API error
+// when the credentials are rejected, e.g. after a key rotation or revocation.
+func (n *Service) VerifyCredentials(ctx context.Context) error {
+	_, err := n.call(ctx, commandGetBalances, url.Values{})
+	if err != nil {
+		return fmt.Errorf("failed to verify credentials: %w", err)
+	}
+
+	return nil
+}
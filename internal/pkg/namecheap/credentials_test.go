@@ -0,0 +1,58 @@
+package namecheap_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+)
+
+func TestVerifyCredentials(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		body    string
+		wantErr error
+	}{
+		{
+			name: "valid credentials",
+			body: `<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="OK" xmlns="http://api.namecheap.com/xml.response">
+  <CommandResponse Type="namecheap.users.getBalances">
+    <UserGetBalancesResult Currency="USD" AvailableBalance="4.00" />
+  </CommandResponse>
+</ApiResponse>`,
+			wantErr: nil,
+		},
+		{
+			name: "revoked key",
+			body: `<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="ERROR" xmlns="http://api.namecheap.com/xml.response">
+  <Errors><Error Number="1011102">API Key is invalid or API access has not been enabled</Error></Errors>
+</ApiResponse>`,
+			wantErr: namecheap.ErrAPIError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			service := newTestServiceWithHandler(t, func(w http.ResponseWriter, r *http.Request) {
+				if got := r.URL.Query().Get("Command"); got != "namecheap.users.getBalances" {
+					t.Errorf("Command = %q, want namecheap.users.getBalances", got)
+				}
+
+				_, _ = w.Write([]byte(tt.body))
+			})
+
+			err := service.VerifyCredentials(context.Background())
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("VerifyCredentials() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package namecheap
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// maxDomainLength is the maximum length of a domain name in octets (RFC 1035).
+	maxDomainLength = 253
+	// maxLabelLength is the maximum length of a single domain label in octets.
+	maxLabelLength = 63
+)
+
+var (
+	// ErrDomainTooLong is reported for domains longer than 253 octets.
+	ErrDomainTooLong = errors.New("domain exceeds 253 octets")
+	// ErrLabelTooLong is reported for domains with a label longer than 63 octets.
+	ErrLabelTooLong = errors.New("domain label exceeds 63 octets")
+)
+
+// validateDomain checks the length limits of a domain name. It runs before any
+// API call so abusive inputs are rejected locally with a per-domain error.
+func validateDomain(domain string) error {
+	name := strings.TrimSuffix(domain, ".")
+
+	if len(name) > maxDomainLength {
+		return fmt.Errorf("%w: got %d", ErrDomainTooLong, len(name))
+	}
+
+	for label := range strings.SplitSeq(name, ".") {
+		if len(label) > maxLabelLength {
+			return fmt.Errorf("%w: got %d", ErrLabelTooLong, len(label))
+		}
+	}
+
+	return nil
+}
+
+// NormalizeDomain extracts the lowercase host from pasted input such as
+// "http://user@Example.com:8080/path?x=1#top", dropping the scheme, userinfo, port,
+// path, query, fragment and trailing dot. Input that net/url rejects is cut apart by hand.
+// The host is put in Unicode NFC form, so a composed and a decomposed "café.com" are the
+// same domain.
+func NormalizeDomain(input string) string {
+	raw := strings.TrimSpace(input)
+	if !strings.Contains(raw, "://") {
+		// Parse bare hosts as scheme-relative URLs so ports and paths are still recognised.
+		raw = "//" + raw
+	}
+
+	host := ""
+
+	parsed, err := url.Parse(raw)
+	if err == nil {
+		host = parsed.Hostname()
+	} else {
+		host = splitHost(raw)
+	}
+
+	return norm.NFC.String(strings.TrimSuffix(strings.ToLower(host), "."))
+}
+
+// splitHost is the fallback of NormalizeDomain for input net/url can't parse.
+func splitHost(raw string) string {
+	if _, rest, ok := strings.Cut(raw, "//"); ok {
+		raw = rest
+	}
+
+	if i := strings.IndexAny(raw, "/?#"); i >= 0 {
+		raw = raw[:i]
+	}
+
+	if i := strings.LastIndex(raw, "@"); i >= 0 {
+		raw = raw[i+1:]
+	}
+
+	if i := strings.LastIndex(raw, ":"); i >= 0 {
+		raw = raw[:i]
+	}
+
+	return raw
+}
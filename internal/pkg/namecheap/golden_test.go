@@ -0,0 +1,114 @@
+package namecheap_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+)
+
+// update rewrites the golden files from the current parser output:
+//
+//	go test ./internal/pkg/namecheap -run TestDomainsCheck_Golden -update
+//
+//nolint:gochecknoglobals
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// fixtureDomain matches the Domain attributes of a fixture, which are the domains the
+// test asks to check.
+var fixtureDomain = regexp.MustCompile(`Domain="([^"]+)"`) //nolint:gochecknoglobals
+
+// TestDomainsCheck_Golden serves every testdata/parse/*.xml response and compares the
+// parsed results with the matching .golden.json file. To cover a new parsing case, add
+// a fixture and run the test with -update.
+func TestDomainsCheck_Golden(t *testing.T) {
+	t.Parallel()
+
+	fixtures, err := filepath.Glob(filepath.Join("testdata", "parse", "*.xml"))
+	if err != nil {
+		t.Fatalf("Glob() unexpected error: %v", err)
+	}
+
+	if len(fixtures) == 0 {
+		t.Fatal("no fixtures found in testdata/parse")
+	}
+
+	for _, fixture := range fixtures {
+		name := strings.TrimSuffix(filepath.Base(fixture), ".xml")
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			body := loadFixture(t, fixture)
+
+			service := newTestService(t, string(body), func(config *namecheap.Config) {
+				config.RegisterURLTemplate = "https://www.namecheap.com/domains/registration/results/?domain={domain}"
+				config.AvailableErrorNumbers = []string{"2011166"}
+			})
+
+			results, err := service.DomainsCheck(context.Background(), fixtureDomains(body))
+			if err != nil {
+				t.Fatalf("DomainsCheck() unexpected error: %v", err)
+			}
+
+			compareGolden(t, strings.TrimSuffix(fixture, ".xml")+".golden.json", results)
+		})
+	}
+}
+
+// loadFixture reads a fixture file, failing the test when it is missing.
+func loadFixture(t *testing.T, path string) []byte {
+	t.Helper()
+
+	body, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read fixture %s: %v", path, err)
+	}
+
+	return body
+}
+
+// fixtureDomains returns the domains a fixture has results for, in document order.
+func fixtureDomains(body []byte) []string {
+	var domains []string
+
+	for _, match := range fixtureDomain.FindAllSubmatch(body, -1) {
+		domains = append(domains, string(match[1]))
+	}
+
+	return domains
+}
+
+// compareGolden compares the JSON encoding of got with the golden file at path, or
+// rewrites the file when -update is set.
+func compareGolden(t *testing.T, path string, got any) {
+	t.Helper()
+
+	encoded, err := json.MarshalIndent(got, "", "  ")
+	if err != nil {
+		t.Fatalf("failed to encode results: %v", err)
+	}
+
+	encoded = append(encoded, '\n')
+
+	if *update {
+		err = os.WriteFile(path, encoded, 0o600)
+		if err != nil {
+			t.Fatalf("failed to update golden file %s: %v", path, err)
+		}
+
+		return
+	}
+
+	want := loadFixture(t, path)
+	if !bytes.Equal(encoded, want) {
+		t.Errorf("results differ from %s (rerun with -update to accept):\ngot:\n%s\nwant:\n%s", path, encoded, want)
+	}
+}
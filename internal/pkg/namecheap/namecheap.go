@@ -2,24 +2,83 @@
 package namecheap
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"encoding/xml"
 	"fmt"
+	"io"
+	"math"
+	"net"
 	"net/http"
+	"net/netip"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/concurrency"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/metrics"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/requestid"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/retry"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/tool"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/tracing"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"golang.org/x/time/rate"
 )
 
 const (
-	// maxDomainsPerCheck is the maximum number of domains allowed in a single API request.
-	maxDomainsPerCheck = 50
-	// httpTimeoutSeconds is the timeout for HTTP requests in seconds.
-	httpTimeoutSeconds = 30
+	// metricsProvider labels the metrics of this backend.
+	metricsProvider = "namecheap"
+	// DefaultUserAgent identifies API requests when Config.UserAgent is unset, instead of
+	// Go's default, which some APIs throttle.
+	DefaultUserAgent = "mcp-domain-checker"
+
+	// defaultMaxDomainsPerCheck is the maximum number of domains the API allows in a single
+	// request, the batch size when Config.MaxDomainsPerCheck is unset.
+	defaultMaxDomainsPerCheck = 50
+	// maxConcurrentBatches bounds the API requests in flight for one large check.
+	maxConcurrentBatches = 4
+	// defaultRequestTimeout is the per-request HTTP timeout when Config.RequestTimeout is unset.
+	defaultRequestTimeout = 30 * time.Second
+	// defaultBatchRetryDelay is the wait before a batch retry when Config.BatchRetryDelay is unset.
+	defaultBatchRetryDelay = time.Second
+	// retryBackoff is the delay before the first retry.
+	retryBackoff = 250 * time.Millisecond
+
+	// commandDomainsCheck is the API command that checks domain availability.
+	commandDomainsCheck = "namecheap.domains.check"
+
+	// statusOK and statusError are the documented values of the ApiResponse Status attribute.
+	statusOK    = "OK"
+	statusError = "ERROR"
+
+	// ProductionEndpoint is the Namecheap production API.
+	ProductionEndpoint = "https://api.namecheap.com/xml.response"
+	// SandboxEndpoint is the Namecheap sandbox API, selected by Config.Sandbox.
+	SandboxEndpoint = "https://api.sandbox.namecheap.com/xml.response"
+
+	// CurrencyUSD is the currency of every Namecheap price and fee.
+	CurrencyUSD = "USD"
+
+	// AvailabilityAvailable, AvailabilityTaken and AvailabilityUnknown are the values of Result.Availability.
+	AvailabilityAvailable = "available"
+	AvailabilityTaken     = "taken"
+	AvailabilityUnknown   = "unknown"
+
+	// StatusAvailable, StatusRegistered, StatusPremium, StatusReserved and StatusError are
+	// the values of Result.Status.
+	StatusAvailable  = "available"
+	StatusRegistered = "registered"
+	StatusPremium    = "premium"
+	StatusReserved   = "reserved"
+	StatusError      = "error"
 )
 
 var (
@@ -31,16 +90,49 @@ var (
 	ErrNamecheapAPIFailed = errors.New("Namecheap API call failed")
 	// ErrAPIError is returned when the API returns an error response.
 	ErrAPIError = errors.New("API error")
-	// ErrMaxDomainsExceeded is returned when more than 50 domains are requested.
-	ErrMaxDomainsExceeded = errors.New("max of 50 domains are allowed in a single check command")
+	// ErrNotXML is returned when a successful response isn't XML, e.g. a proxy's HTML page.
+	ErrNotXML = errors.New("response is not XML")
+	// ErrUnexpectedStatus is returned when the API response Status is neither OK nor ERROR.
+	ErrUnexpectedStatus = errors.New("unexpected API response status")
+	// ErrInvalidEndpoint is returned by NewService when the endpoint isn't an https URL.
+	ErrInvalidEndpoint = errors.New("invalid Namecheap endpoint")
+	// ErrInvalidClientIP is returned by NewService when ClientIP isn't an IPv4 or IPv6 address.
+	ErrInvalidClientIP = errors.New("invalid Namecheap client IP")
+	// ErrIPNotWhitelisted wraps ErrAPIError when the API rejects the request IP, which
+	// happens when ClientIP isn't on the account's API whitelist.
+	ErrIPNotWhitelisted = errors.New("client IP not whitelisted")
+	// ErrAuthFailed wraps ErrAPIError when the API rejects the API user or key.
+	ErrAuthFailed = errors.New("authentication failed")
 )
 
+// ipErrorNumbers are the API error numbers for a request IP the account doesn't accept.
+//
+//nolint:gochecknoglobals
+var ipErrorNumbers = map[string]bool{
+	"1011150": true, // Parameter RequestIP is invalid
+	"1017150": true, // Parameter RequestIP is disabled or locked
+	"1017105": true, // Parameter ClientIP is disabled or locked
+}
+
+// authErrorNumbers are the API error numbers for a missing, invalid or disabled API user or key.
+//
+//nolint:gochecknoglobals
+var authErrorNumbers = map[string]bool{
+	"1010101": true, // Parameter APIUser is missing
+	"1010102": true, // Parameter APIKey is missing
+	"1011101": true, // Parameter APIUser is invalid
+	"1011102": true, // Parameter APIKey is invalid
+	"1017101": true, // APIUser is disabled or not found
+}
+
 // DomainChecker defines the interface for domain availability checking services.
 // Implementations must provide methods to check domains and return service metadata.
 type DomainChecker interface {
 	// DomainsCheck checks domain availability for the given list of domains.
 	// Returns a slice of Result with availability information for each domain.
-	DomainsCheck(domains []string) ([]Result, error)
+	// A *PartialError comes with complete results when only part of the check failed;
+	// see IsPartial.
+	DomainsCheck(ctx context.Context, domains []string) ([]Result, error)
 	// Name returns the unique identifier name of the service.
 	Name() string
 	// Description returns a human-readable description of the service.
@@ -48,10 +140,14 @@ type DomainChecker interface {
 }
 
 // Service provides domain availability checking using the Namecheap API.
-// It implements the DomainChecker interface for integration with MCP tools.
+// It implements the DomainChecker interface and is exposed as an MCP tool through CheckService.
 type Service struct {
-	logger *zap.Logger
-	config Config
+	logger  *zap.Logger
+	config  Config
+	pricing *pricingCache
+	tldList *tldListCache
+	// limiter paces API calls; nil when RateLimitPerSecond is unset
+	limiter *rate.Limiter
 }
 
 // Config holds the configuration required to authenticate with the Namecheap API.
@@ -65,8 +161,54 @@ type Config struct {
 	UserName string
 	// ClientIP is the whitelisted IP address for API access
 	ClientIP string
-	// Endpoint is the Namecheap API endpoint URL (sandbox or production)
+	// Endpoint is the Namecheap API endpoint URL; empty uses SandboxEndpoint when Sandbox is
+	// set and ProductionEndpoint otherwise
 	Endpoint string
+	// Sandbox selects SandboxEndpoint when Endpoint is empty
+	Sandbox bool
+	// IncludeRaw attaches the raw DomainCheckResult attributes to each Result for debugging
+	IncludeRaw bool
+	// RegisterURLTemplate builds the registration link of available domains; "{domain}" is
+	// replaced with the query-escaped domain. Empty disables the links.
+	RegisterURLTemplate string
+	// MaxRetries is how many times a transient network failure is retried; zero disables retries
+	MaxRetries int
+	// RequestTimeout bounds each API request; zero uses a 30 second default
+	RequestTimeout time.Duration
+	// CheckTimeout bounds a whole DomainsCheck call, all batches included; zero means no limit
+	CheckTimeout time.Duration
+	// HTTPClient, when set, is used for every API call instead of a client with the default
+	// timeout and retries, e.g. to share a connection pool or stub responses in tests
+	HTTPClient *http.Client
+	// RateLimitPerSecond caps outbound API calls per second; zero or less means unlimited
+	RateLimitPerSecond float64
+	// RateLimitBurst is how many calls may go out at once before the rate applies; values
+	// below one are treated as one
+	RateLimitBurst int
+	// BatchRetry retries a whole DomainsCheck once when every batch failed, on top of the
+	// per-request retries
+	BatchRetry bool
+	// BatchRetryDelay is the wait before the batch retry; zero uses one second
+	BatchRetryDelay time.Duration
+	// NumberFormat is the separator format of the prices in API responses
+	NumberFormat NumberFormat
+	// StrictXML logs, at debug level, the response elements and attributes the decoder
+	// doesn't map, to notice changes in the API schema
+	StrictXML bool
+	// AvailableErrorNumbers are the DomainCheckResult ErrorNo values that mean the domain
+	// isn't registered; such results are reported available instead of errored
+	AvailableErrorNumbers []string
+	// MaxDomainsPerCheck is how many domains each API request checks; zero or less uses the
+	// API maximum of 50
+	MaxDomainsPerCheck int
+	// IncludeRawResponse attaches each raw XML response to the Meta of the tool call that
+	// caused it, see tool.RecordRawResponse
+	IncludeRawResponse bool
+	// UserAgent is the User-Agent header of API requests; empty uses DefaultUserAgent
+	UserAgent string
+	// StandardPricing fills Result.StandardRegistrationPrice of available non-premium domains
+	// from the cached price list, which costs one getPricing call per cache period
+	StandardPricing bool
 }
 
 // ParamsIn represents the input parameters for domain availability checking.
@@ -74,13 +216,71 @@ type Config struct {
 type ParamsIn struct {
 	// Domains is the list of domain names to check for availability
 	Domains []string `json:"domains" jsonschema:"The domains to check, e.g. example.com,example.org"`
+	// TreatTakenAsError marks registered domains as errors
+	TreatTakenAsError bool `json:"treatTakenAsError,omitempty" jsonschema:"Report taken domains as errors"`
+	// GroupBy nests the results by the given key; only "tldType" is supported
+	GroupBy string `json:"groupBy,omitempty" jsonschema:"Set to tldType to group results by gTLD, ccTLD and newgTLD"`
+	// SLDRegex, when set, checks only the domains whose second-level label matches it
+	SLDRegex string `json:"sldRegex,omitempty" jsonschema:"Only check domains whose SLD matches this regex"`
+	// CorrelationID, when set, is echoed on every result and the summary
+	CorrelationID string `json:"correlationId,omitempty" jsonschema:"Caller ID copied onto every result and the summary"`
+	// SortBy orders the results: input (the default), name, available or price
+	SortBy string `json:"sortBy,omitempty" jsonschema:"Order: input (default), name, available first or price (cheapest)"`
+	// AvailableOnly drops taken and errored domains from the results, keeping them in the summary
+	AvailableOnly bool `json:"availableOnly,omitempty" jsonschema:"Return only the available domains"`
+	// ReportDuplicates lists the domains requested more than once, e.g. as Example.com and
+	// example.com, with the forms they were given in
+	ReportDuplicates bool `json:"reportDuplicates,omitempty" jsonschema:"List domains requested more than once"`
+}
+
+// DomainCount returns the number of domains to check, for logging.
+func (p ParamsIn) DomainCount() int {
+	return len(p.Domains)
 }
 
 // ParamsOut represents the output of domain availability checking.
 // It contains the results for all domains that were checked.
 type ParamsOut struct {
 	// Results contains the availability information for each checked domain
-	Results []Result `json:"results" jsonschema:"The results of the domain checks"`
+	Results []Result `json:"results,omitempty" jsonschema:"The results of the domain checks"`
+	// Groups contains the results nested by TLD type when groupBy is tldType
+	Groups []ResultGroup `json:"groups,omitempty" jsonschema:"The results grouped by TLD type"`
+	// Summary aggregates the results
+	Summary Summary `json:"summary" jsonschema:"Counts of checked, available and errored domains"`
+	// Errors lists the domains a partially failed check couldn't answer, grouped by error
+	Errors []CheckError `json:"errors,omitempty" jsonschema:"Domains that couldn't be checked, grouped by error"`
+	// Duplicates lists the domains requested more than once when reportDuplicates is set
+	Duplicates []Duplicate `json:"duplicates,omitempty" jsonschema:"Domains requested more than once"`
+}
+
+// Duplicate is a domain several requested strings normalized to, checked once.
+type Duplicate struct {
+	// Domain is the normalized domain that was checked
+	Domain string `json:"domain" jsonschema:"The normalized domain that was checked"`
+	// Inputs are the requested strings the domain was normalized from, in request order
+	Inputs []string `json:"inputs" jsonschema:"The domain as given in the request, each time it was given"`
+}
+
+// ResultGroup holds the results sharing a TLD type.
+type ResultGroup struct {
+	// TLDType is the TLD family of the group: gTLD, ccTLD or newgTLD
+	TLDType string `json:"tldType" jsonschema:"The TLD family: gTLD, ccTLD or newgTLD"`
+	// Results are the group's results in input order
+	Results []Result `json:"results" jsonschema:"The results in this group"`
+}
+
+// Summary aggregates the outcome of a domain check.
+type Summary struct {
+	// Checked is the number of domains checked, including any left out by AvailableOnly
+	Checked int `json:"checked" jsonschema:"Number of domains checked"`
+	// Available is the number of domains available for registration
+	Available int `json:"available" jsonschema:"Number of available domains"`
+	// Errors is the number of results carrying an error
+	Errors int `json:"errors" jsonschema:"Number of domains whose result carries an error"`
+	// Filtered is the number of taken or errored results AvailableOnly left out
+	Filtered int `json:"filtered,omitempty" jsonschema:"Number of results left out by availableOnly"`
+	// CorrelationID is the caller-supplied ID of the check, if any
+	CorrelationID string `json:"correlationId,omitempty" jsonschema:"The correlationId the check was made with"`
 }
 
 // Result contains the availability and pricing information for a single domain.
@@ -90,6 +290,12 @@ type Result struct {
 	Domain string `json:"domain" jsonschema:"The domain that was checked"`
 	// Available indicates if the domain is available for registration
 	Available bool `json:"available" jsonschema:"Indicates if the domain is available for registration"`
+	// Availability is the tri-state answer: available, taken, or unknown when an error prevented one
+	Availability string `json:"availability" jsonschema:"available, taken, or unknown when the check failed"`
+	// Status tells why a domain is or isn't available: available, registered, premium
+	// (registrable at the premium price), reserved (a premium name not offered for
+	// registration) or error
+	Status string `json:"status,omitempty" jsonschema:"One of available, registered, premium, reserved or error"`
 	// IsPremiumName indicates whether the domain is classified as premium
 	IsPremiumName bool `json:"isPremiumName" jsonschema:"Indicates whether the domain name is premium"`
 	// PremiumRegistrationPrice is the registration cost for premium domains
@@ -100,8 +306,54 @@ type Result struct {
 	IcannFee float64 `json:"icannFee,omitempty" jsonschema:"Fee charged by ICANN"`
 	// EapFee is the Early Access Program fee for premium domains
 	EapFee float64 `json:"eapFee,omitempty" jsonschema:"EAP fee"`
+	// StandardRegistrationPrice is the one-year price of the TLD from the price list, for
+	// available non-premium domains
+	StandardRegistrationPrice float64 `json:"standardRegistrationPrice,omitempty" jsonschema:"Standard TLD price"`
+	// Currency is the ISO 4217 code of the prices and fees, e.g. USD; empty when none were quoted
+	Currency string `json:"currency,omitempty" jsonschema:"ISO 4217 currency of the prices and fees, e.g. USD"`
 	// Error contains any error message if the domain check failed
 	Error string `json:"error,omitempty" jsonschema:"Error message if domain check failed"`
+	// ErrorCode is the backend's numeric error for the domain, e.g. Namecheap's ErrorNo; 0 when none
+	ErrorCode int `json:"errorCode,omitempty" jsonschema:"Numeric backend error code, 0 or absent when none"`
+	// RegisterURL links to the registrar's registration page for available domains
+	RegisterURL string `json:"registerURL,omitempty" jsonschema:"Registration link, set for available domains only"`
+	// Blocked is true when the domain's name is on the abuse or trademark blocklist; advisory only
+	Blocked bool `json:"blocked,omitempty" jsonschema:"True if the name is on an abuse or trademark blocklist"`
+	// BlockReason explains why the name is blocklisted
+	BlockReason string `json:"blockReason,omitempty" jsonschema:"Why the name is blocklisted"`
+	// CorrelationID is the caller-supplied ID of the check, for joining results across systems
+	CorrelationID string `json:"correlationId,omitempty" jsonschema:"The correlationId the check was made with"`
+	// Input is the caller-supplied string the checked Domain was normalized from
+	Input string `json:"input,omitempty" jsonschema:"The domain as given in the request, before normalization"`
+	// ReferencePrice is the configured reference registration price of the domain's TLD,
+	// set on priced results only
+	ReferencePrice float64 `json:"referencePrice,omitempty" jsonschema:"Reference registration price of the TLD"`
+	// ReferencePriceDelta is the registration price minus ReferencePrice; negative means cheaper
+	ReferencePriceDelta float64 `json:"referencePriceDelta,omitempty" jsonschema:"Price minus the reference price"`
+	// Raw holds the backend attributes the result was parsed from, when debugging is enabled
+	Raw map[string]string `json:"raw,omitempty" jsonschema:"Raw backend attributes for debugging"`
+
+	// omitAvailable drops Available from the JSON encoding, leaving only Availability;
+	// set by OmitAvailableChecker
+	omitAvailable bool
+}
+
+// RegistrationPrice returns the price of registering the domain: the premium price when
+// one is quoted, else the standard TLD price, or zero when neither is known.
+func (r Result) RegistrationPrice() float64 {
+	if r.PremiumRegistrationPrice > 0 {
+		return r.PremiumRegistrationPrice
+	}
+
+	return r.StandardRegistrationPrice
+}
+
+// centsPerUnit scales prices to cents for rounding.
+const centsPerUnit = 100
+
+// RoundCents rounds amount to two decimals, hiding float noise such as 3.2000000000000006.
+func RoundCents(amount float64) float64 {
+	return math.Round(amount*centsPerUnit) / centsPerUnit
 }
 
 // APIResponse represents the XML response structure from the Namecheap API.
@@ -125,8 +377,10 @@ type Error struct {
 
 // CommandResponse represents the command response section of the API response.
 type CommandResponse struct {
-	Type               string              `xml:"Type,attr"`
-	DomainCheckResults []DomainCheckResult `xml:"DomainCheckResult"`
+	Type                 string               `xml:"Type,attr"`
+	DomainCheckResults   []DomainCheckResult  `xml:"DomainCheckResult"`
+	UserGetPricingResult UserGetPricingResult `xml:"UserGetPricingResult"`
+	TLDListResult        TLDListResult        `xml:"Tlds"`
 }
 
 // DomainCheckResult represents individual domain check results from the API response.
@@ -140,21 +394,106 @@ type DomainCheckResult struct {
 	EapFee                   string `xml:"EapFee,attr"`
 	ErrorNo                  string `xml:"ErrorNo,attr"`
 	Description              string `xml:"Description,attr"`
+	// Attributes holds every attribute of the element, including ones not mapped above.
+	Attributes map[string]string `xml:"-"`
+}
+
+// UnmarshalXML decodes the result and keeps a copy of all of its attributes in Attributes.
+func (d *DomainCheckResult) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) error {
+	type plain DomainCheckResult
+
+	var decoded plain
+
+	err := decoder.DecodeElement(&decoded, &start)
+	if err != nil {
+		return err
+	}
+
+	*d = DomainCheckResult(decoded)
+	d.Attributes = make(map[string]string, len(start.Attr))
+
+	for _, attr := range start.Attr {
+		d.Attributes[attr.Name.Local] = attr.Value
+	}
+
+	return nil
 }
 
 // NewService creates a new Namecheap service with the provided logger and configuration.
-// It validates that all required API credentials are present and returns an error if any are missing.
+// It validates that all required API credentials are present and returns an error if any are missing,
+// and that the endpoint is a well-formed https URL.
 func NewService(logger *zap.Logger, config Config) (*Service, error) {
 	if config.APIUser == "" || config.APIKey == "" || config.UserName == "" || config.ClientIP == "" {
 		return nil, ErrMissingAPICredentials
 	}
 
+	// Namecheap answers a malformed ClientIp only with a confusing IP mismatch error.
+	_, err := netip.ParseAddr(config.ClientIP)
+	if err != nil {
+		return nil, fmt.Errorf("%w %q: %w", ErrInvalidClientIP, config.ClientIP, err)
+	}
+
+	if config.Endpoint == "" {
+		config.Endpoint = ProductionEndpoint
+		if config.Sandbox {
+			config.Endpoint = SandboxEndpoint
+		}
+	}
+
+	err = validateEndpoint(config.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var limiter *rate.Limiter
+	if config.RateLimitPerSecond > 0 {
+		limiter = rate.NewLimiter(rate.Limit(config.RateLimitPerSecond), max(config.RateLimitBurst, 1))
+	}
+
 	return &Service{
-		logger: logger,
-		config: config,
+		logger:  logger,
+		config:  config,
+		pricing: &pricingCache{mu: sync.Mutex{}, prices: nil, fetchedAt: time.Time{}},
+		tldList: &tldListCache{mu: sync.Mutex{}, tlds: nil, fetchedAt: time.Time{}},
+		limiter: limiter,
 	}, nil
 }
 
+// validateEndpoint checks that endpoint is an absolute https URL with a host. Plain http
+// is accepted for loopback hosts only, so a local mock of the API can be used.
+func validateEndpoint(endpoint string) error {
+	parsed, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrInvalidEndpoint, endpoint, err)
+	}
+
+	if parsed.Host == "" {
+		return fmt.Errorf("%w %q: missing host", ErrInvalidEndpoint, endpoint)
+	}
+
+	switch parsed.Scheme {
+	case "https":
+		return nil
+	case "http":
+		if isLoopback(parsed.Hostname()) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w %q: must be an https URL", ErrInvalidEndpoint, endpoint)
+}
+
+// isLoopback reports whether host is localhost or a loopback IP address.
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+
+	return ip != nil && ip.IsLoopback()
+}
+
 // Description returns a description of the Namecheap service.
 func (n *Service) Description() string {
 	return "Check domain availability using Namecheap API"
@@ -165,61 +504,343 @@ func (n *Service) Name() string {
 	return "check_availability_namecheap"
 }
 
-// Execute performs domain availability checking with the given input parameters.
-// It implements the generic Service interface for MCP tool integration.
-func (n *Service) Execute(in ParamsIn) (ParamsOut, error) {
-	results, err := n.DomainsCheck(in.Domains)
-	if err != nil {
-		return ParamsOut{}, fmt.Errorf("%w: %w", ErrNamecheapAPIFailed, err)
+// DomainsCheck checks domain availability for the given list of domains using the Namecheap API
+// and returns detailed availability information including premium domain pricing and associated
+// fees. Lists longer than Config.MaxDomainsPerCheck are split into batches, see checkBatches. Returns
+// ErrMissingDomains if no domains are provided. Domains that fail validation are not sent to
+// the API; they are returned in place with the validation error in Result.Error. Duplicates,
+// compared after normalization, are checked once and the result repeated for each. When some
+// batches fail, the results come with a *PartialError naming the failed domains. With
+// Config.StandardPricing set, available non-premium results also get their TLD's standard
+// registration price, see addStandardPricing.
+func (n *Service) DomainsCheck(ctx context.Context, domains []string) ([]Result, error) {
+	if len(domains) == 0 {
+		return nil, ErrMissingDomains
 	}
 
-	return ParamsOut{Results: results}, nil
+	metrics.DomainsCheck(metricsProvider, len(domains))
+
+	results, err := n.domainsCheck(ctx, domains)
+	if n.config.StandardPricing && (err == nil || IsPartial(err)) {
+		n.addStandardPricing(ctx, results)
+	}
+
+	return results, err
 }
 
-// DomainsCheck checks domain availability for the given list of domains using the Namecheap API.
-// It accepts up to 50 domains in a single request and returns detailed availability information
-// including premium domain pricing and associated fees. Returns ErrMissingDomains if no domains
-// are provided, or an error if more than 50 domains are requested.
-func (n *Service) DomainsCheck(domains []string) ([]Result, error) {
-	if len(domains) == 0 {
-		return nil, ErrMissingDomains
+// domainsCheck validates and deduplicates domains and checks the valid ones, see DomainsCheck.
+func (n *Service) domainsCheck(ctx context.Context, domains []string) ([]Result, error) {
+	if n.config.CheckTimeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, n.config.CheckTimeout)
+		defer cancel()
+	}
+
+	invalid := make(map[int]string)
+	valid := make([]string, 0, len(domains))
+	// seen holds the normalized valid domains, so duplicates are sent only once.
+	seen := make(map[string]bool, len(domains))
+
+	for i, domain := range domains {
+		err := validateDomain(domain)
+		if err != nil {
+			invalid[i] = err.Error()
+
+			continue
+		}
+
+		key := NormalizeDomain(domain)
+		if !seen[key] {
+			seen[key] = true
+
+			valid = append(valid, domain)
+		}
 	}
 
-	if len(domains) > maxDomainsPerCheck {
-		return nil, ErrMaxDomainsExceeded
+	if len(valid) == len(domains) {
+		return n.checkWithBatchRetry(ctx, domains)
 	}
 
-	return n.checkDomains(domains)
+	checked := make(map[string]Result, len(valid))
+
+	// partialErr is kept when only some batches failed, see checkBatches.
+	var partialErr error
+
+	if len(valid) > 0 {
+		results, err := n.checkWithBatchRetry(ctx, valid)
+		if err != nil && !IsPartial(err) {
+			return nil, err
+		}
+
+		partialErr = err
+
+		for _, result := range results {
+			checked[NormalizeDomain(result.Domain)] = result
+		}
+	}
+
+	results := make([]Result, 0, len(domains))
+
+	for i, domain := range domains {
+		if message, ok := invalid[i]; ok {
+			results = append(results, errorResult(domain, message))
+
+			continue
+		}
+
+		if result, ok := checked[NormalizeDomain(domain)]; ok {
+			results = append(results, result)
+		}
+	}
+
+	return results, partialErr
+}
+
+// apiFailed wraps err in ErrNamecheapAPIFailed unless it already is one, e.g. an HTTP
+// failure reported by roundTrip, so the message doesn't repeat the sentinel.
+func apiFailed(err error) error {
+	if errors.Is(err, ErrNamecheapAPIFailed) {
+		return err
+	}
+
+	return fmt.Errorf("%w: %w", ErrNamecheapAPIFailed, err)
+}
+
+// responseError turns the Errors of an ERROR response into an ErrAPIError quoting the first
+// error. Known IP whitelist and authentication errors also wrap ErrIPNotWhitelisted or
+// ErrAuthFailed, with a hint on how to fix them.
+func (n *Service) responseError(errs Errors) error {
+	if len(errs.Error) == 0 {
+		return fmt.Errorf("%w: unknown error", ErrAPIError)
+	}
+
+	apiErr := errs.Error[0]
+
+	switch {
+	case ipErrorNumbers[apiErr.Number]:
+		return fmt.Errorf("%w: %w: %s (add %s to your Namecheap API whitelist under Profile > Tools > API Access)",
+			ErrIPNotWhitelisted, ErrAPIError, apiErr.Message, n.config.ClientIP)
+	case authErrorNumbers[apiErr.Number]:
+		return fmt.Errorf("%w: %w: %s (check the API user and key, and that API access is enabled for the account)",
+			ErrAuthFailed, ErrAPIError, apiErr.Message)
+	default:
+		return fmt.Errorf("%w: %s", ErrAPIError, apiErr.Message)
+	}
 }
 
-func (n *Service) checkDomains(domains []string) ([]Result, error) {
+// checkWithBatchRetry runs checkBatches and, when BatchRetry is set and every batch
+// failed, runs it once more after BatchRetryDelay. Cancellation and partial failures are
+// never retried.
+func (n *Service) checkWithBatchRetry(ctx context.Context, domains []string) ([]Result, error) {
+	results, err := n.checkBatches(ctx, domains)
+	if err == nil || IsPartial(err) || !n.config.BatchRetry || ctx.Err() != nil {
+		return results, err
+	}
+
+	delay := n.config.BatchRetryDelay
+	if delay <= 0 {
+		delay = defaultBatchRetryDelay
+	}
+
+	n.logger.Warn("Namecheap check failed, retrying the whole batch",
+		zap.Duration("delay", delay),
+		zap.Error(err),
+	)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-timer.C:
+	}
+
+	return n.checkBatches(ctx, domains)
+}
+
+// checkBatches checks domains in batches of maxDomainsPerCheck(), at most maxConcurrentBatches
+// at a time, and returns the results in input order. The domains of a failed batch are
+// returned with the batch error in Result.Error so other batches' results survive, along
+// with a *PartialError listing them; only when every batch failed are no results returned.
+func (n *Service) checkBatches(ctx context.Context, domains []string) ([]Result, error) {
+	batches := slices.Collect(slices.Chunk(domains, n.maxDomainsPerCheck()))
+	batchResults, err := concurrency.MapBounded(ctx, batches, maxConcurrentBatches, n.checkDomains)
+
+	// batchErrs stays nil when every batch succeeded.
+	var batchErrs concurrency.Errors
+
+	errors.As(err, &batchErrs)
+
+	results := make([]Result, 0, len(domains))
+	failed := 0
+
+	var failedDomains []string
+
+	for i, batch := range batches {
+		batchErr := batchErrs.Err(i)
+		if batchErr == nil {
+			results = append(results, batchResults[i]...)
+
+			continue
+		}
+
+		failed++
+
+		failedDomains = append(failedDomains, batch...)
+
+		n.logger.Warn("Namecheap batch check failed",
+			zap.Int("domain_count", len(batch)),
+			zap.Error(batchErr),
+		)
+
+		for _, domain := range batch {
+			results = append(results, errorResult(domain, batchErr.Error()))
+		}
+	}
+
+	switch failed {
+	case 0:
+		return results, nil
+	case len(batches):
+		return nil, batchErrs[0]
+	default:
+		return results, NewPartialError(batchErrs.Unwrap()[0], failedDomains)
+	}
+}
+
+// errorResult is the result of a domain that couldn't be checked.
+func errorResult(domain, message string) Result {
+	return Result{ //nolint:exhaustruct
+		Domain:       domain,
+		Availability: AvailabilityUnknown,
+		Status:       StatusError,
+		Error:        message,
+	}
+}
+
+func (n *Service) checkDomains(ctx context.Context, domains []string) ([]Result, error) {
 	n.logger.Debug("Checking domains with Namecheap API",
 		zap.Strings("domains", domains),
+		zap.Int("domain_count", len(domains)),
 	)
 
-	domainList := strings.Join(domains, ",")
+	params := url.Values{}
+	params.Add("DomainList", strings.Join(domains, ","))
 
-	reqURL, err := n.buildRequestURL(n.config.Endpoint, domainList)
+	apiResp, err := n.call(ctx, commandDomainsCheck, params)
+	if err != nil {
+		return nil, err
+	}
+
+	results := n.parseResults(apiResp.CommandResponse.DomainCheckResults)
+
+	n.logger.Debug("Domain check completed",
+		zap.Int("domains_checked", len(results)),
+		zap.Any("results", results),
+	)
+
+	return results, nil
+}
+
+// waitForRateLimit blocks until the rate limiter lets the next API call through. A wait
+// cut short by ctx returns the context error, so callers see a cancellation rather than
+// an API failure.
+func (n *Service) waitForRateLimit(ctx context.Context) error {
+	if n.limiter == nil {
+		return nil
+	}
+
+	err := n.limiter.Wait(ctx)
+	if err == nil {
+		return nil
+	}
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	// Wait gives up early when the deadline would pass before a call is allowed.
+	return fmt.Errorf("%w: %w", context.DeadlineExceeded, err)
+}
+
+// call executes command with the given parameters against the Namecheap API and
+// returns the decoded response. Responses whose Status isn't OK are returned as errors.
+// Cancelling ctx aborts the HTTP request. Each request is traced in its own span.
+func (n *Service) call(ctx context.Context, command string, params url.Values) (*APIResponse, error) {
+	err := n.waitForRateLimit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	attributes := []attribute.KeyValue{
+		attribute.String(tracing.AttrProvider, metricsProvider),
+		attribute.String("namecheap.command", command),
+	}
+	if domains := params.Get("DomainList"); domains != "" {
+		attributes = append(attributes, attribute.Int(tracing.AttrDomainCount, strings.Count(domains, ",")+1))
+	}
+
+	ctx, span := tracing.Tracer().Start(ctx, command,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attributes...),
+	)
+	defer span.End()
+
+	apiResp, err := n.roundTrip(ctx, command, params)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return apiResp, err
+}
+
+// roundTrip sends one API request for call and decodes its response.
+func (n *Service) roundTrip(ctx context.Context, command string, params url.Values) (*APIResponse, error) {
+	reqURL, err := n.buildRequestURL(n.config.Endpoint, command, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build request URL: %w", err)
 	}
 
 	n.logger.Debug("Making Namecheap API call",
-		zap.String("url", reqURL),
-		zap.Int("domain_count", len(domains)),
+		urlField(reqURL),
+		zap.String("command", command),
+		requestid.Field(ctx),
 	)
 
-	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, reqURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	client := &http.Client{ //nolint:exhaustruct
-		Timeout: time.Second * httpTimeoutSeconds,
+	userAgent := n.config.UserAgent
+	if userAgent == "" {
+		userAgent = DefaultUserAgent
 	}
 
+	req.Header.Set("User-Agent", userAgent)
+
+	client := n.config.HTTPClient
+	if client == nil {
+		client = &http.Client{ //nolint:exhaustruct
+			Timeout:   n.requestTimeout(),
+			Transport: retry.NewTransport(http.DefaultTransport, n.config.MaxRetries, retryBackoff),
+		}
+	}
+
+	start := time.Now()
 	resp, err := client.Do(req)
+	metrics.UpstreamRequest(metricsProvider, time.Since(start), resp, err)
+
 	if err != nil {
+		// The error quotes the request URL, credentials included.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			urlErr.URL = redactURL(urlErr.URL)
+		}
+
 		return nil, fmt.Errorf("HTTP request failed: %w", err)
 	}
 
@@ -227,35 +848,92 @@ func (n *Service) checkDomains(domains []string) ([]Result, error) {
 		_ = resp.Body.Close()
 	}()
 
+	reader := bufio.NewReaderSize(resp.Body, sniffLength)
+	// A short body is returned with an error; it is still all there is to sniff.
+	head, _ := reader.Peek(sniffLength)
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("%w: HTTP %d: %s", ErrNamecheapAPIFailed, resp.StatusCode, bodySnippet(head))
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if !looksLikeXML(contentType, head) {
+		return nil, fmt.Errorf("%w: %w (Content-Type %q): %s",
+			ErrNamecheapAPIFailed, ErrNotXML, contentType, bodySnippet(head))
+	}
+
+	var (
+		body io.Reader = reader
+		raw  []byte
+	)
+
+	// At debug level, and with IncludeRawResponse, the response is needed twice: once to
+	// decode and once to log, scan in strict mode or attach to the tool result.
+	if n.logsBodies() || n.config.IncludeRawResponse {
+		raw, err = io.ReadAll(reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read XML response: %w", err)
+		}
+
+		body = bytes.NewReader(raw)
+
+		n.logger.Debug("Namecheap API response",
+			zap.String("command", command),
+			zap.Int("status", resp.StatusCode),
+			zap.ByteString("body", raw),
+			requestid.Field(ctx),
+		)
+	}
+
+	if n.config.IncludeRawResponse {
+		tool.RecordRawResponse(ctx, metricsProvider, command, raw)
+	}
+
 	var apiResp APIResponse
 
-	decoder := xml.NewDecoder(resp.Body)
+	decoder := xml.NewDecoder(body)
 
 	err = decoder.Decode(&apiResp)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode XML response: %w", err)
 	}
 
-	if apiResp.Status != "OK" {
-		errorMsg := "unknown error"
-		if len(apiResp.Errors.Error) > 0 {
-			errorMsg = apiResp.Errors.Error[0].Message
-		}
+	if n.reportsUnknownXML() {
+		n.reportUnknownXML(raw)
+	}
 
-		return nil, fmt.Errorf("%w: %s", ErrAPIError, errorMsg)
+	switch apiResp.Status {
+	case statusOK:
+	case statusError:
+		return nil, n.responseError(apiResp.Errors)
+	default:
+		// Anything else usually means the endpoint isn't the Namecheap API
+		// (or is in maintenance), so surface the raw value for diagnosis.
+		return nil, fmt.Errorf("%w: %q", ErrUnexpectedStatus, apiResp.Status)
 	}
 
-	results := n.parseResults(apiResp.CommandResponse.DomainCheckResults)
+	return &apiResp, nil
+}
 
-	n.logger.Debug("Domain check completed",
-		zap.Int("domains_checked", len(results)),
-		zap.Any("results", results),
-	)
+// maxDomainsPerCheck returns the configured batch size or the API maximum.
+func (n *Service) maxDomainsPerCheck() int {
+	if n.config.MaxDomainsPerCheck > 0 {
+		return n.config.MaxDomainsPerCheck
+	}
 
-	return results, nil
+	return defaultMaxDomainsPerCheck
+}
+
+// requestTimeout returns the configured per-request timeout or the default.
+func (n *Service) requestTimeout() time.Duration {
+	if n.config.RequestTimeout > 0 {
+		return n.config.RequestTimeout
+	}
+
+	return defaultRequestTimeout
 }
 
-func (n *Service) buildRequestURL(baseURL, domainList string) (string, error) {
+func (n *Service) buildRequestURL(baseURL, command string, extra url.Values) (string, error) {
 	baseURLParsed, err := url.Parse(baseURL)
 	if err != nil {
 		return "", err
@@ -266,8 +944,13 @@ func (n *Service) buildRequestURL(baseURL, domainList string) (string, error) {
 	params.Add("ApiKey", n.config.APIKey)
 	params.Add("UserName", n.config.UserName)
 	params.Add("ClientIp", n.config.ClientIP)
-	params.Add("Command", "namecheap.domains.check")
-	params.Add("DomainList", domainList)
+	params.Add("Command", command)
+
+	for key, values := range extra {
+		for _, value := range values {
+			params.Add(key, value)
+		}
+	}
 
 	baseURLParsed.RawQuery = params.Encode()
 
@@ -279,40 +962,79 @@ func (n *Service) parseResults(domainResults []DomainCheckResult) []Result {
 
 	for _, domainResult := range domainResults {
 		result := Result{
-			Domain:                   domainResult.Domain,
-			Available:                domainResult.Available == "true",
-			IsPremiumName:            domainResult.IsPremiumName == "true",
-			PremiumRegistrationPrice: 0,
-			PremiumRenewalPrice:      0,
-			IcannFee:                 0,
-			EapFee:                   0,
-			Error:                    "",
+			Domain:                    domainResult.Domain,
+			Available:                 domainResult.Available == "true",
+			Availability:              "",
+			Status:                    "",
+			IsPremiumName:             domainResult.IsPremiumName == "true",
+			PremiumRegistrationPrice:  0,
+			PremiumRenewalPrice:       0,
+			IcannFee:                  0,
+			EapFee:                    0,
+			StandardRegistrationPrice: 0,
+			Currency:                  "",
+			Error:                     "",
+			ErrorCode:                 0,
+			RegisterURL:               "",
+			Blocked:                   false,
+			BlockReason:               "",
+			CorrelationID:             "",
+			Input:                     "",
+			ReferencePrice:            0,
+			ReferencePriceDelta:       0,
+			Raw:                       nil,
+			omitAvailable:             false,
+		}
+
+		if n.config.IncludeRaw {
+			result.Raw = domainResult.Attributes
 		}
 
-		if domainResult.ErrorNo != "0" && domainResult.Description != "" {
+		// An absent or unparsable ErrorNo leaves the code at 0.
+		result.ErrorCode, _ = strconv.Atoi(strings.TrimSpace(domainResult.ErrorNo))
+
+		switch {
+		case domainResult.ErrorNo != "0" && slices.Contains(n.config.AvailableErrorNumbers, domainResult.ErrorNo):
+			// Some registries answer an unregistered domain with an error number.
+			result.Available = true
+		case domainResult.ErrorNo != "0" && domainResult.Description != "":
 			result.Error = domainResult.Description
 		}
 
+		result.Availability = availabilityOf(result)
+		result.Status = StatusOf(result)
+
 		if result.IsPremiumName {
-			price, regErr := ParseFloat(domainResult.PremiumRegistrationPrice)
+			price, regErr := n.config.NumberFormat.Parse(domainResult.PremiumRegistrationPrice)
 			if regErr == nil {
 				result.PremiumRegistrationPrice = price
 			}
 
-			price, renErr := ParseFloat(domainResult.PremiumRenewalPrice)
+			price, renErr := n.config.NumberFormat.Parse(domainResult.PremiumRenewalPrice)
 			if renErr == nil {
 				result.PremiumRenewalPrice = price
 			}
 		}
 
-		fee, icannErr := ParseFloat(domainResult.IcannFee)
-		if icannErr == nil {
-			result.IcannFee = fee
+		// Fees and the registration link only apply to registrable domains; Namecheap
+		// sometimes reports fees for taken ones too, so those stay zeroed.
+		if result.Available {
+			result.RegisterURL = registerURL(n.config.RegisterURLTemplate, result.Domain)
+
+			fee, icannErr := n.config.NumberFormat.Parse(domainResult.IcannFee)
+			if icannErr == nil {
+				result.IcannFee = fee
+			}
+
+			fee, eapErr := n.config.NumberFormat.Parse(domainResult.EapFee)
+			if eapErr == nil {
+				result.EapFee = fee
+			}
 		}
 
-		fee, eapErr := ParseFloat(domainResult.EapFee)
-		if eapErr == nil {
-			result.EapFee = fee
+		if result.PremiumRegistrationPrice > 0 || result.PremiumRenewalPrice > 0 ||
+			result.IcannFee > 0 || result.EapFee > 0 {
+			result.Currency = CurrencyUSD
 		}
 
 		results = append(results, result)
@@ -321,11 +1043,75 @@ func (n *Service) parseResults(domainResults []DomainCheckResult) []Result {
 	return results
 }
 
+// availabilityOf derives the tri-state availability of a parsed result. An error
+// means the backend gave no definitive answer, whatever the Available flag says.
+func availabilityOf(result Result) string {
+	switch {
+	case result.Error != "":
+		return AvailabilityUnknown
+	case result.Available:
+		return AvailabilityAvailable
+	default:
+		return AvailabilityTaken
+	}
+}
+
+// StatusOf derives the Status of a result from its error, Available and IsPremiumName.
+// A premium name that isn't available is held back by the registry.
+func StatusOf(result Result) string {
+	switch {
+	case result.Error != "":
+		return StatusError
+	case result.Available && result.IsPremiumName:
+		return StatusPremium
+	case result.Available:
+		return StatusAvailable
+	case result.IsPremiumName:
+		return StatusReserved
+	default:
+		return StatusRegistered
+	}
+}
+
+// registerURL fills the "{domain}" placeholder of template with the escaped domain.
+func registerURL(template, domain string) string {
+	if template == "" {
+		return ""
+	}
+
+	return strings.ReplaceAll(template, "{domain}", url.QueryEscape(strings.ToLower(domain)))
+}
+
 // ParseFloat is a helper function to parse float values from string, exported for testing.
+// It expects "." decimals and no thousands separators; see NumberFormat for other locales.
 func ParseFloat(s string) (float64, error) {
+	return NumberFormat{Decimal: "", Thousands: ""}.Parse(s)
+}
+
+// NumberFormat describes how a backend formats prices, so locale-formatted values such
+// as "1.000,00" parse instead of silently becoming zero. The zero value is the plain
+// "1000.00" format.
+type NumberFormat struct {
+	// Decimal is the decimal separator; empty means "."
+	Decimal string
+	// Thousands is the digit grouping separator removed before parsing; empty means none
+	Thousands string
+}
+
+// Parse converts s to a float using the format's separators. An empty string is zero.
+func (f NumberFormat) Parse(s string) (float64, error) {
+	s = strings.TrimSpace(s)
 	if s == "" {
 		return 0, nil
 	}
 
+	if f.Thousands != "" {
+		s = strings.ReplaceAll(s, f.Thousands, "")
+	}
+
+	if f.Decimal != "" && f.Decimal != "." {
+		s = strings.Replace(s, f.Decimal, ".", 1)
+	}
+
 	return strconv.ParseFloat(s, 64)
 }
@@ -1,10 +1,26 @@
 package namecheap_test
 
 import (
+	"context"
 	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
 	"testing"
+	"time"
 
 	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/tldtype"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/tool"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"go.uber.org/zap"
 )
 
@@ -86,6 +102,61 @@ func TestParseFloat(t *testing.T) {
 	}
 }
 
+func TestNumberFormat_Parse(t *testing.T) {
+	t.Parallel()
+
+	european := namecheap.NumberFormat{Decimal: ",", Thousands: "."}
+
+	tests := []struct {
+		name    string
+		format  namecheap.NumberFormat
+		input   string
+		want    float64
+		wantErr bool
+	}{
+		{name: "european thousands and decimals", format: european, input: "1.000,00", want: 1000, wantErr: false},
+		{name: "european decimals only", format: european, input: "12,5", want: 12.5, wantErr: false},
+		{name: "european millions", format: european, input: "2.500.000,75", want: 2500000.75, wantErr: false},
+		{
+			name:    "comma thousands",
+			format:  namecheap.NumberFormat{Decimal: ".", Thousands: ","},
+			input:   "1,000.00",
+			want:    1000,
+			wantErr: false,
+		},
+		{
+			name:    "space thousands",
+			format:  namecheap.NumberFormat{Decimal: ",", Thousands: " "},
+			input:   " 1 000,50 ",
+			want:    1000.5,
+			wantErr: false,
+		},
+		{
+			name:    "default format rejects grouping",
+			format:  namecheap.NumberFormat{Decimal: "", Thousands: ""},
+			input:   "1.000,00",
+			want:    0,
+			wantErr: true,
+		},
+		{name: "empty string returns zero", format: european, input: "", want: 0, wantErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tt.format.Parse(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("Parse(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
 //nolint:funlen
 func TestNewService(t *testing.T) {
 	t.Parallel()
@@ -100,77 +171,203 @@ func TestNewService(t *testing.T) {
 		{
 			name: "valid config",
 			config: namecheap.Config{
-				APIUser:  "user",
-				APIKey:   "key",
-				UserName: "username",
-				ClientIP: "127.0.0.1",
-				Endpoint: "https://api.namecheap.com/xml.response",
+				APIUser:               "user",
+				APIKey:                "key",
+				UserName:              "username",
+				ClientIP:              "127.0.0.1",
+				Endpoint:              "https://api.namecheap.com/xml.response",
+				Sandbox:               false,
+				IncludeRaw:            false,
+				RegisterURLTemplate:   "",
+				MaxRetries:            0,
+				HTTPClient:            nil,
+				RequestTimeout:        0,
+				CheckTimeout:          0,
+				RateLimitPerSecond:    0,
+				RateLimitBurst:        0,
+				StrictXML:             false,
+				NumberFormat:          namecheap.NumberFormat{Decimal: "", Thousands: ""},
+				BatchRetry:            false,
+				BatchRetryDelay:       0,
+				AvailableErrorNumbers: nil,
+				MaxDomainsPerCheck:    0,
+				IncludeRawResponse:    false,
+				UserAgent:             "",
+				StandardPricing:       false,
 			},
 			wantErr: nil,
 		},
 		{
 			name: "missing APIUser",
 			config: namecheap.Config{
-				APIUser:  "",
-				APIKey:   "key",
-				UserName: "username",
-				ClientIP: "127.0.0.1",
-				Endpoint: "",
+				APIUser:               "",
+				APIKey:                "key",
+				UserName:              "username",
+				ClientIP:              "127.0.0.1",
+				Endpoint:              "",
+				Sandbox:               false,
+				IncludeRaw:            false,
+				RegisterURLTemplate:   "",
+				MaxRetries:            0,
+				HTTPClient:            nil,
+				RequestTimeout:        0,
+				CheckTimeout:          0,
+				RateLimitPerSecond:    0,
+				RateLimitBurst:        0,
+				StrictXML:             false,
+				NumberFormat:          namecheap.NumberFormat{Decimal: "", Thousands: ""},
+				BatchRetry:            false,
+				BatchRetryDelay:       0,
+				AvailableErrorNumbers: nil,
+				MaxDomainsPerCheck:    0,
+				IncludeRawResponse:    false,
+				UserAgent:             "",
+				StandardPricing:       false,
 			},
 			wantErr: namecheap.ErrMissingAPICredentials,
 		},
 		{
 			name: "missing APIKey",
 			config: namecheap.Config{
-				APIUser:  "user",
-				APIKey:   "",
-				UserName: "username",
-				ClientIP: "127.0.0.1",
-				Endpoint: "",
+				APIUser:               "user",
+				APIKey:                "",
+				UserName:              "username",
+				ClientIP:              "127.0.0.1",
+				Endpoint:              "",
+				Sandbox:               false,
+				IncludeRaw:            false,
+				RegisterURLTemplate:   "",
+				MaxRetries:            0,
+				HTTPClient:            nil,
+				RequestTimeout:        0,
+				CheckTimeout:          0,
+				RateLimitPerSecond:    0,
+				RateLimitBurst:        0,
+				StrictXML:             false,
+				NumberFormat:          namecheap.NumberFormat{Decimal: "", Thousands: ""},
+				BatchRetry:            false,
+				BatchRetryDelay:       0,
+				AvailableErrorNumbers: nil,
+				MaxDomainsPerCheck:    0,
+				IncludeRawResponse:    false,
+				UserAgent:             "",
+				StandardPricing:       false,
 			},
 			wantErr: namecheap.ErrMissingAPICredentials,
 		},
 		{
 			name: "missing UserName",
 			config: namecheap.Config{
-				APIUser:  "user",
-				APIKey:   "key",
-				UserName: "",
-				ClientIP: "127.0.0.1",
-				Endpoint: "",
+				APIUser:               "user",
+				APIKey:                "key",
+				UserName:              "",
+				ClientIP:              "127.0.0.1",
+				Endpoint:              "",
+				Sandbox:               false,
+				IncludeRaw:            false,
+				RegisterURLTemplate:   "",
+				MaxRetries:            0,
+				HTTPClient:            nil,
+				RequestTimeout:        0,
+				CheckTimeout:          0,
+				RateLimitPerSecond:    0,
+				RateLimitBurst:        0,
+				StrictXML:             false,
+				NumberFormat:          namecheap.NumberFormat{Decimal: "", Thousands: ""},
+				BatchRetry:            false,
+				BatchRetryDelay:       0,
+				AvailableErrorNumbers: nil,
+				MaxDomainsPerCheck:    0,
+				IncludeRawResponse:    false,
+				UserAgent:             "",
+				StandardPricing:       false,
 			},
 			wantErr: namecheap.ErrMissingAPICredentials,
 		},
 		{
 			name: "missing ClientIP",
 			config: namecheap.Config{
-				APIUser:  "user",
-				APIKey:   "key",
-				UserName: "username",
-				ClientIP: "",
-				Endpoint: "",
+				APIUser:               "user",
+				APIKey:                "key",
+				UserName:              "username",
+				ClientIP:              "",
+				Endpoint:              "",
+				Sandbox:               false,
+				IncludeRaw:            false,
+				RegisterURLTemplate:   "",
+				MaxRetries:            0,
+				HTTPClient:            nil,
+				RequestTimeout:        0,
+				CheckTimeout:          0,
+				RateLimitPerSecond:    0,
+				RateLimitBurst:        0,
+				StrictXML:             false,
+				NumberFormat:          namecheap.NumberFormat{Decimal: "", Thousands: ""},
+				BatchRetry:            false,
+				BatchRetryDelay:       0,
+				AvailableErrorNumbers: nil,
+				MaxDomainsPerCheck:    0,
+				IncludeRawResponse:    false,
+				UserAgent:             "",
+				StandardPricing:       false,
 			},
 			wantErr: namecheap.ErrMissingAPICredentials,
 		},
 		{
 			name: "all fields missing",
 			config: namecheap.Config{
-				APIUser:  "",
-				APIKey:   "",
-				UserName: "",
-				ClientIP: "",
-				Endpoint: "",
+				APIUser:               "",
+				APIKey:                "",
+				UserName:              "",
+				ClientIP:              "",
+				Endpoint:              "",
+				Sandbox:               false,
+				IncludeRaw:            false,
+				RegisterURLTemplate:   "",
+				MaxRetries:            0,
+				HTTPClient:            nil,
+				RequestTimeout:        0,
+				CheckTimeout:          0,
+				RateLimitPerSecond:    0,
+				RateLimitBurst:        0,
+				StrictXML:             false,
+				NumberFormat:          namecheap.NumberFormat{Decimal: "", Thousands: ""},
+				BatchRetry:            false,
+				BatchRetryDelay:       0,
+				AvailableErrorNumbers: nil,
+				MaxDomainsPerCheck:    0,
+				IncludeRawResponse:    false,
+				UserAgent:             "",
+				StandardPricing:       false,
 			},
 			wantErr: namecheap.ErrMissingAPICredentials,
 		},
 		{
 			name: "endpoint can be empty",
 			config: namecheap.Config{
-				APIUser:  "user",
-				APIKey:   "key",
-				UserName: "username",
-				ClientIP: "127.0.0.1",
-				Endpoint: "",
+				APIUser:               "user",
+				APIKey:                "key",
+				UserName:              "username",
+				ClientIP:              "127.0.0.1",
+				Endpoint:              "",
+				Sandbox:               false,
+				IncludeRaw:            false,
+				RegisterURLTemplate:   "",
+				MaxRetries:            0,
+				HTTPClient:            nil,
+				RequestTimeout:        0,
+				CheckTimeout:          0,
+				RateLimitPerSecond:    0,
+				RateLimitBurst:        0,
+				StrictXML:             false,
+				NumberFormat:          namecheap.NumberFormat{Decimal: "", Thousands: ""},
+				BatchRetry:            false,
+				BatchRetryDelay:       0,
+				AvailableErrorNumbers: nil,
+				MaxDomainsPerCheck:    0,
+				IncludeRawResponse:    false,
+				UserAgent:             "",
+				StandardPricing:       false,
 			},
 			wantErr: nil,
 		},
@@ -201,16 +398,180 @@ func TestNewService(t *testing.T) {
 	}
 }
 
+func TestNewService_ClientIP(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		clientIP string
+		wantErr  error
+	}{
+		{name: "IPv4", clientIP: "203.0.113.7", wantErr: nil},
+		{name: "IPv6", clientIP: "2001:db8::7", wantErr: nil},
+		{name: "truncated IPv4", clientIP: "127.0.0", wantErr: namecheap.ErrInvalidClientIP},
+		{name: "hostname", clientIP: "myhost.example.com", wantErr: namecheap.ErrInvalidClientIP},
+		{name: "with port", clientIP: "203.0.113.7:443", wantErr: namecheap.ErrInvalidClientIP},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			service, err := namecheap.NewService(zap.NewNop(), namecheap.Config{ //nolint:exhaustruct
+				APIUser:  "user",
+				APIKey:   "key",
+				UserName: "username",
+				ClientIP: tt.clientIP,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewService() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil && service != nil {
+				t.Error("NewService() returned service when error expected")
+			}
+		})
+	}
+}
+
+func TestNewService_Endpoint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		endpoint string
+		sandbox  bool
+		wantURL  string
+		wantErr  error
+	}{
+		{
+			name:     "production by default",
+			endpoint: "",
+			sandbox:  false,
+			wantURL:  namecheap.ProductionEndpoint,
+			wantErr:  nil,
+		},
+		{
+			name:     "sandbox flag",
+			endpoint: "",
+			sandbox:  true,
+			wantURL:  namecheap.SandboxEndpoint,
+			wantErr:  nil,
+		},
+		{
+			name:     "explicit endpoint over sandbox flag",
+			endpoint: "https://proxy.example.com/xml.response",
+			sandbox:  true,
+			wantURL:  "https://proxy.example.com/xml.response",
+			wantErr:  nil,
+		},
+		{
+			name:     "plain http on loopback",
+			endpoint: "http://127.0.0.1:8080/xml.response",
+			sandbox:  false,
+			wantURL:  "http://127.0.0.1:8080/xml.response",
+			wantErr:  nil,
+		},
+		{
+			name:     "plain http",
+			endpoint: "http://api.namecheap.com/xml.response",
+			sandbox:  false,
+			wantURL:  "",
+			wantErr:  namecheap.ErrInvalidEndpoint,
+		},
+		{
+			name:     "missing scheme",
+			endpoint: "api.namecheap.com",
+			sandbox:  false,
+			wantURL:  "",
+			wantErr:  namecheap.ErrInvalidEndpoint,
+		},
+		{
+			name:     "malformed",
+			endpoint: "https://%zz",
+			sandbox:  false,
+			wantURL:  "",
+			wantErr:  namecheap.ErrInvalidEndpoint,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requested string
+
+			transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				requested = req.URL.Scheme + "://" + req.URL.Host + req.URL.Path
+
+				return &http.Response{ //nolint:exhaustruct
+					StatusCode: http.StatusOK,
+					Body: io.NopCloser(strings.NewReader(`<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="OK" xmlns="http://api.namecheap.com/xml.response">
+  <CommandResponse Type="namecheap.domains.check">
+    <DomainCheckResult Domain="example.com" Available="true" ErrorNo="0" Description="" IsPremiumName="false" />
+  </CommandResponse>
+</ApiResponse>`)),
+				}, nil
+			})
+
+			service, err := namecheap.NewService(zap.NewNop(), namecheap.Config{ //nolint:exhaustruct
+				APIUser:    "user",
+				APIKey:     "key",
+				UserName:   "username",
+				ClientIP:   "127.0.0.1",
+				Endpoint:   tt.endpoint,
+				Sandbox:    tt.sandbox,
+				HTTPClient: &http.Client{Transport: transport}, //nolint:exhaustruct
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewService() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			_, err = service.DomainsCheck(context.Background(), []string{"example.com"})
+			if err != nil {
+				t.Fatalf("DomainsCheck() unexpected error: %v", err)
+			}
+
+			if requested != tt.wantURL {
+				t.Errorf("requested %q, want %q", requested, tt.wantURL)
+			}
+		})
+	}
+}
+
 func TestDomainsCheck_Validation(t *testing.T) {
 	t.Parallel()
 
 	logger := zap.NewNop()
 	config := namecheap.Config{
-		APIUser:  "user",
-		APIKey:   "key",
-		UserName: "username",
-		ClientIP: "127.0.0.1",
-		Endpoint: "https://api.namecheap.com/xml.response",
+		APIUser:               "user",
+		APIKey:                "key",
+		UserName:              "username",
+		ClientIP:              "127.0.0.1",
+		Endpoint:              "https://api.namecheap.com/xml.response",
+		Sandbox:               false,
+		IncludeRaw:            false,
+		RegisterURLTemplate:   "",
+		MaxRetries:            0,
+		HTTPClient:            nil,
+		RequestTimeout:        0,
+		CheckTimeout:          0,
+		RateLimitPerSecond:    0,
+		RateLimitBurst:        0,
+		StrictXML:             false,
+		NumberFormat:          namecheap.NumberFormat{Decimal: "", Thousands: ""},
+		BatchRetry:            false,
+		BatchRetryDelay:       0,
+		AvailableErrorNumbers: nil,
+		MaxDomainsPerCheck:    0,
+		IncludeRawResponse:    false,
+		UserAgent:             "",
+		StandardPricing:       false,
 	}
 
 	service, err := namecheap.NewService(logger, config)
@@ -233,10 +594,126 @@ func TestDomainsCheck_Validation(t *testing.T) {
 			domains: nil,
 			wantErr: namecheap.ErrMissingDomains,
 		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := service.DomainsCheck(context.Background(), tt.domains)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("DomainsCheck() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// newTestService returns a Service whose endpoint is an httptest server replying with body.
+func newTestService(t *testing.T, body string, options ...func(*namecheap.Config)) *namecheap.Service {
+	t.Helper()
+
+	return newTestServiceWithHandler(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+
+		_, _ = w.Write([]byte(body))
+	}, options...)
+}
+
+// newTestServiceWithHandler returns a Service whose endpoint is an httptest server using handler.
+// Options adjust the default test configuration before the service is created.
+func newTestServiceWithHandler(
+	t *testing.T,
+	handler http.HandlerFunc,
+	options ...func(*namecheap.Config),
+) *namecheap.Service {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	config := namecheap.Config{
+		APIUser:               "user",
+		APIKey:                "key",
+		UserName:              "username",
+		ClientIP:              "127.0.0.1",
+		Endpoint:              server.URL,
+		Sandbox:               false,
+		IncludeRaw:            false,
+		RegisterURLTemplate:   "",
+		MaxRetries:            0,
+		HTTPClient:            nil,
+		RequestTimeout:        0,
+		CheckTimeout:          0,
+		RateLimitPerSecond:    0,
+		RateLimitBurst:        0,
+		StrictXML:             false,
+		NumberFormat:          namecheap.NumberFormat{Decimal: "", Thousands: ""},
+		BatchRetry:            false,
+		BatchRetryDelay:       0,
+		AvailableErrorNumbers: nil,
+		MaxDomainsPerCheck:    0,
+		IncludeRawResponse:    false,
+		UserAgent:             "",
+		StandardPricing:       false,
+	}
+
+	for _, option := range options {
+		option(&config)
+	}
+
+	service, err := namecheap.NewService(zap.NewNop(), config)
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+
+	return service
+}
+
+func TestDomainsCheck_ResponseStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		body        string
+		wantErr     error
+		wantMessage string
+	}{
+		{
+			name: "error status reports first API error",
+			body: `<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="ERROR" xmlns="http://api.namecheap.com/xml.response">
+  <Errors><Error Number="1011102">Parameter APIKey is missing</Error></Errors>
+</ApiResponse>`,
+			wantErr:     namecheap.ErrAPIError,
+			wantMessage: "Parameter APIKey is missing",
+		},
+		{
+			name: "request IP not whitelisted",
+			body: `<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="ERROR" xmlns="http://api.namecheap.com/xml.response">
+  <Errors><Error Number="1011150">Invalid request IP: 203.0.113.7</Error></Errors>
+  <RequestedCommand>namecheap.domains.check</RequestedCommand>
+</ApiResponse>`,
+			wantErr:     namecheap.ErrIPNotWhitelisted,
+			wantMessage: "add 127.0.0.1 to your Namecheap API whitelist",
+		},
 		{
-			name:    "51 domains exceeds limit",
-			domains: make([]string, 51),
-			wantErr: namecheap.ErrMaxDomainsExceeded,
+			name: "invalid API key",
+			body: `<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="ERROR" xmlns="http://api.namecheap.com/xml.response">
+  <Errors><Error Number="1011102">API Key is invalid or API access has not been enabled</Error></Errors>
+</ApiResponse>`,
+			wantErr:     namecheap.ErrAuthFailed,
+			wantMessage: "API Key is invalid",
+		},
+		{
+			name: "unknown status is reported verbatim",
+			body: `<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="MAINTENANCE" xmlns="http://api.namecheap.com/xml.response">
+  <Errors />
+</ApiResponse>`,
+			wantErr:     namecheap.ErrUnexpectedStatus,
+			wantMessage: "MAINTENANCE",
 		},
 	}
 
@@ -244,9 +721,831 @@ func TestDomainsCheck_Validation(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			_, err := service.DomainsCheck(tt.domains)
+			service := newTestService(t, tt.body)
+
+			_, err := service.DomainsCheck(context.Background(), []string{"example.com"})
 			if !errors.Is(err, tt.wantErr) {
-				t.Errorf("DomainsCheck() error = %v, wantErr %v", err, tt.wantErr)
+				t.Fatalf("DomainsCheck() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !strings.Contains(err.Error(), tt.wantMessage) {
+				t.Errorf("DomainsCheck() error = %q, want it to contain %q", err, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestDomainsCheck_NonXMLResponses(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		status      int
+		contentType string
+		body        string
+		wantErr     error
+		wantMessage string
+	}{
+		{
+			name:        "empty 503",
+			status:      http.StatusServiceUnavailable,
+			contentType: "",
+			body:        "",
+			wantErr:     namecheap.ErrNamecheapAPIFailed,
+			wantMessage: "HTTP 503: empty body",
+		},
+		{
+			name:        "proxy error page",
+			status:      http.StatusBadGateway,
+			contentType: "text/html",
+			body:        "<html>\n<head><title>502 Bad Gateway</title></head>\n</html>",
+			wantErr:     namecheap.ErrNamecheapAPIFailed,
+			wantMessage: "HTTP 502: <html> <head><title>502 Bad Gateway</title></head> </html>",
+		},
+		{
+			name:        "html page with 200",
+			status:      http.StatusOK,
+			contentType: "text/html; charset=utf-8",
+			body:        "<!DOCTYPE html><html><body>Maintenance</body></html>",
+			wantErr:     namecheap.ErrNotXML,
+			wantMessage: `Content-Type "text/html; charset=utf-8"`,
+		},
+		{
+			name:        "json with 200",
+			status:      http.StatusOK,
+			contentType: "",
+			body:        `{"error":"maintenance"}`,
+			wantErr:     namecheap.ErrNotXML,
+			wantMessage: `{"error":"maintenance"}`,
+		},
+		{
+			name:        "long body is truncated",
+			status:      http.StatusInternalServerError,
+			contentType: "text/plain",
+			body:        strings.Repeat("x", 1000),
+			wantErr:     namecheap.ErrNamecheapAPIFailed,
+			wantMessage: strings.Repeat("x", 200) + "...",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			service := newTestServiceWithHandler(t, func(w http.ResponseWriter, _ *http.Request) {
+				if tt.contentType != "" {
+					w.Header().Set("Content-Type", tt.contentType)
+				}
+
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			})
+
+			_, err := service.DomainsCheck(context.Background(), []string{"example.com"})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DomainsCheck() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !strings.Contains(err.Error(), tt.wantMessage) {
+				t.Errorf("DomainsCheck() error = %q, want it to contain %q", err, tt.wantMessage)
+			}
+
+			if strings.Contains(err.Error(), strings.Repeat("x", 201)) {
+				t.Errorf("DomainsCheck() error = %q, want the body truncated", err)
+			}
+
+			// The tool wraps the failure without naming it a second time.
+			_, err = namecheap.NewSingleCheckService(service).Execute(context.Background(),
+				namecheap.SingleParamsIn{Domain: "example.com"})
+			if got := strings.Count(err.Error(), namecheap.ErrNamecheapAPIFailed.Error()); got != 1 {
+				t.Errorf("Execute() error = %q, want %q once, not %d times", err, namecheap.ErrNamecheapAPIFailed, got)
+			}
+		})
+	}
+}
+
+func TestDomainsCheck_UserAgent(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		userAgent string
+		want      string
+	}{
+		{name: "default", userAgent: "", want: namecheap.DefaultUserAgent},
+		{name: "configured", userAgent: "acme-checker/2.1", want: "acme-checker/2.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got atomic.Value
+
+			service := newTestServiceWithHandler(t, func(w http.ResponseWriter, r *http.Request) {
+				got.Store(r.Header.Get("User-Agent"))
+
+				_, _ = w.Write([]byte(`<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="OK" xmlns="http://api.namecheap.com/xml.response">
+  <CommandResponse Type="namecheap.domains.check">
+    <DomainCheckResult Domain="example.com" Available="true" ErrorNo="0" Description="" IsPremiumName="false" />
+  </CommandResponse>
+</ApiResponse>`))
+			}, func(config *namecheap.Config) {
+				config.UserAgent = tt.userAgent
+			})
+
+			_, err := service.DomainsCheck(context.Background(), []string{"example.com"})
+			if err != nil {
+				t.Fatalf("DomainsCheck() unexpected error: %v", err)
+			}
+
+			if got.Load() != tt.want {
+				t.Errorf("User-Agent = %v, want %q", got.Load(), tt.want)
+			}
+		})
+	}
+}
+
+func TestHandler_IncludeRawResponse(t *testing.T) {
+	t.Parallel()
+
+	body := `<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="OK" xmlns="http://api.namecheap.com/xml.response">
+  <CommandResponse Type="namecheap.domains.check">
+    <DomainCheckResult Domain="example.com" Available="true" ErrorNo="0" Description="" IsPremiumName="false" />
+  </CommandResponse>
+</ApiResponse>`
+
+	service := newTestService(t, body, func(config *namecheap.Config) {
+		config.IncludeRawResponse = true
+	})
+	namecheapTool := tool.NewTool(zap.NewNop(), namecheap.NewCheckService(service, tldtype.Default()))
+
+	result, output, err := namecheapTool.Handler(context.Background(), nil, namecheap.ParamsIn{
+		Domains:           []string{"example.com"},
+		TreatTakenAsError: false,
+		GroupBy:           "",
+		SLDRegex:          "",
+		CorrelationID:     "",
+		SortBy:            "",
+		AvailableOnly:     false,
+		ReportDuplicates:  false,
+	})
+	if err != nil {
+		t.Fatalf("Handler() unexpected error: %v", err)
+	}
+
+	if len(output.Results) != 1 || !output.Results[0].Available {
+		t.Errorf("Handler() results = %+v, want example.com available", output.Results)
+	}
+
+	raw, ok := result.Meta["rawResponses"].([]tool.RawResponse)
+	if !ok || len(raw) != 1 || raw[0].Body != body || raw[0].Command != "namecheap.domains.check" {
+		t.Errorf("Meta[rawResponses] = %#v, want the raw check response", result.Meta["rawResponses"])
+	}
+}
+
+func TestHandler_EmptyDomainsIsToolError(t *testing.T) {
+	t.Parallel()
+
+	service := newTestService(t, "")
+	namecheapTool := tool.NewTool(zap.NewNop(), namecheap.NewCheckService(service, tldtype.Default()))
+
+	result, _, err := namecheapTool.Handler(context.Background(), nil, namecheap.ParamsIn{
+		Domains:           []string{},
+		TreatTakenAsError: false,
+		GroupBy:           "",
+		SLDRegex:          "",
+		CorrelationID:     "",
+		SortBy:            "",
+		AvailableOnly:     false,
+		ReportDuplicates:  false,
+	})
+	if err != nil {
+		t.Fatalf("Handler() error = %v, want structured tool error", err)
+	}
+
+	if result == nil || !result.IsError {
+		t.Fatalf("Handler() result = %+v, want IsError result", result)
+	}
+
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	if !ok {
+		t.Fatal("Handler() result.Content[0] is not TextContent")
+	}
+
+	if !strings.Contains(textContent.Text, `supply at least one domain in the "domains" array`) {
+		t.Errorf("Handler() text = %q, want a hint to supply domains", textContent.Text)
+	}
+}
+
+func TestDomainsCheck_LengthLimits(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	service := newTestServiceWithHandler(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+
+		if got := r.URL.Query().Get("DomainList"); got != "example.com" {
+			t.Errorf("DomainList = %q, want only the valid domain", got)
+		}
+
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="OK" xmlns="http://api.namecheap.com/xml.response">
+  <CommandResponse Type="namecheap.domains.check">
+    <DomainCheckResult Domain="example.com" Available="false" ErrorNo="0" Description="" IsPremiumName="false" />
+  </CommandResponse>
+</ApiResponse>`))
+	})
+
+	longDomain := strings.Repeat("a", 296) + ".com"
+	longLabel := strings.Repeat("b", 64) + ".com"
+
+	results, err := service.DomainsCheck(context.Background(), []string{longDomain})
+	if err != nil {
+		t.Fatalf("DomainsCheck() unexpected error: %v", err)
+	}
+
+	if len(results) != 1 || !strings.Contains(results[0].Error, namecheap.ErrDomainTooLong.Error()) {
+		t.Fatalf("DomainsCheck() = %+v, want a length error", results)
+	}
+
+	if calls.Load() != 0 {
+		t.Fatalf("API called %d times for an invalid-only request, want 0", calls.Load())
+	}
+
+	results, err = service.DomainsCheck(context.Background(), []string{longLabel, "example.com", longDomain})
+	if err != nil {
+		t.Fatalf("DomainsCheck() unexpected error: %v", err)
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("DomainsCheck() returned %d results, want 3", len(results))
+	}
+
+	if results[0].Domain != longLabel || !strings.Contains(results[0].Error, namecheap.ErrLabelTooLong.Error()) {
+		t.Errorf("results[0] = %+v, want label length error", results[0])
+	}
+
+	if results[1].Domain != "example.com" || results[1].Error != "" {
+		t.Errorf("results[1] = %+v, want the checked domain", results[1])
+	}
+
+	if results[2].Domain != longDomain || !strings.Contains(results[2].Error, namecheap.ErrDomainTooLong.Error()) {
+		t.Errorf("results[2] = %+v, want domain length error", results[2])
+	}
+}
+
+func TestDomainsCheck_IncludeRaw(t *testing.T) {
+	t.Parallel()
+
+	const body = `<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="OK" xmlns="http://api.namecheap.com/xml.response">
+  <CommandResponse Type="namecheap.domains.check">
+    <DomainCheckResult Domain="example.com" Available="false" ErrorNo="0" Description=""
+      IsPremiumName="false" PremiumRegistrationPrice="0" NewAttribute="surprise" />
+  </CommandResponse>
+</ApiResponse>`
+
+	tests := []struct {
+		name       string
+		includeRaw bool
+		wantRaw    map[string]string
+	}{
+		{
+			name:       "debug on attaches every attribute",
+			includeRaw: true,
+			wantRaw: map[string]string{
+				"Domain":                   "example.com",
+				"Available":                "false",
+				"ErrorNo":                  "0",
+				"Description":              "",
+				"IsPremiumName":            "false",
+				"PremiumRegistrationPrice": "0",
+				"NewAttribute":             "surprise",
+			},
+		},
+		{
+			name:       "debug off leaves raw empty",
+			includeRaw: false,
+			wantRaw:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			service := newTestServiceWithHandler(t, func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(body))
+			}, func(config *namecheap.Config) {
+				config.IncludeRaw = tt.includeRaw
+			})
+
+			results, err := service.DomainsCheck(context.Background(), []string{"example.com"})
+			if err != nil {
+				t.Fatalf("DomainsCheck() unexpected error: %v", err)
+			}
+
+			if len(results) != 1 {
+				t.Fatalf("DomainsCheck() returned %d results, want 1", len(results))
+			}
+
+			if !reflect.DeepEqual(results[0].Raw, tt.wantRaw) {
+				t.Errorf("Raw = %v, want %v", results[0].Raw, tt.wantRaw)
+			}
+		})
+	}
+}
+
+func TestDomainsCheck_MaxRetries(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		maxRetries int
+		wantCalls  int32
+	}{
+		{name: "backend retrying three times", maxRetries: 3, wantCalls: 4},
+		{name: "backend retrying once", maxRetries: 1, wantCalls: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls atomic.Int32
+
+			// Every request is answered with a TCP reset, the failure the retry layer handles.
+			service := newTestServiceWithHandler(t, func(w http.ResponseWriter, _ *http.Request) {
+				calls.Add(1)
+
+				conn, _, err := http.NewResponseController(w).Hijack()
+				if err != nil {
+					t.Errorf("Hijack() error: %v", err)
+
+					return
+				}
+
+				if tcpConn, ok := conn.(*net.TCPConn); ok {
+					_ = tcpConn.SetLinger(0)
+				}
+
+				_ = conn.Close()
+			}, func(config *namecheap.Config) {
+				config.MaxRetries = tt.maxRetries
+			})
+
+			_, err := service.DomainsCheck(context.Background(), []string{"example.com"})
+			if !errors.Is(err, syscall.ECONNRESET) {
+				t.Errorf("DomainsCheck() error = %v, want connection reset", err)
+			}
+
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("requests = %d, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestDomainsCheck_ContextCancelled(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+
+	service := newTestServiceWithHandler(t, func(_ http.ResponseWriter, _ *http.Request) {
+		<-release
+	})
+	t.Cleanup(func() { close(release) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+
+	_, err := service.DomainsCheck(ctx, []string{"example.com"})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("DomainsCheck() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestDomainsCheck_RateLimit(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+
+	service := newTestServiceWithHandler(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		domain := r.URL.Query().Get("DomainList")
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="OK" xmlns="http://api.namecheap.com/xml.response">
+  <CommandResponse Type="namecheap.domains.check">
+    <DomainCheckResult Domain="` + domain + `" Available="true" ErrorNo="0" Description="" IsPremiumName="false" />
+  </CommandResponse>
+</ApiResponse>`))
+	}, func(config *namecheap.Config) {
+		config.RateLimitPerSecond = 20
+		config.RateLimitBurst = 1
+	})
+
+	start := time.Now()
+
+	for _, domain := range []string{"one.com", "two.com", "three.com"} {
+		_, err := service.DomainsCheck(context.Background(), []string{domain})
+		if err != nil {
+			t.Fatalf("DomainsCheck() unexpected error: %v", err)
+		}
+	}
+
+	// The burst lets the first call through; the other two wait 50ms each.
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("three calls took %v, want them paced at 20 per second", elapsed)
+	}
+
+	slow := newTestServiceWithHandler(t, func(_ http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+	}, func(config *namecheap.Config) {
+		config.RateLimitPerSecond = 0.001
+		config.RateLimitBurst = 1
+	})
+
+	// Spend the burst, so the next call has to wait for the limiter.
+	_, _ = slow.DomainsCheck(context.Background(), []string{"first.com"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+
+	sent := requests.Load()
+
+	_, err := namecheap.NewCheckService(slow, tldtype.Default()).Execute(ctx, namecheap.ParamsIn{
+		Domains:           []string{"second.com"},
+		TreatTakenAsError: false,
+		GroupBy:           "",
+		SLDRegex:          "",
+		CorrelationID:     "",
+		SortBy:            "",
+		AvailableOnly:     false,
+		ReportDuplicates:  false,
+	})
+	if !errors.Is(err, context.Canceled) || errors.Is(err, namecheap.ErrNamecheapAPIFailed) {
+		t.Errorf("Execute() error = %v, want the context error rather than an API failure", err)
+	}
+
+	if requests.Load() != sent {
+		t.Error("a rate-limited call was sent after its context was cancelled")
+	}
+}
+
+func TestDomainsCheck_BatchRetry(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		batchRetry   bool
+		wantRequests int32
+		wantErr      bool
+	}{
+		{name: "total failure is retried once", batchRetry: true, wantRequests: 2, wantErr: false},
+		{name: "disabled by default", batchRetry: false, wantRequests: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests atomic.Int32
+
+			service := newTestServiceWithHandler(t, func(w http.ResponseWriter, _ *http.Request) {
+				if requests.Add(1) == 1 {
+					_, _ = w.Write([]byte(`<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="ERROR" xmlns="http://api.namecheap.com/xml.response">
+  <Errors><Error Number="500000">Service temporarily unavailable</Error></Errors>
+</ApiResponse>`))
+
+					return
+				}
+
+				_, _ = w.Write([]byte(`<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="OK" xmlns="http://api.namecheap.com/xml.response">
+  <CommandResponse Type="namecheap.domains.check">
+    <DomainCheckResult Domain="example.com" Available="true" ErrorNo="0" Description="" IsPremiumName="false" />
+  </CommandResponse>
+</ApiResponse>`))
+			}, func(config *namecheap.Config) {
+				config.BatchRetry = tt.batchRetry
+				config.BatchRetryDelay = 10 * time.Millisecond
+			})
+
+			results, err := service.DomainsCheck(context.Background(), []string{"example.com"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DomainsCheck() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && (len(results) != 1 || !results[0].Available) {
+				t.Errorf("DomainsCheck() = %+v, want the retried result", results)
+			}
+
+			if got := requests.Load(); got != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+// roundTripperFunc adapts a function to http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestDomainsCheck_CustomHTTPClient(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	client := &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) { //nolint:exhaustruct
+		calls.Add(1)
+
+		if got := req.URL.Query().Get("DomainList"); got != "example.com" {
+			t.Errorf("DomainList = %q, want example.com", got)
+		}
+
+		return &http.Response{ //nolint:exhaustruct
+			StatusCode: http.StatusOK,
+			Body: io.NopCloser(strings.NewReader(`<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="OK" xmlns="http://api.namecheap.com/xml.response">
+  <CommandResponse Type="namecheap.domains.check">
+    <DomainCheckResult Domain="example.com" Available="true" ErrorNo="0" Description="" IsPremiumName="false" />
+  </CommandResponse>
+</ApiResponse>`)),
+		}, nil
+	})}
+
+	service := newTestServiceWithHandler(t, func(_ http.ResponseWriter, _ *http.Request) {
+		t.Error("the default client was used instead of the injected one")
+	}, func(config *namecheap.Config) {
+		config.HTTPClient = client
+	})
+
+	results, err := service.DomainsCheck(context.Background(), []string{"example.com"})
+	if err != nil {
+		t.Fatalf("DomainsCheck() unexpected error: %v", err)
+	}
+
+	if len(results) != 1 || !results[0].Available {
+		t.Errorf("DomainsCheck() = %+v, want example.com available", results)
+	}
+
+	if calls.Load() != 1 {
+		t.Errorf("injected client calls = %d, want 1", calls.Load())
+	}
+}
+
+func TestDomainsCheck_Batches(t *testing.T) {
+	t.Parallel()
+
+	var (
+		requests atomic.Int32
+		inFlight atomic.Int32
+		peak     atomic.Int32
+	)
+
+	service := newTestServiceWithHandler(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+
+		for {
+			seen := peak.Load()
+			if current <= seen || peak.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+
+		// Keep requests in flight long enough for the batches to overlap.
+		time.Sleep(20 * time.Millisecond)
+
+		domains := strings.Split(r.URL.Query().Get("DomainList"), ",")
+		if slices.Contains(domains, "fail-me.com") {
+			_, _ = w.Write([]byte(`<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="ERROR" xmlns="http://api.namecheap.com/xml.response">
+  <Errors><Error Number="500000">Too many requests</Error></Errors>
+</ApiResponse>`))
+
+			return
+		}
+
+		var body strings.Builder
+
+		body.WriteString(`<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="OK" xmlns="http://api.namecheap.com/xml.response"><CommandResponse Type="namecheap.domains.check">`)
+
+		for _, domain := range domains {
+			body.WriteString(`<DomainCheckResult Domain="` + domain +
+				`" Available="true" ErrorNo="0" Description="" IsPremiumName="false" />`)
+		}
+
+		body.WriteString(`</CommandResponse></ApiResponse>`)
+
+		_, _ = w.Write([]byte(body.String()))
+	})
+
+	domains := make([]string, 0, 260)
+	for i := range 260 {
+		domains = append(domains, "domain"+strconv.Itoa(i)+".com")
+	}
+
+	// Fail the third batch (domains 100-149).
+	domains[120] = "fail-me.com"
+
+	results, err := service.DomainsCheck(context.Background(), domains)
+
+	var partial *namecheap.PartialError
+	if !errors.As(err, &partial) {
+		t.Fatalf("DomainsCheck() error = %v, want a *namecheap.PartialError", err)
+	}
+
+	if failed := partial.FailedDomains(); !reflect.DeepEqual(failed, domains[100:150]) {
+		t.Errorf("FailedDomains() = %v, want the third batch %v", failed, domains[100:150])
+	}
+
+	if !errors.Is(err, namecheap.ErrAPIError) {
+		t.Errorf("DomainsCheck() error = %v, want it to wrap %v", err, namecheap.ErrAPIError)
+	}
+
+	if got := requests.Load(); got != 6 {
+		t.Errorf("requests = %d, want 6 batches", got)
+	}
+
+	if got := peak.Load(); got > 4 {
+		t.Errorf("peak concurrent requests = %d, want at most 4", got)
+	}
+
+	if len(results) != len(domains) {
+		t.Fatalf("DomainsCheck() returned %d results, want %d", len(results), len(domains))
+	}
+
+	for i, result := range results {
+		if result.Domain != domains[i] {
+			t.Fatalf("results[%d].Domain = %q, want %q in input order", i, result.Domain, domains[i])
+		}
+
+		failedBatch := i >= 100 && i < 150
+		if failedBatch != (result.Error != "") {
+			t.Errorf("results[%d] = %+v, want an error only for the failed batch", i, result)
+		}
+
+		if failedBatch && !strings.Contains(result.Error, "Too many requests") {
+			t.Errorf("results[%d].Error = %q, want the batch error", i, result.Error)
+		}
+	}
+}
+
+func TestDomainsCheck_MaxDomainsPerCheck(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+
+	service := newTestServiceWithHandler(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		var body strings.Builder
+
+		body.WriteString(`<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="OK" xmlns="http://api.namecheap.com/xml.response"><CommandResponse Type="namecheap.domains.check">`)
+
+		for domain := range strings.SplitSeq(r.URL.Query().Get("DomainList"), ",") {
+			body.WriteString(`<DomainCheckResult Domain="` + domain +
+				`" Available="true" ErrorNo="0" Description="" IsPremiumName="false" />`)
+		}
+
+		body.WriteString(`</CommandResponse></ApiResponse>`)
+
+		_, _ = w.Write([]byte(body.String()))
+	}, func(config *namecheap.Config) {
+		config.MaxDomainsPerCheck = 10
+	})
+
+	domains := make([]string, 0, 25)
+	for i := range 25 {
+		domains = append(domains, "domain"+strconv.Itoa(i)+".com")
+	}
+
+	results, err := service.DomainsCheck(context.Background(), domains)
+	if err != nil {
+		t.Fatalf("DomainsCheck() unexpected error: %v", err)
+	}
+
+	if len(results) != len(domains) {
+		t.Errorf("DomainsCheck() returned %d results, want %d", len(results), len(domains))
+	}
+
+	if got := requests.Load(); got != 3 {
+		t.Errorf("requests = %d, want 3 batches of at most 10", got)
+	}
+}
+
+func TestDomainsCheck_Duplicates(t *testing.T) {
+	t.Parallel()
+
+	manyDuplicates := make([]string, 0, 61)
+	for i := range 60 {
+		manyDuplicates = append(manyDuplicates, []string{"example.com", "Example.COM", "EXAMPLE.com"}[i%3])
+	}
+
+	manyDuplicates = append(manyDuplicates, "other.com")
+
+	tests := []struct {
+		name     string
+		domains  []string
+		wantSent []string
+	}{
+		{
+			name:     "case variants",
+			domains:  []string{"example.com", "EXAMPLE.com", "other.com", "Example.Com"},
+			wantSent: []string{"example.com,other.com"},
+		},
+		{
+			name:     "trailing dot",
+			domains:  []string{"example.com.", "example.com"},
+			wantSent: []string{"example.com."},
+		},
+		{
+			name:     "composed and decomposed unicode",
+			domains:  []string{"caf\u00e9.com", "cafe\u0301.com"},
+			wantSent: []string{"caf\u00e9.com"},
+		},
+		{
+			name:     "duplicates don't push a list over the batch size",
+			domains:  manyDuplicates,
+			wantSent: []string{"example.com,other.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				mu   sync.Mutex
+				sent []string
+			)
+
+			service := newTestServiceWithHandler(t, func(w http.ResponseWriter, r *http.Request) {
+				domainList := r.URL.Query().Get("DomainList")
+
+				mu.Lock()
+				sent = append(sent, domainList)
+				mu.Unlock()
+
+				var body strings.Builder
+
+				body.WriteString(`<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="OK" xmlns="http://api.namecheap.com/xml.response"><CommandResponse Type="namecheap.domains.check">`)
+
+				for domain := range strings.SplitSeq(domainList, ",") {
+					available := strconv.FormatBool(strings.HasPrefix(domain, "example"))
+					body.WriteString(`<DomainCheckResult Domain="` + strings.TrimSuffix(domain, ".") + `" Available="` +
+						available + `" ErrorNo="0" Description="" IsPremiumName="false" />`)
+				}
+
+				body.WriteString(`</CommandResponse></ApiResponse>`)
+
+				_, _ = w.Write([]byte(body.String()))
+			})
+
+			results, err := service.DomainsCheck(context.Background(), tt.domains)
+			if err != nil {
+				t.Fatalf("DomainsCheck() unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(sent, tt.wantSent) {
+				t.Errorf("sent DomainList %q, want %q", sent, tt.wantSent)
+			}
+
+			if len(results) != len(tt.domains) {
+				t.Fatalf("got %d results, want one per requested domain (%d)", len(results), len(tt.domains))
+			}
+
+			for i, result := range results {
+				if namecheap.NormalizeDomain(result.Domain) != namecheap.NormalizeDomain(tt.domains[i]) {
+					t.Errorf("results[%d].Domain = %q, want the result of %q", i, result.Domain, tt.domains[i])
+				}
+
+				if wantAvailable := strings.HasPrefix(result.Domain, "example"); result.Available != wantAvailable {
+					t.Errorf("results[%d].Available = %v, want %v", i, result.Available, wantAvailable)
+				}
 			}
 		})
 	}
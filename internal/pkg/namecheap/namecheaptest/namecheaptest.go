@@ -0,0 +1,158 @@
+// Package namecheaptest provides a configurable namecheap.DomainChecker for the tests of
+// the packages that check or decorate domain checks.
+package namecheaptest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+)
+
+// Checker is a namecheap.DomainChecker answering from canned results. It records the
+// domains it was asked to check and how often it was called, and is safe for concurrent
+// use. The zero value answers every domain available.
+type Checker struct {
+	// CheckerName is returned by Name and Description; "fake" when empty
+	CheckerName string
+	// Results, when set, are returned by every check as is, whatever the domains
+	Results []namecheap.Result
+	// Answers are the results by checked domain; an answer without a Domain gets the
+	// checked one
+	Answers map[string]namecheap.Result
+	// Answer answers the domains missing from Answers; nil answers them with Available
+	Answer func(domain string) namecheap.Result
+	// Err fails every check
+	Err error
+	// PartialErr is returned with the results as a namecheap.PartialError listing the
+	// domains whose result has an Error
+	PartialErr error
+
+	mu      sync.Mutex
+	calls   int
+	checked []string
+}
+
+// DomainsCheck records the check and answers it from the configured results.
+func (c *Checker) DomainsCheck(_ context.Context, domains []string) ([]namecheap.Result, error) {
+	c.mu.Lock()
+	c.calls++
+	c.checked = append(c.checked, domains...)
+	c.mu.Unlock()
+
+	if c.Err != nil {
+		return nil, c.Err
+	}
+
+	results := append([]namecheap.Result(nil), c.Results...)
+	if c.Results == nil {
+		results = make([]namecheap.Result, 0, len(domains))
+		for _, domain := range domains {
+			results = append(results, c.answer(domain))
+		}
+	}
+
+	if c.PartialErr == nil {
+		return results, nil
+	}
+
+	var failed []string
+
+	for _, result := range results {
+		if result.Error != "" {
+			failed = append(failed, result.Domain)
+		}
+	}
+
+	return results, namecheap.NewPartialError(c.PartialErr, failed)
+}
+
+func (c *Checker) answer(domain string) namecheap.Result {
+	result, ok := c.Answers[domain]
+
+	switch {
+	case ok:
+		if result.Domain == "" {
+			result.Domain = domain
+		}
+	case c.Answer != nil:
+		result = c.Answer(domain)
+	default:
+		result = Available(domain)
+	}
+
+	return result
+}
+
+// Name returns CheckerName, or "fake" when it is empty.
+func (c *Checker) Name() string {
+	if c.CheckerName == "" {
+		return "fake"
+	}
+
+	return c.CheckerName
+}
+
+// Description returns the same as Name.
+func (c *Checker) Description() string {
+	return c.Name()
+}
+
+// Calls returns how many checks the checker answered or failed.
+func (c *Checker) Calls() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.calls
+}
+
+// Checked returns the domains of every check so far, in the order they were asked for.
+func (c *Checker) Checked() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]string(nil), c.checked...)
+}
+
+// Available returns an available result for domain.
+func Available(domain string) namecheap.Result {
+	return Result(domain, true)
+}
+
+// Taken returns a taken result for domain.
+func Taken(domain string) namecheap.Result {
+	return Result(domain, false)
+}
+
+// Result returns a result for domain with Available and Availability set and every other
+// field empty.
+func Result(domain string, available bool) namecheap.Result {
+	availability := namecheap.AvailabilityTaken
+	if available {
+		availability = namecheap.AvailabilityAvailable
+	}
+
+	return namecheap.Result{
+		Domain:                    domain,
+		Available:                 available,
+		Availability:              availability,
+		Status:                    "",
+		IsPremiumName:             false,
+		PremiumRegistrationPrice:  0,
+		PremiumRenewalPrice:       0,
+		IcannFee:                  0,
+		EapFee:                    0,
+		StandardRegistrationPrice: 0,
+		Currency:                  "",
+		Error:                     "",
+		ErrorCode:                 0,
+		RegisterURL:               "",
+		Blocked:                   false,
+		BlockReason:               "",
+		CorrelationID:             "",
+		Input:                     "",
+		ReferencePrice:            0,
+		ReferencePriceDelta:       0,
+		Raw:                       nil,
+	}
+}
@@ -0,0 +1,89 @@
+package namecheap
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// resultJSON is Result without its methods, so MarshalJSON can use the default encoding.
+type resultJSON Result
+
+// MarshalJSON encodes the result, leaving out the legacy "available" flag when the
+// result came through an OmitAvailableChecker.
+func (r Result) MarshalJSON() ([]byte, error) {
+	if !r.omitAvailable {
+		return json.Marshal(resultJSON(r))
+	}
+
+	// The outer Available shadows the embedded one and, being nil, is omitted.
+	return json.Marshal(struct {
+		resultJSON
+
+		Available *bool `json:"available,omitempty"`
+	}{
+		resultJSON: resultJSON(r),
+		Available:  nil,
+	})
+}
+
+// UnmarshalJSON decodes a result. A result encoded without the legacy "available" flag
+// gets it back from its Availability, so decoding doesn't turn it into a taken domain.
+func (r *Result) UnmarshalJSON(data []byte) error {
+	// The outer Available shadows the embedded one and stays nil when the flag is absent.
+	var decoded struct {
+		resultJSON
+
+		Available *bool `json:"available"`
+	}
+
+	err := json.Unmarshal(data, &decoded)
+	if err != nil {
+		return err
+	}
+
+	*r = Result(decoded.resultJSON)
+
+	r.Available = r.Availability == AvailabilityAvailable
+	if decoded.Available != nil {
+		r.Available = *decoded.Available
+	}
+
+	return nil
+}
+
+// OmitAvailableChecker is a DomainChecker whose results serialize only the tri-state
+// Availability, without the ambiguous legacy "available" flag. The Available field is
+// still set for callers in this process.
+type OmitAvailableChecker struct {
+	checker DomainChecker
+}
+
+// NewOmitAvailableChecker wraps checker so its results omit "available" from their JSON.
+func NewOmitAvailableChecker(checker DomainChecker) *OmitAvailableChecker {
+	return &OmitAvailableChecker{checker: checker}
+}
+
+// Name returns the name of the wrapped checker.
+func (o *OmitAvailableChecker) Name() string {
+	return o.checker.Name()
+}
+
+// Description returns the description of the wrapped checker.
+func (o *OmitAvailableChecker) Description() string {
+	return o.checker.Description()
+}
+
+// DomainsCheck checks domains with the wrapped checker and marks the results to omit
+// "available" when encoded.
+func (o *OmitAvailableChecker) DomainsCheck(ctx context.Context, domains []string) ([]Result, error) {
+	results, err := o.checker.DomainsCheck(ctx, domains)
+	if err != nil && !IsPartial(err) {
+		return nil, err
+	}
+
+	for i := range results {
+		results[i].omitAvailable = true
+	}
+
+	return results, err
+}
@@ -0,0 +1,68 @@
+package namecheap_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap/namecheaptest"
+)
+
+func TestOmitAvailableChecker(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		omit          bool
+		wantAvailable bool
+	}{
+		{name: "legacy bool kept by default", omit: false, wantAvailable: true},
+		{name: "legacy bool omitted when requested", omit: true, wantAvailable: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var checker namecheap.DomainChecker = &namecheaptest.Checker{Results: []namecheap.Result{ //nolint:exhaustruct
+				checkResult("free.com", true, ""),
+				checkResult("taken.com", false, ""),
+			}}
+			if tt.omit {
+				checker = namecheap.NewOmitAvailableChecker(checker)
+			}
+
+			results, err := checker.DomainsCheck(context.Background(), []string{"free.com", "taken.com"})
+			if err != nil {
+				t.Fatalf("DomainsCheck() unexpected error: %v", err)
+			}
+
+			if !results[0].Available {
+				t.Error("Available = false, want the struct field kept for internal use")
+			}
+
+			encoded, err := json.Marshal(results)
+			if err != nil {
+				t.Fatalf("Marshal() unexpected error: %v", err)
+			}
+
+			var decoded []map[string]any
+
+			err = json.Unmarshal(encoded, &decoded)
+			if err != nil {
+				t.Fatalf("Unmarshal() unexpected error: %v", err)
+			}
+
+			for _, result := range decoded {
+				if _, ok := result["available"]; ok != tt.wantAvailable {
+					t.Errorf("%v: \"available\" present = %v, want %v", result["domain"], ok, tt.wantAvailable)
+				}
+
+				if _, ok := result["availability"]; !ok {
+					t.Errorf("%v: \"availability\" missing", result["domain"])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package namecheap
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// PartialError is returned by DomainsCheck together with the results when only some of a
+// check's batches failed. The results are complete: the failed domains are in them too,
+// with their batch error in Result.Error.
+type PartialError struct {
+	// Err is the error of the first failed batch
+	Err error
+
+	failedDomains []string
+}
+
+// NewPartialError reports that the check of failedDomains failed with err while the
+// other domains were checked.
+func NewPartialError(err error, failedDomains []string) *PartialError {
+	return &PartialError{Err: err, failedDomains: failedDomains}
+}
+
+// Error reports how many domains couldn't be checked and why.
+func (e *PartialError) Error() string {
+	return fmt.Sprintf("%d domains couldn't be checked: %v", len(e.failedDomains), e.Err)
+}
+
+// Unwrap returns the error of the first failed batch.
+func (e *PartialError) Unwrap() error {
+	return e.Err
+}
+
+// FailedDomains returns the domains of the failed batches, in input order.
+func (e *PartialError) FailedDomains() []string {
+	return slices.Clone(e.failedDomains)
+}
+
+// IsPartial reports whether err is a *PartialError, in which case the results returned
+// with it are usable.
+func IsPartial(err error) bool {
+	var partial *PartialError
+
+	return errors.As(err, &partial)
+}
+
+// CheckError lists the domains of a partially failed check that failed with one error.
+type CheckError struct {
+	// Error is the error message shared by the domains
+	Error string `json:"error" jsonschema:"Why the domains couldn't be checked"`
+	// Domains are the domains that failed with Error
+	Domains []string `json:"domains" jsonschema:"The domains that couldn't be checked"`
+}
+
+// checkErrors groups the failed domains of partial by their Result.Error, in order of
+// first appearance.
+func checkErrors(partial *PartialError, results []Result) []CheckError {
+	failed := make(map[string]bool, len(partial.failedDomains))
+	for _, domain := range partial.failedDomains {
+		failed[domain] = true
+	}
+
+	var checkErrs []CheckError
+
+	for _, result := range results {
+		if !failed[result.Domain] {
+			continue
+		}
+
+		message := result.Error
+		if message == "" {
+			message = partial.Err.Error()
+		}
+
+		i := slices.IndexFunc(checkErrs, func(e CheckError) bool { return e.Error == message })
+		if i < 0 {
+			checkErrs = append(checkErrs, CheckError{Error: message, Domains: nil})
+			i = len(checkErrs) - 1
+		}
+
+		checkErrs[i].Domains = append(checkErrs[i].Domains, result.Domain)
+	}
+
+	return checkErrs
+}
@@ -0,0 +1,287 @@
+package namecheap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/tool"
+	"go.uber.org/zap"
+)
+
+const (
+	// commandGetPricing is the API command that returns the account's price list.
+	commandGetPricing = "namecheap.users.getPricing"
+	// pricingCacheTTL is how long a fetched price list is reused. Prices change rarely.
+	pricingCacheTTL = 24 * time.Hour
+
+	// Product categories of the getPricing response.
+	categoryRegister = "register"
+	categoryRenew    = "renew"
+	categoryTransfer = "transfer"
+)
+
+var (
+	// ErrMissingTLDs is returned when no TLDs are provided for a pricing lookup.
+	ErrMissingTLDs = errors.New("missing TLDs to price")
+	// errTLDNotPriced is reported per TLD when the price list has no entry for it.
+	errTLDNotPriced = errors.New("TLD not found in Namecheap price list")
+)
+
+// UserGetPricingResult represents the getPricing section of the API response.
+type UserGetPricingResult struct {
+	ProductTypes []PricingProductType `xml:"ProductType"`
+}
+
+// PricingProductType groups pricing categories for a product type (e.g. domains).
+type PricingProductType struct {
+	Name       string                   `xml:"Name,attr"`
+	Categories []PricingProductCategory `xml:"ProductCategory"`
+}
+
+// PricingProductCategory groups product prices for an action (register, renew, transfer).
+type PricingProductCategory struct {
+	Name     string           `xml:"Name,attr"`
+	Products []PricingProduct `xml:"Product"`
+}
+
+// PricingProduct holds the prices of a single product, i.e. a TLD.
+type PricingProduct struct {
+	Name   string         `xml:"Name,attr"`
+	Prices []PricingPrice `xml:"Price"`
+}
+
+// PricingPrice is the price of a product for a given duration.
+type PricingPrice struct {
+	Duration     string `xml:"Duration,attr"`
+	DurationType string `xml:"DurationType,attr"`
+	Price        string `xml:"Price,attr"`
+	YourPrice    string `xml:"YourPrice,attr"`
+	Currency     string `xml:"Currency,attr"`
+}
+
+// TLDPrice contains the one-year prices of a TLD.
+type TLDPrice struct {
+	// TLD is the top-level domain, without the leading dot
+	TLD string `json:"tld" jsonschema:"The top-level domain, e.g. com"`
+	// Currency is the currency of the prices
+	Currency string `json:"currency,omitempty" jsonschema:"Currency of the prices, e.g. USD"`
+	// Register is the one-year registration price
+	Register float64 `json:"register,omitempty" jsonschema:"One-year registration price"`
+	// Renew is the one-year renewal price
+	Renew float64 `json:"renew,omitempty" jsonschema:"One-year renewal price"`
+	// Transfer is the transfer price
+	Transfer float64 `json:"transfer,omitempty" jsonschema:"Transfer price"`
+	// Error contains any error message if the TLD couldn't be priced
+	Error string `json:"error,omitempty" jsonschema:"Error message if the TLD could not be priced"`
+}
+
+// pricingCache holds the most recently fetched price list keyed by TLD.
+type pricingCache struct {
+	mu        sync.Mutex
+	prices    map[string]TLDPrice
+	fetchedAt time.Time
+}
+
+// TLDPricing returns register, renew and transfer prices for the given TLDs. The
+// full price list is fetched once and cached, so no availability check is made
+// and repeated lookups don't hit the API.
+func (n *Service) TLDPricing(ctx context.Context, tlds []string) ([]TLDPrice, error) {
+	if len(tlds) == 0 {
+		return nil, ErrMissingTLDs
+	}
+
+	table, err := n.pricingTable(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	prices := make([]TLDPrice, 0, len(tlds))
+
+	for _, tld := range tlds {
+		key := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tld), "."))
+
+		price, ok := table[key]
+		if !ok {
+			price = TLDPrice{TLD: key, Currency: "", Register: 0, Renew: 0, Transfer: 0, Error: errTLDNotPriced.Error()}
+		}
+
+		prices = append(prices, price)
+	}
+
+	return prices, nil
+}
+
+// pricingTable returns the cached price list, refreshing it when it has expired.
+func (n *Service) pricingTable(ctx context.Context) (map[string]TLDPrice, error) {
+	n.pricing.mu.Lock()
+	defer n.pricing.mu.Unlock()
+
+	if n.pricing.prices != nil && time.Since(n.pricing.fetchedAt) < pricingCacheTTL {
+		return n.pricing.prices, nil
+	}
+
+	params := url.Values{}
+	params.Add("ProductType", "DOMAIN")
+
+	apiResp, err := n.call(ctx, commandGetPricing, params)
+	if err != nil {
+		return nil, err
+	}
+
+	n.pricing.prices = parsePricing(apiResp.CommandResponse.UserGetPricingResult, n.config.NumberFormat)
+	n.pricing.fetchedAt = time.Now()
+
+	n.logger.Debug("Fetched Namecheap price list", zap.Int("tld_count", len(n.pricing.prices)))
+
+	return n.pricing.prices, nil
+}
+
+// parsePricing flattens the getPricing response into one-year prices per TLD, reading
+// amounts in the given number format.
+func parsePricing(result UserGetPricingResult, format NumberFormat) map[string]TLDPrice {
+	prices := make(map[string]TLDPrice)
+
+	for _, productType := range result.ProductTypes {
+		for _, category := range productType.Categories {
+			for _, product := range category.Products {
+				tld := strings.ToLower(product.Name)
+
+				price, ok := oneYearPrice(product.Prices)
+				if !ok {
+					continue
+				}
+
+				entry := prices[tld]
+				entry.TLD = tld
+				entry.Currency = price.Currency
+
+				amount := price.YourPrice
+				if amount == "" {
+					amount = price.Price
+				}
+
+				value, err := format.Parse(amount)
+				if err != nil {
+					continue
+				}
+
+				switch strings.ToLower(category.Name) {
+				case categoryRegister:
+					entry.Register = value
+				case categoryRenew:
+					entry.Renew = value
+				case categoryTransfer:
+					entry.Transfer = value
+				}
+
+				prices[tld] = entry
+			}
+		}
+	}
+
+	return prices
+}
+
+// oneYearPrice picks the one-year price from a product's duration list.
+func oneYearPrice(prices []PricingPrice) (PricingPrice, bool) {
+	for _, price := range prices {
+		if price.Duration == "1" && strings.EqualFold(price.DurationType, "YEAR") {
+			return price, true
+		}
+	}
+
+	return PricingPrice{}, false
+}
+
+// PricingParamsIn represents the input parameters for a TLD pricing lookup.
+type PricingParamsIn struct {
+	// TLDs is the list of TLDs to price
+	TLDs []string `json:"tlds" jsonschema:"The TLDs to price, e.g. com,io"`
+}
+
+// PricingParamsOut represents the prices of the requested TLDs.
+type PricingParamsOut struct {
+	// Prices contains one entry per requested TLD, in request order
+	Prices []TLDPrice `json:"prices" jsonschema:"One-year prices per requested TLD"`
+}
+
+// PricingService exposes TLD pricing as a standalone MCP tool.
+type PricingService struct {
+	service *Service
+}
+
+// NewPricingService creates a tool service that prices TLDs with service.
+func NewPricingService(service *Service) *PricingService {
+	return &PricingService{
+		service: service,
+	}
+}
+
+// Name returns the name of the pricing service.
+func (p *PricingService) Name() string {
+	return "get_tld_pricing_namecheap"
+}
+
+// Description returns a description of the pricing service.
+func (p *PricingService) Description() string {
+	return "Get Namecheap one-year register, renew and transfer prices for TLDs, without checking availability"
+}
+
+// Execute returns the prices of the requested TLDs.
+func (p *PricingService) Execute(ctx context.Context, in PricingParamsIn) (PricingParamsOut, error) {
+	if len(in.TLDs) == 0 {
+		return PricingParamsOut{}, fmt.Errorf("%w: %w", tool.ErrInvalidInput, ErrMissingTLDs)
+	}
+
+	prices, err := p.service.TLDPricing(ctx, in.TLDs)
+	if err != nil {
+		return PricingParamsOut{}, apiFailed(err)
+	}
+
+	return PricingParamsOut{Prices: prices}, nil
+}
+
+// addStandardPricing sets StandardRegistrationPrice on the available non-premium results
+// from the cached price list. The list is only fetched when such a result exists, and a
+// failed fetch is logged and leaves the results without standard prices.
+func (n *Service) addStandardPricing(ctx context.Context, results []Result) {
+	if !slices.ContainsFunc(results, isStandardAvailable) {
+		return
+	}
+
+	table, err := n.pricingTable(ctx)
+	if err != nil {
+		n.logger.Warn("Failed to fetch Namecheap price list for standard pricing", zap.Error(err))
+
+		return
+	}
+
+	for i := range results {
+		if !isStandardAvailable(results[i]) {
+			continue
+		}
+
+		_, tld, _ := strings.Cut(NormalizeDomain(results[i].Domain), ".")
+
+		price, ok := table[tld]
+		if !ok || price.Register <= 0 {
+			continue
+		}
+
+		results[i].StandardRegistrationPrice = price.Register
+		if results[i].Currency == "" {
+			results[i].Currency = price.Currency
+		}
+	}
+}
+
+// isStandardAvailable reports whether result is an available domain sold at the standard price.
+func isStandardAvailable(result Result) bool {
+	return result.Available && !result.IsPremiumName && result.Error == ""
+}
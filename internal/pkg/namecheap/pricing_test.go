@@ -0,0 +1,136 @@
+package namecheap_test
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+)
+
+const pricingResponse = `<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="OK" xmlns="http://api.namecheap.com/xml.response">
+  <Errors />
+  <CommandResponse Type="namecheap.users.getPricing">
+    <UserGetPricingResult>
+      <ProductType Name="domains">
+        <ProductCategory Name="register">
+          <Product Name="com">
+            <Price Duration="1" DurationType="YEAR" Price="10.28" YourPrice="9.58" Currency="USD" />
+            <Price Duration="2" DurationType="YEAR" Price="20.56" YourPrice="19.16" Currency="USD" />
+          </Product>
+          <Product Name="io">
+            <Price Duration="1" DurationType="YEAR" Price="32.88" YourPrice="" Currency="USD" />
+          </Product>
+        </ProductCategory>
+        <ProductCategory Name="renew">
+          <Product Name="com">
+            <Price Duration="1" DurationType="YEAR" Price="14.58" YourPrice="14.58" Currency="USD" />
+          </Product>
+        </ProductCategory>
+        <ProductCategory Name="transfer">
+          <Product Name="com">
+            <Price Duration="1" DurationType="YEAR" Price="9.98" YourPrice="9.98" Currency="USD" />
+          </Product>
+        </ProductCategory>
+      </ProductType>
+    </UserGetPricingResult>
+  </CommandResponse>
+</ApiResponse>`
+
+func TestPricingService_Standalone(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	service := newTestServiceWithHandler(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+
+		if got := r.URL.Query().Get("Command"); got != "namecheap.users.getPricing" {
+			t.Errorf("Command = %q, want only pricing calls", got)
+		}
+
+		_, _ = w.Write([]byte(pricingResponse))
+	})
+
+	pricing := namecheap.NewPricingService(service)
+
+	want := []namecheap.TLDPrice{
+		{TLD: "com", Currency: "USD", Register: 9.58, Renew: 14.58, Transfer: 9.98, Error: ""},
+		{TLD: "io", Currency: "USD", Register: 32.88, Renew: 0, Transfer: 0, Error: ""},
+		{TLD: "zz", Currency: "", Register: 0, Renew: 0, Transfer: 0, Error: "TLD not found in Namecheap price list"},
+	}
+
+	for range 2 {
+		out, err := pricing.Execute(context.Background(), namecheap.PricingParamsIn{TLDs: []string{"com", ".IO", "zz"}})
+		if err != nil {
+			t.Fatalf("Execute() unexpected error: %v", err)
+		}
+
+		if !reflect.DeepEqual(out.Prices, want) {
+			t.Errorf("Execute() prices = %+v, want %+v", out.Prices, want)
+		}
+	}
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("API calls = %d, want 1 (cached)", got)
+	}
+}
+
+const standardCheckResponse = `<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="OK" xmlns="http://api.namecheap.com/xml.response">
+  <CommandResponse Type="namecheap.domains.check">
+    <DomainCheckResult Domain="fresh.com" Available="true" ErrorNo="0" Description="" IsPremiumName="false" />
+    <DomainCheckResult Domain="fancy.com" Available="true" ErrorNo="0" Description="" IsPremiumName="true"
+      PremiumRegistrationPrice="950.00" PremiumRenewalPrice="950.00" />
+    <DomainCheckResult Domain="taken.io" Available="false" ErrorNo="0" Description="" IsPremiumName="false" />
+    <DomainCheckResult Domain="fresh.io" Available="true" ErrorNo="0" Description="" IsPremiumName="false" />
+  </CommandResponse>
+</ApiResponse>`
+
+func TestDomainsCheck_StandardPricing(t *testing.T) {
+	t.Parallel()
+
+	var pricingCalls atomic.Int32
+
+	service := newTestServiceWithHandler(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("Command") {
+		case "namecheap.users.getPricing":
+			pricingCalls.Add(1)
+
+			_, _ = w.Write([]byte(pricingResponse))
+		default:
+			_, _ = w.Write([]byte(standardCheckResponse))
+		}
+	}, func(config *namecheap.Config) {
+		config.StandardPricing = true
+	})
+
+	// Only available non-premium domains get the standard price.
+	want := map[string]float64{"fresh.com": 9.58, "fancy.com": 0, "taken.io": 0, "fresh.io": 32.88}
+
+	for range 2 {
+		results, err := service.DomainsCheck(context.Background(),
+			[]string{"fresh.com", "fancy.com", "taken.io", "fresh.io"})
+		if err != nil {
+			t.Fatalf("DomainsCheck() unexpected error: %v", err)
+		}
+
+		for _, result := range results {
+			if result.StandardRegistrationPrice != want[result.Domain] {
+				t.Errorf("%s StandardRegistrationPrice = %v, want %v",
+					result.Domain, result.StandardRegistrationPrice, want[result.Domain])
+			}
+		}
+
+		if results[0].Currency != "USD" {
+			t.Errorf("fresh.com Currency = %q, want USD", results[0].Currency)
+		}
+	}
+
+	if got := pricingCalls.Load(); got != 1 {
+		t.Errorf("pricing API calls = %d, want 1 (cached)", got)
+	}
+}
@@ -0,0 +1,53 @@
+package namecheap
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// redactedValue replaces secret query parameter values in logged URLs.
+const redactedValue = "***"
+
+// sensitiveParams are the query parameters of an API request URL that carry credentials
+// or identify the account.
+//
+//nolint:gochecknoglobals
+var sensitiveParams = []string{"ApiKey", "ApiUser", "ClientIp"}
+
+// redactURL returns rawURL with the values of sensitiveParams replaced by "***", so the
+// URL can be logged. A URL that doesn't parse is replaced entirely, since its credentials
+// can't be located.
+func redactURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return redactedValue
+	}
+
+	pairs := strings.Split(parsed.RawQuery, "&")
+
+	for i, pair := range pairs {
+		key, _, _ := strings.Cut(pair, "=")
+
+		name, err := url.QueryUnescape(key)
+		if err != nil || slices.Contains(sensitiveParams, name) {
+			pairs[i] = key + "=" + redactedValue
+		}
+	}
+
+	parsed.RawQuery = strings.Join(pairs, "&")
+
+	return parsed.String()
+}
+
+// urlField is the only way an API request URL is logged, so credentials never reach the logs.
+func urlField(rawURL string) zap.Field {
+	return zap.String("url", redactURL(rawURL))
+}
+
+// logsBodies reports whether request URLs and response bodies are logged.
+func (n *Service) logsBodies() bool {
+	return n.logger.Core().Enabled(zap.DebugLevel)
+}
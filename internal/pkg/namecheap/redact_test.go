@@ -0,0 +1,171 @@
+package namecheap_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestDomainsCheck_DebugLogging(t *testing.T) {
+	t.Parallel()
+
+	const response = `<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="OK" xmlns="http://api.namecheap.com/xml.response">
+  <CommandResponse Type="namecheap.domains.check">
+    <DomainCheckResult Domain="example.com" Available="true" ErrorNo="0" Description="" IsPremiumName="false" />
+  </CommandResponse>
+</ApiResponse>`
+
+	tests := []struct {
+		name     string
+		level    zapcore.Level
+		wantBody bool
+	}{
+		{name: "debug level logs the response body", level: zap.DebugLevel, wantBody: true},
+		{name: "silent above debug level", level: zap.InfoLevel, wantBody: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(response))
+			}))
+			t.Cleanup(server.Close)
+
+			core, logs := observer.New(tt.level)
+
+			service, err := namecheap.NewService(zap.New(core), namecheap.Config{ //nolint:exhaustruct
+				APIUser:  "secret-user",
+				APIKey:   "secret-key",
+				UserName: "username",
+				ClientIP: "127.0.0.1",
+				Endpoint: server.URL,
+			})
+			if err != nil {
+				t.Fatalf("Failed to create service: %v", err)
+			}
+
+			_, err = service.DomainsCheck(context.Background(), []string{"example.com"})
+			if err != nil {
+				t.Fatalf("DomainsCheck() unexpected error: %v", err)
+			}
+
+			var urls, bodies []string
+
+			for _, entry := range logs.All() {
+				fields := entry.ContextMap()
+				if url, ok := fields["url"].(string); ok {
+					urls = append(urls, url)
+				}
+
+				if body, ok := fields["body"].(string); ok {
+					bodies = append(bodies, body)
+				}
+			}
+
+			for _, url := range urls {
+				if strings.Contains(url, "secret-") || !strings.Contains(url, "ApiKey=***") ||
+					!strings.Contains(url, "ApiUser=***") {
+					t.Errorf("logged url %q, want ApiKey and ApiUser redacted", url)
+				}
+			}
+
+			if tt.wantBody && (len(urls) != 1 || len(bodies) != 1 || bodies[0] != response) {
+				t.Errorf("logged urls %v and bodies %v, want the request URL and response body", urls, bodies)
+			}
+
+			if !tt.wantBody && (len(urls) != 0 || len(bodies) != 0) {
+				t.Errorf("logged urls %v and bodies %v, want nothing", urls, bodies)
+			}
+		})
+	}
+}
+
+func TestDomainsCheck_NeverLogsCredentials(t *testing.T) {
+	t.Parallel()
+
+	const apiKey = "k3y-0f-the-account"
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		wantErr bool
+	}{
+		{
+			name: "successful call",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="OK" xmlns="http://api.namecheap.com/xml.response">
+  <CommandResponse Type="namecheap.domains.check">
+    <DomainCheckResult Domain="example.com" Available="true" ErrorNo="0" Description="" IsPremiumName="false" />
+  </CommandResponse>
+</ApiResponse>`))
+			},
+			wantErr: false,
+		},
+		{
+			name: "failed connection",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				conn, _, err := http.NewResponseController(w).Hijack()
+				if err == nil {
+					_ = conn.Close()
+				}
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(tt.handler)
+			t.Cleanup(server.Close)
+
+			core, logs := observer.New(zap.DebugLevel)
+			logger := zap.New(core)
+
+			service, err := namecheap.NewService(logger, namecheap.Config{ //nolint:exhaustruct
+				APIUser:  "user",
+				APIKey:   apiKey,
+				UserName: "username",
+				ClientIP: "127.0.0.1",
+				Endpoint: server.URL,
+			})
+			if err != nil {
+				t.Fatalf("Failed to create service: %v", err)
+			}
+
+			_, err = service.DomainsCheck(context.Background(), []string{"example.com"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DomainsCheck() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			// The error ends up in tool results and the tool layer's logs.
+			if err != nil {
+				logger.Error("check failed", zap.Error(err))
+			}
+
+			if logs.Len() == 0 {
+				t.Fatal("nothing was logged")
+			}
+
+			for _, entry := range logs.All() {
+				logged := fmt.Sprint(entry.Message, entry.ContextMap())
+				if strings.Contains(logged, apiKey) {
+					t.Errorf("log entry %q contains the API key", logged)
+				}
+			}
+		})
+	}
+}
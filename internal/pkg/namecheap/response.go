@@ -0,0 +1,54 @@
+package namecheap
+
+import (
+	"bytes"
+	"mime"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// sniffLength is how much of a response is looked at before decoding it, as
+	// http.DetectContentType does.
+	sniffLength = 512
+	// snippetLength bounds the body quoted in errors about responses that aren't XML.
+	snippetLength = 200
+)
+
+// looksLikeXML reports whether a response with the given Content-Type header, starting
+// with head, can be an XML API response. Proxies and outages answer with HTML, JSON or
+// empty pages, which the XML decoder only reports as a cryptic EOF or syntax error.
+func looksLikeXML(contentType string, head []byte) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err == nil && (mediaType == "text/html" || strings.HasSuffix(mediaType, "json")) {
+		return false
+	}
+
+	trimmed := bytes.TrimLeft(bytes.TrimPrefix(head, []byte("\xef\xbb\xbf")), " \t\r\n")
+	if len(trimmed) == 0 || trimmed[0] != '<' {
+		return false
+	}
+
+	return !strings.HasPrefix(http.DetectContentType(trimmed), "text/html")
+}
+
+// bodySnippet returns the start of body for error messages, on one line and at most
+// snippetLength bytes long.
+func bodySnippet(body []byte) string {
+	snippet := strings.Join(strings.Fields(string(body)), " ")
+	if snippet == "" {
+		return "empty body"
+	}
+
+	if len(snippet) > snippetLength {
+		cut := snippetLength
+		for cut > 0 && !utf8.RuneStart(snippet[cut]) {
+			cut--
+		}
+
+		snippet = snippet[:cut] + "..."
+	}
+
+	return snippet
+}
@@ -0,0 +1,79 @@
+package namecheap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/tool"
+)
+
+var (
+	// ErrMissingDomain is returned when the single domain check is given no domain.
+	ErrMissingDomain = errors.New("missing domain to check")
+	// ErrNoResult is returned when the checker answered without a result for the domain.
+	ErrNoResult = errors.New("no result for the domain")
+)
+
+// SingleParamsIn represents the input parameters for checking one domain.
+type SingleParamsIn struct {
+	// Domain is the domain name to check
+	Domain string `json:"domain" jsonschema:"The domain to check, e.g. example.com"`
+}
+
+// DomainCount returns the number of domains to check, for logging.
+func (p SingleParamsIn) DomainCount() int {
+	return 1
+}
+
+// SingleCheckService exposes a DomainChecker as an MCP tool checking one domain, with a
+// simpler schema than CheckService for the common single-domain question.
+type SingleCheckService struct {
+	checker DomainChecker
+}
+
+// NewSingleCheckService creates a tool service that checks one domain with checker.
+func NewSingleCheckService(checker DomainChecker) *SingleCheckService {
+	return &SingleCheckService{
+		checker: checker,
+	}
+}
+
+// Name returns the name of the single domain check service.
+func (s *SingleCheckService) Name() string {
+	return "check_domain"
+}
+
+// Description returns a description of the single domain check service.
+func (s *SingleCheckService) Description() string {
+	return "Check whether a single domain is available to register, with its premium pricing; " +
+		"use check_availability_namecheap for lists of domains"
+}
+
+// Execute checks in.Domain, normalized like CheckService does, and returns its result
+// with the requested string as Input.
+func (s *SingleCheckService) Execute(ctx context.Context, in SingleParamsIn) (Result, error) {
+	domain := NormalizeDomain(in.Domain)
+	if domain == "" {
+		return Result{}, fmt.Errorf("%w: %w; supply the domain to check, e.g. \"example.com\"",
+			tool.ErrInvalidInput, ErrMissingDomain)
+	}
+
+	results, err := s.checker.DomainsCheck(ctx, []string{domain})
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return Result{}, err
+	}
+
+	if err != nil {
+		return Result{}, apiFailed(err)
+	}
+
+	if len(results) == 0 {
+		return Result{}, fmt.Errorf("%w: %w %q", ErrNamecheapAPIFailed, ErrNoResult, domain)
+	}
+
+	result := results[0]
+	result.Input = in.Domain
+
+	return result, nil
+}
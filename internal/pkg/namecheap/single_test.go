@@ -0,0 +1,68 @@
+package namecheap_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap/namecheaptest"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/tool"
+)
+
+func TestSingleCheckService_Execute(t *testing.T) {
+	t.Parallel()
+
+	checker := &namecheaptest.Checker{} //nolint:exhaustruct
+
+	result, err := namecheap.NewSingleCheckService(checker).Execute(context.Background(),
+		namecheap.SingleParamsIn{Domain: "https://Example.com/pricing"})
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+
+	if want := []string{"example.com"}; !reflect.DeepEqual(checker.Checked(), want) {
+		t.Errorf("checked = %v, want %v", checker.Checked(), want)
+	}
+
+	if result.Domain != "example.com" || result.Input != "https://Example.com/pricing" {
+		t.Errorf("result = %+v, want example.com with the requested string as Input", result)
+	}
+}
+
+func TestSingleCheckService_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		domain  string
+		checker namecheap.DomainChecker
+		wantErr error
+	}{
+		{
+			name:    "missing domain",
+			domain:  "  ",
+			checker: &namecheaptest.Checker{}, //nolint:exhaustruct
+			wantErr: tool.ErrInvalidInput,
+		},
+		{
+			name:    "no result",
+			domain:  "example.com",
+			checker: &namecheaptest.Checker{Results: []namecheap.Result{}}, //nolint:exhaustruct
+			wantErr: namecheap.ErrNoResult,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := namecheap.NewSingleCheckService(tt.checker).Execute(context.Background(),
+				namecheap.SingleParamsIn{Domain: tt.domain})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
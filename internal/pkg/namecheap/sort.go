@@ -0,0 +1,148 @@
+package namecheap
+
+import (
+	"cmp"
+	"errors"
+	"math"
+	"slices"
+	"strings"
+)
+
+const (
+	// sortByInput keeps the requested order.
+	sortByInput = "input"
+	// sortByName orders results alphabetically by domain.
+	sortByName = "name"
+	// sortByAvailable lists available domains first, then taken, then unknown.
+	sortByAvailable = "available"
+	// sortByPrice lists the cheapest registration price first.
+	sortByPrice = "price"
+)
+
+var (
+	// ErrUnknownSortBy is returned when SortBy names an unsupported order.
+	ErrUnknownSortBy = errors.New("unknown sortBy value")
+	// ErrMissingResult is reported per domain when a checker returned no result for it.
+	ErrMissingResult = errors.New("checker returned no result")
+)
+
+// sortByValues are the accepted SortBy values, the empty default meaning sortByInput.
+//
+//nolint:gochecknoglobals
+var sortByValues = []string{"", sortByInput, sortByName, sortByAvailable, sortByPrice}
+
+// inInputOrder reorders results to follow domains, matching them case-insensitively,
+// since a backend may answer in its own order. Results matching no requested domain
+// keep their relative order after the others.
+func inInputOrder(results []Result, domains []string) []Result {
+	// positions maps each requested domain to its indexes, in order, to handle duplicates.
+	positions := make(map[string][]int, len(domains))
+	for i, domain := range domains {
+		key := strings.ToLower(domain)
+		positions[key] = append(positions[key], i)
+	}
+
+	placed := make([]*Result, len(domains))
+	extra := make([]Result, 0)
+
+	for i := range results {
+		key := strings.ToLower(results[i].Domain)
+
+		indexes := positions[key]
+		if len(indexes) == 0 {
+			extra = append(extra, results[i])
+
+			continue
+		}
+
+		placed[indexes[0]] = &results[i]
+		positions[key] = indexes[1:]
+	}
+
+	ordered := make([]Result, 0, len(results))
+
+	for _, result := range placed {
+		if result != nil {
+			ordered = append(ordered, *result)
+		}
+	}
+
+	return append(ordered, extra...)
+}
+
+// MatchResults returns one result per domain, in the order of domains, matching results
+// by normalized domain since a backend may answer in its own order or leave domains out.
+// Duplicate domains take matching results in turn. A domain without a result gets an
+// unknown result carrying ErrMissingResult; results matching no domain are dropped.
+func MatchResults(domains []string, results []Result) []Result {
+	// byDomain maps each normalized domain to its results, in order, to handle duplicates.
+	byDomain := make(map[string][]Result, len(results))
+	for _, result := range results {
+		key := NormalizeDomain(result.Domain)
+		byDomain[key] = append(byDomain[key], result)
+	}
+
+	matched := make([]Result, 0, len(domains))
+
+	for _, domain := range domains {
+		key := NormalizeDomain(domain)
+
+		candidates := byDomain[key]
+		if len(candidates) == 0 {
+			matched = append(matched, Result{ //nolint:exhaustruct
+				Domain:       domain,
+				Availability: AvailabilityUnknown,
+				Status:       StatusError,
+				Error:        ErrMissingResult.Error(),
+			})
+
+			continue
+		}
+
+		matched = append(matched, candidates[0])
+		byDomain[key] = candidates[1:]
+	}
+
+	return matched
+}
+
+// sortResults orders results in place by sortBy. The sort is stable, so ties keep the
+// input order and sorting twice gives the same order.
+func sortResults(results []Result, sortBy string) {
+	switch sortBy {
+	case sortByName:
+		slices.SortStableFunc(results, func(x, y Result) int {
+			return strings.Compare(strings.ToLower(x.Domain), strings.ToLower(y.Domain))
+		})
+	case sortByAvailable:
+		slices.SortStableFunc(results, func(x, y Result) int {
+			return cmp.Compare(availabilityRank(x), availabilityRank(y))
+		})
+	case sortByPrice:
+		slices.SortStableFunc(results, func(x, y Result) int {
+			return cmp.Compare(priceKey(x), priceKey(y))
+		})
+	}
+}
+
+// availabilityRank orders available before taken before unknown.
+func availabilityRank(result Result) int {
+	switch result.Availability {
+	case AvailabilityAvailable:
+		return 0
+	case AvailabilityTaken:
+		return 1
+	default:
+		return 2 //nolint:mnd
+	}
+}
+
+// priceKey is the registration price a result sorts by. Results without a quoted price
+// sort last, as their price isn't known.
+func priceKey(result Result) float64 {
+	if price := result.RegistrationPrice(); price > 0 {
+		return price
+	}
+
+	return math.Inf(1)
+}
@@ -0,0 +1,155 @@
+package namecheap
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// commandGetTLDList is the API command that returns the TLDs Namecheap supports.
+	commandGetTLDList = "namecheap.domains.getTldList"
+	// tldListCacheTTL is how long a fetched TLD list is reused. New TLDs are rare.
+	tldListCacheTTL = 24 * time.Hour
+)
+
+// TLDListResult represents the getTldList section of the API response.
+type TLDListResult struct {
+	TLDs []TLDListEntry `xml:"Tld"`
+}
+
+// TLDListEntry describes a single TLD of the getTldList response.
+type TLDListEntry struct {
+	Name              string `xml:"Name,attr"`
+	Type              string `xml:"Type,attr"`
+	MinRegisterYears  string `xml:"MinRegisterYears,attr"`
+	MaxRegisterYears  string `xml:"MaxRegisterYears,attr"`
+	IsAPIRegisterable string `xml:"IsApiRegisterable,attr"`
+	Description       string `xml:",chardata"`
+}
+
+// TLDInfo describes a TLD Namecheap supports.
+type TLDInfo struct {
+	// TLD is the top-level domain, without the leading dot
+	TLD string `json:"tld" jsonschema:"The top-level domain, e.g. com"`
+	// Type is the TLD family as reported by Namecheap, e.g. GTLD or CCTLD
+	Type string `json:"type,omitempty" jsonschema:"The TLD family, e.g. GTLD or CCTLD"`
+	// Description is Namecheap's description of the TLD
+	Description string `json:"description,omitempty" jsonschema:"What the TLD is for, e.g. US Business"`
+	// MinRegisterYears is the shortest registration period, in years
+	MinRegisterYears int `json:"minRegisterYears,omitempty" jsonschema:"Shortest registration period in years"`
+	// MaxRegisterYears is the longest registration period, in years
+	MaxRegisterYears int `json:"maxRegisterYears,omitempty" jsonschema:"Longest registration period in years"`
+	// Registerable reports whether domains of the TLD can be registered through the API
+	Registerable bool `json:"registerable" jsonschema:"Whether domains of the TLD can be registered through the API"`
+}
+
+// tldListCache holds the most recently fetched TLD list.
+type tldListCache struct {
+	mu        sync.Mutex
+	tlds      []TLDInfo
+	fetchedAt time.Time
+}
+
+// ListTLDs returns the TLDs Namecheap supports, in the order the API lists them. The list
+// is fetched once and cached, so repeated calls don't hit the API.
+func (n *Service) ListTLDs(ctx context.Context) ([]TLDInfo, error) {
+	n.tldList.mu.Lock()
+	defer n.tldList.mu.Unlock()
+
+	if n.tldList.tlds != nil && time.Since(n.tldList.fetchedAt) < tldListCacheTTL {
+		return n.tldList.tlds, nil
+	}
+
+	apiResp, err := n.call(ctx, commandGetTLDList, url.Values{})
+	if err != nil {
+		return nil, err
+	}
+
+	n.tldList.tlds = parseTLDList(apiResp.CommandResponse.TLDListResult)
+	n.tldList.fetchedAt = time.Now()
+
+	n.logger.Debug("Fetched Namecheap TLD list", zap.Int("tld_count", len(n.tldList.tlds)))
+
+	return n.tldList.tlds, nil
+}
+
+// parseTLDList converts the getTldList response entries into TLDInfo values. Year
+// counts that don't parse are left at zero.
+func parseTLDList(result TLDListResult) []TLDInfo {
+	tlds := make([]TLDInfo, 0, len(result.TLDs))
+
+	for _, entry := range result.TLDs {
+		minYears, _ := strconv.Atoi(entry.MinRegisterYears)
+		maxYears, _ := strconv.Atoi(entry.MaxRegisterYears)
+
+		tlds = append(tlds, TLDInfo{
+			TLD:              strings.ToLower(strings.TrimPrefix(entry.Name, ".")),
+			Type:             entry.Type,
+			Description:      strings.TrimSpace(entry.Description),
+			MinRegisterYears: minYears,
+			MaxRegisterYears: maxYears,
+			Registerable:     strings.EqualFold(entry.IsAPIRegisterable, "true"),
+		})
+	}
+
+	return tlds
+}
+
+// TLDListParamsIn represents the input parameters for listing the supported TLDs.
+type TLDListParamsIn struct {
+	// RegisterableOnly leaves out the TLDs that can't be registered through the API
+	RegisterableOnly bool `json:"registerableOnly,omitempty" jsonschema:"Only list TLDs registrable through the API"`
+}
+
+// TLDListParamsOut represents the supported TLDs.
+type TLDListParamsOut struct {
+	// TLDs are the supported TLDs, in the order Namecheap lists them
+	TLDs []TLDInfo `json:"tlds" jsonschema:"The TLDs Namecheap supports"`
+}
+
+// TLDListService exposes the supported TLDs as a standalone MCP tool.
+type TLDListService struct {
+	service *Service
+}
+
+// NewTLDListService creates a tool service that lists the TLDs supported by service.
+func NewTLDListService(service *Service) *TLDListService {
+	return &TLDListService{
+		service: service,
+	}
+}
+
+// Name returns the name of the TLD list service.
+func (t *TLDListService) Name() string {
+	return "list_tlds"
+}
+
+// Description returns a description of the TLD list service.
+func (t *TLDListService) Description() string {
+	return "List the TLDs Namecheap supports with their type and registration periods, " +
+		"e.g. to pick the TLDs for suggest_alternatives"
+}
+
+// Execute returns the supported TLDs.
+func (t *TLDListService) Execute(ctx context.Context, in TLDListParamsIn) (TLDListParamsOut, error) {
+	tlds, err := t.service.ListTLDs(ctx)
+	if err != nil {
+		return TLDListParamsOut{}, apiFailed(err)
+	}
+
+	out := make([]TLDInfo, 0, len(tlds))
+
+	for _, tld := range tlds {
+		if !in.RegisterableOnly || tld.Registerable {
+			out = append(out, tld)
+		}
+	}
+
+	return TLDListParamsOut{TLDs: out}, nil
+}
@@ -0,0 +1,77 @@
+package namecheap_test
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+)
+
+const tldListResponse = `<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="OK" xmlns="http://api.namecheap.com/xml.response">
+  <Errors />
+  <RequestedCommand>namecheap.domains.getTldList</RequestedCommand>
+  <CommandResponse Type="namecheap.domains.getTldList">
+    <Tlds>
+      <Tld Name="biz" NonRealTime="false" MinRegisterYears="1" MaxRegisterYears="10" MinRenewYears="1"
+        MaxRenewYears="10" IsApiRegisterable="true" IsApiRenewable="true" IsApiTransferable="false"
+        Type="GTLD" IsSupportsIDN="false" Category="P">US Business</Tld>
+      <Tld Name="uk" NonRealTime="false" MinRegisterYears="1" MaxRegisterYears="10" MinRenewYears="1"
+        MaxRenewYears="10" IsApiRegisterable="false" IsApiRenewable="true" IsApiTransferable="true"
+        Type="CCTLD" IsSupportsIDN="false" Category="A">United Kingdom</Tld>
+    </Tlds>
+  </CommandResponse>
+</ApiResponse>`
+
+func TestTLDListService_Execute(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	service := newTestServiceWithHandler(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+
+		if got := r.URL.Query().Get("Command"); got != "namecheap.domains.getTldList" {
+			t.Errorf("Command = %q, want only TLD list calls", got)
+		}
+
+		_, _ = w.Write([]byte(tldListResponse))
+	})
+
+	tlds := namecheap.NewTLDListService(service)
+
+	biz := namecheap.TLDInfo{
+		TLD: "biz", Type: "GTLD", Description: "US Business", MinRegisterYears: 1, MaxRegisterYears: 10, Registerable: true,
+	}
+	uk := namecheap.TLDInfo{
+		TLD: "uk", Type: "CCTLD", Description: "United Kingdom", MinRegisterYears: 1, MaxRegisterYears: 10,
+		Registerable: false,
+	}
+
+	tests := []struct {
+		name string
+		in   namecheap.TLDListParamsIn
+		want []namecheap.TLDInfo
+	}{
+		{name: "all", in: namecheap.TLDListParamsIn{RegisterableOnly: false}, want: []namecheap.TLDInfo{biz, uk}},
+		{name: "registerable only", in: namecheap.TLDListParamsIn{RegisterableOnly: true}, want: []namecheap.TLDInfo{biz}},
+	}
+
+	for _, tt := range tests {
+		out, err := tlds.Execute(context.Background(), tt.in)
+		if err != nil {
+			t.Fatalf("%s: Execute() unexpected error: %v", tt.name, err)
+		}
+
+		if !reflect.DeepEqual(out.TLDs, tt.want) {
+			t.Errorf("%s: Execute() TLDs = %+v, want %+v", tt.name, out.TLDs, tt.want)
+		}
+	}
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("API calls = %d, want 1 (cached)", got)
+	}
+}
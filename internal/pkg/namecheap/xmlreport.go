@@ -0,0 +1,159 @@
+package namecheap
+
+import (
+	"bytes"
+	"encoding/xml"
+	"iter"
+	"reflect"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// ignoredElements are envelope elements Namecheap always sends that the decoder
+// deliberately skips; they aren't reported as schema drift.
+//
+//nolint:gochecknoglobals
+var ignoredElements = map[string]struct{}{
+	"Warnings":         {},
+	"RequestedCommand": {},
+	"Server":           {},
+	"GengineTime":      {},
+	"ExecutionTime":    {},
+}
+
+// reportsUnknownXML reports whether responses should be scanned for unmapped XML.
+// The scan only runs in strict mode and when its debug logs would be written.
+func (n *Service) reportsUnknownXML() bool {
+	return n.config.StrictXML && n.logger.Core().Enabled(zap.DebugLevel)
+}
+
+// reportUnknownXML logs every element and attribute of data that has no field in the
+// type it decodes into, starting from APIResponse. Children of an unknown element are
+// not reported separately. Malformed XML ends the scan quietly; decoding reports it.
+func (n *Service) reportUnknownXML(data []byte) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+
+	// types[i] is the type decoding the element at path[i]; nil inside unknown subtrees.
+	var (
+		types []reflect.Type
+		path  []string
+	)
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			name := element.Name.Local
+			typ := reflect.TypeFor[APIResponse]()
+
+			if len(types) > 0 {
+				typ = nil
+
+				if parent := types[len(types)-1]; parent != nil {
+					child, ok := childElementType(parent, name)
+					_, ignored := ignoredElements[name]
+
+					if ok {
+						typ = child
+					} else if !ignored {
+						n.logger.Debug("Unrecognized XML element in Namecheap response",
+							zap.String("path", strings.Join(append(path, name), "/")))
+					}
+				}
+			}
+
+			path = append(path, name)
+			types = append(types, typ)
+
+			if typ != nil {
+				n.reportUnknownAttributes(typ, strings.Join(path, "/"), element.Attr)
+			}
+		case xml.EndElement:
+			if len(types) > 0 {
+				types = types[:len(types)-1]
+				path = path[:len(path)-1]
+			}
+		}
+	}
+}
+
+// reportUnknownAttributes logs the attributes that have no ",attr" field in typ.
+// Namespace declarations are never reported.
+func (n *Service) reportUnknownAttributes(typ reflect.Type, path string, attrs []xml.Attr) {
+	for _, attr := range attrs {
+		if attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" {
+			continue
+		}
+
+		if !hasAttributeField(typ, attr.Name.Local) {
+			n.logger.Debug("Unrecognized XML attribute in Namecheap response",
+				zap.String("path", path+"@"+attr.Name.Local))
+		}
+	}
+}
+
+// childElementType returns the type decoding the child element name of parent.
+func childElementType(parent reflect.Type, name string) (reflect.Type, bool) {
+	for field := range xmlFields(parent) {
+		tagName, options := xmlTag(field)
+		if options != "" || tagName != name {
+			continue
+		}
+
+		typ := field.Type
+		for typ.Kind() == reflect.Slice || typ.Kind() == reflect.Pointer {
+			typ = typ.Elem()
+		}
+
+		return typ, true
+	}
+
+	return nil, false
+}
+
+// hasAttributeField reports whether typ has a ",attr" field named name.
+func hasAttributeField(typ reflect.Type, name string) bool {
+	for field := range xmlFields(typ) {
+		tagName, options := xmlTag(field)
+		if options == "attr" && tagName == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// xmlFields yields the struct fields of typ that take part in XML decoding.
+func xmlFields(typ reflect.Type) iter.Seq[reflect.StructField] {
+	return func(yield func(reflect.StructField) bool) {
+		if typ.Kind() != reflect.Struct {
+			return
+		}
+
+		for field := range typ.Fields() {
+			if !field.IsExported() || field.Name == "XMLName" || field.Tag.Get("xml") == "-" {
+				continue
+			}
+
+			if !yield(field) {
+				return
+			}
+		}
+	}
+}
+
+// xmlTag splits the xml tag of field into its name, defaulting to the field name as
+// encoding/xml does, and its options.
+func xmlTag(field reflect.StructField) (string, string) {
+	name, options, _ := strings.Cut(field.Tag.Get("xml"), ",")
+	if name == "" {
+		name = field.Name
+	}
+
+	return name, options
+}
@@ -0,0 +1,116 @@
+package namecheap_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// driftedResponse has an unexpected element and attribute next to the known envelope.
+const driftedResponse = `<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="OK" xmlns="http://api.namecheap.com/xml.response">
+  <Errors />
+  <Warnings />
+  <RequestedCommand>namecheap.domains.check</RequestedCommand>
+  <CommandResponse Type="namecheap.domains.check">
+    <DomainCheckResult Domain="example.com" Available="true" ErrorNo="0" Description=""
+      IsPremiumName="false" RegistryTier="standard" />
+    <CheckQuota Remaining="42"><Window>hour</Window></CheckQuota>
+  </CommandResponse>
+  <Server>PHX01SBAPIEXT05</Server>
+  <GengineTime>--</GengineTime>
+  <ExecutionTime>0.008</ExecutionTime>
+</ApiResponse>`
+
+func TestDomainsCheck_StrictXML(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		strict    bool
+		level     zapcore.Level
+		wantPaths []string
+	}{
+		{
+			name:   "strict mode logs unrecognized fields",
+			strict: true,
+			level:  zap.DebugLevel,
+			wantPaths: []string{
+				"ApiResponse/CommandResponse/DomainCheckResult@RegistryTier",
+				"ApiResponse/CommandResponse/CheckQuota",
+			},
+		},
+		{name: "off by default", strict: false, level: zap.DebugLevel, wantPaths: nil},
+		{name: "silent above debug level", strict: true, level: zap.InfoLevel, wantPaths: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(driftedResponse))
+			}))
+			t.Cleanup(server.Close)
+
+			core, logs := observer.New(tt.level)
+
+			service, err := namecheap.NewService(zap.New(core), namecheap.Config{
+				APIUser:               "user",
+				APIKey:                "key",
+				UserName:              "username",
+				ClientIP:              "127.0.0.1",
+				Endpoint:              server.URL,
+				Sandbox:               false,
+				IncludeRaw:            false,
+				RegisterURLTemplate:   "",
+				MaxRetries:            0,
+				HTTPClient:            nil,
+				RequestTimeout:        0,
+				CheckTimeout:          0,
+				RateLimitPerSecond:    0,
+				RateLimitBurst:        0,
+				StrictXML:             tt.strict,
+				NumberFormat:          namecheap.NumberFormat{Decimal: "", Thousands: ""},
+				BatchRetry:            false,
+				BatchRetryDelay:       0,
+				AvailableErrorNumbers: nil,
+				MaxDomainsPerCheck:    0,
+				IncludeRawResponse:    false,
+				UserAgent:             "",
+				StandardPricing:       false,
+			})
+			if err != nil {
+				t.Fatalf("Failed to create service: %v", err)
+			}
+
+			results, err := service.DomainsCheck(context.Background(), []string{"example.com"})
+			if err != nil || len(results) != 1 || !results[0].Available {
+				t.Fatalf("DomainsCheck() = %+v, %v; want the known fields decoded", results, err)
+			}
+
+			var paths []string
+
+			for _, entry := range logs.All() {
+				if path, ok := entry.ContextMap()["path"].(string); ok {
+					paths = append(paths, path)
+				}
+			}
+
+			slices.Sort(paths)
+
+			want := slices.Sorted(slices.Values(tt.wantPaths))
+			if !reflect.DeepEqual(paths, want) {
+				t.Errorf("logged paths = %v, want %v", paths, want)
+			}
+		})
+	}
+}
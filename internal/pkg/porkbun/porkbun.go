@@ -0,0 +1,313 @@
+// Package porkbun provides domain availability checking through the Porkbun API.
+// Its Service implements namecheap.DomainChecker, so it plugs into the same tools
+// and decorators as the Namecheap provider.
+package porkbun
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/limit"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/metrics"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/requestid"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/retry"
+	"go.uber.org/zap"
+)
+
+const (
+	// metricsProvider labels the metrics of this backend.
+	metricsProvider = "porkbun"
+
+	// defaultRequestTimeout is the per-request HTTP timeout when Config.RequestTimeout is unset.
+	defaultRequestTimeout = 30 * time.Second
+	// retryBackoff is the delay before the first retry.
+	retryBackoff = 250 * time.Millisecond
+
+	// statusSuccess and statusError are the values of the response "status" field.
+	statusSuccess = "SUCCESS"
+	statusError   = "ERROR"
+
+	// answerYes is how Porkbun spells true in the avail and premium fields.
+	answerYes = "yes"
+)
+
+var (
+	// ErrMissingAPICredentials is returned when the API key or secret is missing.
+	ErrMissingAPICredentials = errors.New("missing Porkbun API credentials")
+	// ErrAPIError is returned when the Porkbun API answers with an ERROR status.
+	ErrAPIError = errors.New("porkbun API error")
+	// ErrUnexpectedStatus is returned for responses that are neither SUCCESS nor ERROR.
+	ErrUnexpectedStatus = errors.New("unexpected Porkbun API status")
+)
+
+// Config holds the configuration for the Porkbun API client.
+type Config struct {
+	// APIKey is the Porkbun API key
+	APIKey string
+	// SecretAPIKey is the Porkbun secret API key
+	SecretAPIKey string
+	// Endpoint is the base URL of the JSON API, e.g. https://api.porkbun.com/api/json/v3
+	Endpoint string
+	// RequestTimeout bounds each API request; zero uses a 30 second default
+	RequestTimeout time.Duration
+	// MaxRetries is how many times a transient network failure is retried; zero disables retries
+	MaxRetries int
+	// RateLimitPerSecond caps outbound API requests per second; zero or less means unlimited
+	RateLimitPerSecond float64
+	// NumberFormat is the separator format of the prices in API responses
+	NumberFormat namecheap.NumberFormat
+}
+
+// credentials is the JSON body every authenticated Porkbun request carries.
+type credentials struct {
+	APIKey       string `json:"apikey"`
+	SecretAPIKey string `json:"secretapikey"`
+}
+
+// apiResponse is the envelope of every Porkbun API response.
+type apiResponse struct {
+	Status   string          `json:"status"`
+	Message  string          `json:"message"`
+	Response json.RawMessage `json:"response"`
+}
+
+// CheckDomainResponse is the "response" object of the checkDomain endpoint.
+type CheckDomainResponse struct {
+	Avail      string                `json:"avail"`
+	Price      string                `json:"price"`
+	Premium    string                `json:"premium"`
+	Additional CheckDomainAdditional `json:"additional"`
+}
+
+// CheckDomainAdditional holds the renewal and transfer quotes of a checkDomain response.
+type CheckDomainAdditional struct {
+	Renewal CheckDomainPrice `json:"renewal"`
+}
+
+// CheckDomainPrice is a single price quote.
+type CheckDomainPrice struct {
+	Price string `json:"price"`
+}
+
+// Service checks domain availability using the Porkbun API.
+type Service struct {
+	logger *zap.Logger
+	config Config
+	client *http.Client
+}
+
+// NewService creates a new Porkbun service with the given configuration.
+// Returns ErrMissingAPICredentials if the API key or secret is missing.
+func NewService(logger *zap.Logger, config Config) (*Service, error) {
+	if config.APIKey == "" || config.SecretAPIKey == "" {
+		return nil, ErrMissingAPICredentials
+	}
+
+	timeout := config.RequestTimeout
+	if timeout <= 0 {
+		timeout = defaultRequestTimeout
+	}
+
+	return &Service{
+		logger: logger,
+		config: config,
+		client: &http.Client{ //nolint:exhaustruct
+			Timeout: timeout,
+			Transport: retry.NewTransport(
+				limit.NewTransport(http.DefaultTransport, config.RateLimitPerSecond), config.MaxRetries, retryBackoff),
+		},
+	}, nil
+}
+
+// Name returns the name of the Porkbun service.
+func (s *Service) Name() string {
+	return "check_availability_porkbun"
+}
+
+// Description returns a description of the Porkbun service.
+func (s *Service) Description() string {
+	return "Check domain availability using Porkbun API"
+}
+
+// DomainsCheck checks each domain with Porkbun's checkDomain endpoint, one request per
+// domain since the API has no bulk check. A failed lookup is reported in that domain's
+// Result.Error. The whole check fails only when ctx is cancelled or expires, or when
+// the API rejects the request itself, e.g. for invalid keys, with an ErrAPIError.
+func (s *Service) DomainsCheck(ctx context.Context, domains []string) ([]namecheap.Result, error) {
+	if len(domains) == 0 {
+		return nil, namecheap.ErrMissingDomains
+	}
+
+	metrics.DomainsCheck(metricsProvider, len(domains))
+
+	results := make([]namecheap.Result, 0, len(domains))
+	pinged := false
+
+	for _, domain := range domains {
+		result, err := s.checkDomain(ctx, domain)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+
+			// An ERROR answer is about either the domain, e.g. an unsupported TLD, or the
+			// request, e.g. rejected keys; the authenticated ping tells them apart.
+			if errors.Is(err, ErrAPIError) && !pinged {
+				pinged = true
+
+				_, pingErr := s.call(ctx, "ping")
+				if errors.Is(pingErr, ErrAPIError) {
+					return nil, pingErr
+				}
+			}
+
+			result = namecheap.Result{ //nolint:exhaustruct
+				Domain:       domain,
+				Availability: namecheap.AvailabilityUnknown,
+				Status:       namecheap.StatusError,
+				Error:        err.Error(),
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// VerifyCredentials calls the authenticated ping endpoint and returns the API error
+// when the keys are rejected.
+func (s *Service) VerifyCredentials(ctx context.Context) error {
+	_, err := s.call(ctx, "ping")
+	if err != nil {
+		return fmt.Errorf("failed to verify credentials: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) checkDomain(ctx context.Context, domain string) (namecheap.Result, error) {
+	raw, err := s.call(ctx, "domain/checkDomain/"+url.PathEscape(domain))
+	if err != nil {
+		return namecheap.Result{}, err
+	}
+
+	var resp CheckDomainResponse
+
+	err = json.Unmarshal(raw, &resp)
+	if err != nil {
+		return namecheap.Result{}, fmt.Errorf("failed to decode checkDomain response: %w", err)
+	}
+
+	result := namecheap.Result{
+		Domain:                    domain,
+		Available:                 resp.Avail == answerYes,
+		Availability:              namecheap.AvailabilityTaken,
+		Status:                    namecheap.StatusRegistered,
+		IsPremiumName:             resp.Premium == answerYes,
+		PremiumRegistrationPrice:  0,
+		PremiumRenewalPrice:       0,
+		IcannFee:                  0,
+		EapFee:                    0,
+		StandardRegistrationPrice: 0,
+		Currency:                  "",
+		Error:                     "",
+		ErrorCode:                 0,
+		RegisterURL:               "",
+		Blocked:                   false,
+		BlockReason:               "",
+		CorrelationID:             "",
+		Input:                     "",
+		ReferencePrice:            0,
+		ReferencePriceDelta:       0,
+		Raw:                       nil,
+	}
+
+	if !result.Available {
+		return result, nil
+	}
+
+	result.Availability = namecheap.AvailabilityAvailable
+	result.Status = namecheap.StatusAvailable
+
+	// Unparsable prices are left out rather than failing the check.
+	price, err := s.config.NumberFormat.Parse(resp.Price)
+	if err != nil {
+		price = 0
+	}
+
+	if result.IsPremiumName {
+		result.Status = namecheap.StatusPremium
+		result.PremiumRegistrationPrice = price
+
+		renewal, renErr := s.config.NumberFormat.Parse(resp.Additional.Renewal.Price)
+		if renErr == nil {
+			result.PremiumRenewalPrice = renewal
+		}
+	} else {
+		result.StandardRegistrationPrice = price
+	}
+
+	if result.PremiumRegistrationPrice > 0 || result.PremiumRenewalPrice > 0 || result.StandardRegistrationPrice > 0 {
+		// Porkbun prices everything in US dollars.
+		result.Currency = namecheap.CurrencyUSD
+	}
+
+	return result, nil
+}
+
+// call POSTs the credentials to path under the configured endpoint and returns the
+// "response" field of a SUCCESS answer.
+func (s *Service) call(ctx context.Context, path string) (json.RawMessage, error) {
+	body, err := json.Marshal(credentials{APIKey: s.config.APIKey, SecretAPIKey: s.config.SecretAPIKey})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode credentials: %w", err)
+	}
+
+	reqURL := strings.TrimSuffix(s.config.Endpoint, "/") + "/" + path
+
+	s.logger.Debug("Making Porkbun API call", zap.String("path", path), requestid.Field(ctx))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	start := time.Now()
+	resp, err := s.client.Do(req)
+	metrics.UpstreamRequest(metricsProvider, time.Since(start), resp, err)
+
+	if err != nil {
+		return nil, fmt.Errorf("HTTP request failed: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	var apiResp apiResponse
+
+	err = json.NewDecoder(resp.Body).Decode(&apiResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode JSON response: %w", err)
+	}
+
+	switch apiResp.Status {
+	case statusSuccess:
+		return apiResp.Response, nil
+	case statusError:
+		return nil, fmt.Errorf("%w: %s", ErrAPIError, apiResp.Message)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnexpectedStatus, apiResp.Status)
+	}
+}
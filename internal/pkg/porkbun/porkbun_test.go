@@ -0,0 +1,161 @@
+package porkbun_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/porkbun"
+	"go.uber.org/zap"
+)
+
+// responses are the stubbed checkDomain answers, keyed by domain.
+//
+//nolint:gochecknoglobals
+var responses = map[string]string{
+	"fresh.com": `{"status":"SUCCESS","response":{"avail":"yes","type":"registration","price":"9.68",` +
+		`"premium":"no","additional":{"renewal":{"type":"renewal","price":"9.68"}}}}`,
+	"luxury.io": `{"status":"SUCCESS","response":{"avail":"yes","type":"registration","price":"2500.00",` +
+		`"premium":"yes","additional":{"renewal":{"type":"renewal","price":"45.00"}}}}`,
+	"taken.com": `{"status":"SUCCESS","response":{"avail":"no","type":"registration","price":"9.68",` +
+		`"premium":"no","additional":{"renewal":{"type":"renewal","price":"9.68"}}}}`,
+	"bad.zz": `{"status":"ERROR","message":"The TLD zz is not supported."}`,
+}
+
+func newTestService(t *testing.T) *porkbun.Service {
+	t.Helper()
+
+	return newKeyedTestService(t, "pk1_key")
+}
+
+// newKeyedTestService returns a service calling a stub API that only accepts pk1_key.
+func newKeyedTestService(t *testing.T, apiKey string) *porkbun.Service {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var creds map[string]string
+
+		err := json.NewDecoder(r.Body).Decode(&creds)
+		if err != nil || creds["apikey"] != "pk1_key" || creds["secretapikey"] != "sk1_secret" {
+			_, _ = w.Write([]byte(`{"status":"ERROR","message":"Invalid API key. (002)"}`))
+
+			return
+		}
+
+		if r.URL.Path == "/ping" {
+			_, _ = w.Write([]byte(`{"status":"SUCCESS","yourIp":"127.0.0.1"}`))
+
+			return
+		}
+
+		domain := strings.TrimPrefix(r.URL.Path, "/domain/checkDomain/")
+		_, _ = w.Write([]byte(responses[domain]))
+	}))
+	t.Cleanup(server.Close)
+
+	service, err := porkbun.NewService(zap.NewNop(), porkbun.Config{
+		APIKey:             apiKey,
+		SecretAPIKey:       "sk1_secret",
+		Endpoint:           server.URL,
+		RequestTimeout:     0,
+		MaxRetries:         0,
+		RateLimitPerSecond: 0,
+		NumberFormat:       namecheap.NumberFormat{Decimal: "", Thousands: ""},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+
+	return service
+}
+
+func TestNewService_MissingCredentials(t *testing.T) {
+	t.Parallel()
+
+	_, err := porkbun.NewService(zap.NewNop(), porkbun.Config{
+		APIKey:             "pk1_key",
+		SecretAPIKey:       "",
+		Endpoint:           "",
+		RequestTimeout:     0,
+		MaxRetries:         0,
+		RateLimitPerSecond: 0,
+		NumberFormat:       namecheap.NumberFormat{Decimal: "", Thousands: ""},
+	})
+	if !errors.Is(err, porkbun.ErrMissingAPICredentials) {
+		t.Errorf("NewService() error = %v, want %v", err, porkbun.ErrMissingAPICredentials)
+	}
+}
+
+func TestDomainsCheck(t *testing.T) {
+	t.Parallel()
+
+	results, err := newTestService(t).DomainsCheck(context.Background(),
+		[]string{"fresh.com", "luxury.io", "taken.com", "bad.zz"})
+	if err != nil {
+		t.Fatalf("DomainsCheck() unexpected error: %v", err)
+	}
+
+	if len(results) != 4 {
+		t.Fatalf("DomainsCheck() returned %d results, want 4", len(results))
+	}
+
+	tests := []struct {
+		domain       string
+		available    bool
+		availability string
+		premium      bool
+		status       string
+		registration float64
+		renewal      float64
+		standard     float64
+		wantError    bool
+	}{
+		{domain: "fresh.com", available: true, availability: namecheap.AvailabilityAvailable,
+			premium: false, status: namecheap.StatusAvailable, registration: 0, renewal: 0, standard: 9.68,
+			wantError: false},
+		{domain: "luxury.io", available: true, availability: namecheap.AvailabilityAvailable,
+			premium: true, status: namecheap.StatusPremium, registration: 2500, renewal: 45, standard: 0,
+			wantError: false},
+		{domain: "taken.com", available: false, availability: namecheap.AvailabilityTaken,
+			premium: false, status: namecheap.StatusRegistered, registration: 0, renewal: 0, standard: 0,
+			wantError: false},
+		{domain: "bad.zz", available: false, availability: namecheap.AvailabilityUnknown,
+			premium: false, status: namecheap.StatusError, registration: 0, renewal: 0, standard: 0,
+			wantError: true},
+	}
+
+	for i, tt := range tests {
+		got := results[i]
+
+		if got.Domain != tt.domain || got.Available != tt.available || got.Availability != tt.availability ||
+			got.IsPremiumName != tt.premium || got.PremiumRegistrationPrice != tt.registration ||
+			got.PremiumRenewalPrice != tt.renewal || got.StandardRegistrationPrice != tt.standard ||
+			got.Status != tt.status || (got.Error != "") != tt.wantError {
+			t.Errorf("results[%d] = %+v, want %+v", i, got, tt)
+		}
+	}
+}
+
+func TestDomainsCheck_RejectedKeys(t *testing.T) {
+	t.Parallel()
+
+	results, err := newKeyedTestService(t, "pk1_wrong").DomainsCheck(context.Background(),
+		[]string{"fresh.com", "taken.com"})
+	if !errors.Is(err, porkbun.ErrAPIError) || results != nil {
+		t.Errorf("DomainsCheck() = %v, %v; want nil, %v", results, err, porkbun.ErrAPIError)
+	}
+}
+
+func TestVerifyCredentials(t *testing.T) {
+	t.Parallel()
+
+	err := newTestService(t).VerifyCredentials(context.Background())
+	if err != nil {
+		t.Errorf("VerifyCredentials() unexpected error: %v", err)
+	}
+}
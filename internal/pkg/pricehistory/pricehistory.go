@@ -0,0 +1,275 @@
+// Package pricehistory records the prices returned by domain checks so they can
+// be charted over time. Recording is backend-agnostic: Recorder decorates any
+// namecheap.DomainChecker and appends priced results to a pluggable Store.
+package pricehistory
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/tool"
+	"go.uber.org/zap"
+)
+
+// filePermissions is the mode used when creating a history file.
+const filePermissions = 0o600
+
+// ErrMissingDomain is returned when no domain is provided for a history query.
+var ErrMissingDomain = errors.New("missing domain")
+
+// Entry is a single recorded price observation for a domain.
+type Entry struct {
+	// Domain is the lowercase domain name the prices belong to
+	Domain string `json:"domain" jsonschema:"The domain the prices belong to"`
+	// Timestamp is when the prices were observed
+	Timestamp time.Time `json:"timestamp" jsonschema:"When the prices were observed"`
+	// RegistrationPrice is the premium registration price at that time
+	RegistrationPrice float64 `json:"registrationPrice" jsonschema:"Premium registration price"`
+	// RenewalPrice is the premium renewal price at that time
+	RenewalPrice float64 `json:"renewalPrice" jsonschema:"Premium renewal price"`
+	// IcannFee is the ICANN fee at that time
+	IcannFee float64 `json:"icannFee" jsonschema:"Fee charged by ICANN"`
+	// EapFee is the Early Access Program fee at that time
+	EapFee float64 `json:"eapFee" jsonschema:"EAP fee"`
+}
+
+// Store persists price entries and returns them per domain.
+type Store interface {
+	// Append stores the given entries.
+	Append(entries ...Entry) error
+	// History returns the entries for domain in the order they were appended.
+	History(domain string) ([]Entry, error)
+}
+
+// MemoryStore is a Store that keeps entries in memory for the lifetime of the process.
+type MemoryStore struct {
+	mu      sync.RWMutex
+	entries map[string][]Entry
+}
+
+// NewMemoryStore creates an empty in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		mu:      sync.RWMutex{},
+		entries: make(map[string][]Entry),
+	}
+}
+
+// Append stores the given entries.
+func (m *MemoryStore) Append(entries ...Entry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, entry := range entries {
+		m.entries[entry.Domain] = append(m.entries[entry.Domain], entry)
+	}
+
+	return nil
+}
+
+// History returns a copy of the entries recorded for domain.
+func (m *MemoryStore) History(domain string) ([]Entry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return append([]Entry(nil), m.entries[strings.ToLower(domain)]...), nil
+}
+
+// FileStore is a Store that appends entries to a JSON-lines file.
+type FileStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileStore creates a store backed by the file at path. The file is created on first append.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{
+		mu:   sync.Mutex{},
+		path: path,
+	}
+}
+
+// Append writes the given entries to the end of the file, one JSON object per line.
+func (f *FileStore) Append(entries ...Entry) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, filePermissions)
+	if err != nil {
+		return fmt.Errorf("failed to open price history file: %w", err)
+	}
+
+	encoder := json.NewEncoder(file)
+
+	for _, entry := range entries {
+		err = encoder.Encode(entry)
+		if err != nil {
+			_ = file.Close()
+
+			return fmt.Errorf("failed to write price history entry: %w", err)
+		}
+	}
+
+	return file.Close()
+}
+
+// History scans the file for entries recorded for domain.
+func (f *FileStore) History(domain string) ([]Entry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	file, err := os.Open(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to open price history file: %w", err)
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	domain = strings.ToLower(domain)
+
+	var entries []Entry
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry Entry
+
+		err = json.Unmarshal(scanner.Bytes(), &entry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read price history entry: %w", err)
+		}
+
+		if entry.Domain == domain {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, scanner.Err()
+}
+
+// Recorder is a DomainChecker that records the prices of every priced result
+// returned by the wrapped checker. Recording failures are logged, never returned,
+// so history can't break availability checks.
+type Recorder struct {
+	checker namecheap.DomainChecker
+	store   Store
+	logger  *zap.Logger
+	now     func() time.Time
+}
+
+// NewRecorder wraps checker so that priced results are appended to store.
+func NewRecorder(logger *zap.Logger, checker namecheap.DomainChecker, store Store) *Recorder {
+	return &Recorder{
+		checker: checker,
+		store:   store,
+		logger:  logger,
+		now:     time.Now,
+	}
+}
+
+// Name returns the name of the wrapped checker.
+func (r *Recorder) Name() string {
+	return r.checker.Name()
+}
+
+// Description returns the description of the wrapped checker.
+func (r *Recorder) Description() string {
+	return r.checker.Description()
+}
+
+// DomainsCheck checks domains with the wrapped checker and records the priced results.
+func (r *Recorder) DomainsCheck(ctx context.Context, domains []string) ([]namecheap.Result, error) {
+	results, err := r.checker.DomainsCheck(ctx, domains)
+	if err != nil && !namecheap.IsPartial(err) {
+		return nil, err
+	}
+
+	now := r.now().UTC()
+	entries := make([]Entry, 0, len(results))
+
+	for _, result := range results {
+		if result.PremiumRegistrationPrice == 0 && result.PremiumRenewalPrice == 0 {
+			continue
+		}
+
+		entries = append(entries, Entry{
+			Domain:            strings.ToLower(result.Domain),
+			Timestamp:         now,
+			RegistrationPrice: result.PremiumRegistrationPrice,
+			RenewalPrice:      result.PremiumRenewalPrice,
+			IcannFee:          result.IcannFee,
+			EapFee:            result.EapFee,
+		})
+	}
+
+	if len(entries) > 0 {
+		appendErr := r.store.Append(entries...)
+		if appendErr != nil {
+			r.logger.Warn("Failed to record price history", zap.Error(appendErr))
+		}
+	}
+
+	return results, err
+}
+
+// ParamsIn represents the input parameters for a price history query.
+type ParamsIn struct {
+	// Domain is the domain to return recorded prices for
+	Domain string `json:"domain" jsonschema:"The domain to return recorded prices for, e.g. example.com"`
+}
+
+// ParamsOut represents the recorded price history of a domain.
+type ParamsOut struct {
+	// Entries are the recorded prices, oldest first
+	Entries []Entry `json:"entries" jsonschema:"Recorded prices, oldest first"`
+}
+
+// HistoryService exposes the recorded price history as an MCP tool.
+type HistoryService struct {
+	store Store
+}
+
+// NewHistoryService creates a tool service that reads history from store.
+func NewHistoryService(store Store) *HistoryService {
+	return &HistoryService{
+		store: store,
+	}
+}
+
+// Name returns the name of the price history service.
+func (h *HistoryService) Name() string {
+	return "price_history"
+}
+
+// Description returns a description of the price history service.
+func (h *HistoryService) Description() string {
+	return "Return the premium prices recorded for a domain by previous availability checks, oldest first"
+}
+
+// Execute returns the recorded price history for the requested domain.
+func (h *HistoryService) Execute(_ context.Context, in ParamsIn) (ParamsOut, error) {
+	domain := strings.TrimSpace(in.Domain)
+	if domain == "" {
+		return ParamsOut{}, fmt.Errorf("%w: %w", tool.ErrInvalidInput, ErrMissingDomain)
+	}
+
+	entries, err := h.store.History(domain)
+	if err != nil {
+		return ParamsOut{}, err
+	}
+
+	return ParamsOut{Entries: entries}, nil
+}
@@ -0,0 +1,99 @@
+package pricehistory_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap/namecheaptest"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/pricehistory"
+	"go.uber.org/zap"
+)
+
+func pricedResult(domain string, registration, renewal float64) namecheap.Result {
+	return namecheap.Result{
+		Domain:                    domain,
+		Available:                 true,
+		Availability:              "",
+		Status:                    "",
+		IsPremiumName:             registration > 0,
+		PremiumRegistrationPrice:  registration,
+		PremiumRenewalPrice:       renewal,
+		IcannFee:                  0.18,
+		EapFee:                    0,
+		StandardRegistrationPrice: 0,
+		Currency:                  "",
+		Error:                     "",
+		ErrorCode:                 0,
+		RegisterURL:               "",
+		Blocked:                   false,
+		BlockReason:               "",
+		CorrelationID:             "",
+		Input:                     "",
+		ReferencePrice:            0,
+		ReferencePriceDelta:       0,
+		Raw:                       nil,
+	}
+}
+
+//nolint:cyclop
+func TestRecorder_RecordsPricedResults(t *testing.T) {
+	t.Parallel()
+
+	stores := map[string]pricehistory.Store{
+		"memory": pricehistory.NewMemoryStore(),
+		"file":   pricehistory.NewFileStore(filepath.Join(t.TempDir(), "history.jsonl")),
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			checker := &namecheaptest.Checker{} //nolint:exhaustruct
+			recorder := pricehistory.NewRecorder(zap.NewNop(), checker, store)
+			history := pricehistory.NewHistoryService(store)
+
+			for _, price := range []float64{100, 120} {
+				checker.Results = []namecheap.Result{
+					pricedResult("Premium.com", price, 50),
+					pricedResult("plain.com", 0, 0),
+				}
+
+				results, err := recorder.DomainsCheck(context.Background(), []string{"premium.com", "plain.com"})
+				if err != nil {
+					t.Fatalf("DomainsCheck() unexpected error: %v", err)
+				}
+
+				if len(results) != 2 {
+					t.Fatalf("DomainsCheck() returned %d results, want 2", len(results))
+				}
+			}
+
+			out, err := history.Execute(context.Background(), pricehistory.ParamsIn{Domain: "premium.com"})
+			if err != nil {
+				t.Fatalf("Execute() unexpected error: %v", err)
+			}
+
+			if len(out.Entries) != 2 {
+				t.Fatalf("Execute() returned %d entries, want 2", len(out.Entries))
+			}
+
+			for i, want := range []float64{100, 120} {
+				entry := out.Entries[i]
+				if entry.RegistrationPrice != want || entry.RenewalPrice != 50 || entry.Timestamp.IsZero() {
+					t.Errorf("entry[%d] = %+v, want registration %v, renewal 50 and a timestamp", i, entry, want)
+				}
+			}
+
+			out, err = history.Execute(context.Background(), pricehistory.ParamsIn{Domain: "plain.com"})
+			if err != nil {
+				t.Fatalf("Execute() unexpected error: %v", err)
+			}
+
+			if len(out.Entries) != 0 {
+				t.Errorf("unpriced domain has %d entries, want 0", len(out.Entries))
+			}
+		})
+	}
+}
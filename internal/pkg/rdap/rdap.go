@@ -0,0 +1,336 @@
+// Package rdap provides registrar-agnostic availability checking over RDAP (RFC 9082/9083).
+// It needs no API key: the IANA bootstrap registry maps each TLD to its registry's RDAP
+// server, where a 404 for a domain means it isn't registered. RDAP carries no pricing,
+// so the price fields of its results are always zero.
+package rdap
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/limit"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/metrics"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/retry"
+	"go.uber.org/zap"
+)
+
+const (
+	// metricsProvider labels the metrics of this backend.
+	metricsProvider = "rdap"
+
+	// DefaultBootstrapURL is IANA's RDAP bootstrap registry for domain names.
+	DefaultBootstrapURL = "https://data.iana.org/rdap/dns.json"
+
+	// defaultRequestTimeout is the per-request HTTP timeout when Config.RequestTimeout is unset.
+	defaultRequestTimeout = 30 * time.Second
+	// retryBackoff is the delay before the first retry.
+	retryBackoff = 250 * time.Millisecond
+	// bootstrapTTL is how long the bootstrap registry is reused; IANA updates it rarely.
+	bootstrapTTL = 24 * time.Hour
+)
+
+var (
+	// ErrNoRDAPServer is reported per domain when the bootstrap registry has no server for its TLD.
+	ErrNoRDAPServer = errors.New("no RDAP server for TLD")
+	// ErrUnexpectedStatus is reported per domain for RDAP answers other than 200 and 404.
+	ErrUnexpectedStatus = errors.New("unexpected RDAP response status")
+	// ErrMissingFallbackResult is reported per domain when the fallback checker skipped it.
+	ErrMissingFallbackResult = errors.New("fallback checker returned no result")
+)
+
+// Config holds the configuration for the RDAP checker.
+type Config struct {
+	// BootstrapURL is the IANA bootstrap registry; empty uses DefaultBootstrapURL
+	BootstrapURL string
+	// RequestTimeout bounds each HTTP request; zero uses a 30 second default
+	RequestTimeout time.Duration
+	// MaxRetries is how many times a transient network failure is retried; zero disables retries
+	MaxRetries int
+	// RateLimitPerSecond caps outbound API requests per second; zero or less means unlimited
+	RateLimitPerSecond float64
+	// Fallback, when set, checks the domains whose TLD has no RDAP server
+	Fallback namecheap.DomainChecker
+}
+
+// bootstrapRegistry is the subset of the RFC 9224 bootstrap file the checker reads.
+// Each service is a pair of [TLDs, base URLs].
+type bootstrapRegistry struct {
+	Services [][][]string `json:"services"`
+}
+
+// Service checks domain availability with RDAP lookups.
+type Service struct {
+	logger *zap.Logger
+	config Config
+	client *http.Client
+
+	mu        sync.Mutex
+	servers   map[string]string
+	fetchedAt time.Time
+}
+
+// NewService creates an RDAP checker. It needs no credentials, so it can always be enabled.
+func NewService(logger *zap.Logger, config Config) *Service {
+	if config.BootstrapURL == "" {
+		config.BootstrapURL = DefaultBootstrapURL
+	}
+
+	timeout := config.RequestTimeout
+	if timeout <= 0 {
+		timeout = defaultRequestTimeout
+	}
+
+	return &Service{
+		logger: logger,
+		config: config,
+		client: &http.Client{ //nolint:exhaustruct
+			Timeout: timeout,
+			Transport: retry.NewTransport(
+				limit.NewTransport(http.DefaultTransport, config.RateLimitPerSecond), config.MaxRetries, retryBackoff),
+		},
+		mu:        sync.Mutex{},
+		servers:   nil,
+		fetchedAt: time.Time{},
+	}
+}
+
+// Name returns the name of the RDAP service.
+func (s *Service) Name() string {
+	return "check_availability_rdap"
+}
+
+// Description returns a description of the RDAP service.
+func (s *Service) Description() string {
+	return "Check whether domains are registered using RDAP; no pricing information is returned"
+}
+
+// DomainsCheck looks every domain up on its registry's RDAP server, handing domains of
+// TLDs without one to the fallback checker when configured. Failed lookups are reported
+// in that domain's Result.Error; only a bootstrap failure or a cancelled ctx fails the
+// whole check.
+func (s *Service) DomainsCheck(ctx context.Context, domains []string) ([]namecheap.Result, error) {
+	if len(domains) == 0 {
+		return nil, namecheap.ErrMissingDomains
+	}
+
+	metrics.DomainsCheck(metricsProvider, len(domains))
+
+	servers, err := s.bootstrap(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]namecheap.Result, len(domains))
+
+	// fallback holds the indexes of the domains left to the fallback checker.
+	var fallback []int
+
+	for i, domain := range domains {
+		result, err := s.lookup(ctx, servers, domain)
+		if errors.Is(err, ErrNoRDAPServer) && s.config.Fallback != nil {
+			fallback = append(fallback, i)
+
+			continue
+		}
+
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+
+			result = errorResult(domain, err)
+		}
+
+		results[i] = result
+	}
+
+	if len(fallback) > 0 {
+		err = s.checkFallback(ctx, domains, fallback, results)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+// checkFallback checks domains[i] for every i in indexes with the fallback checker and
+// stores the answers in results. A failed fallback check marks only those domains.
+func (s *Service) checkFallback(
+	ctx context.Context,
+	domains []string,
+	indexes []int,
+	results []namecheap.Result,
+) error {
+	names := make([]string, 0, len(indexes))
+	for _, i := range indexes {
+		names = append(names, domains[i])
+	}
+
+	s.logger.Debug("Checking domains without RDAP server", zap.String("fallback", s.config.Fallback.Name()),
+		zap.Strings("domains", names))
+
+	fallbackResults, err := s.config.Fallback.DomainsCheck(ctx, names)
+	if err != nil && !namecheap.IsPartial(err) {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		for _, i := range indexes {
+			results[i] = errorResult(domains[i], err)
+		}
+
+		return nil
+	}
+
+	for j, i := range indexes {
+		if j < len(fallbackResults) {
+			results[i] = fallbackResults[j]
+		} else {
+			results[i] = errorResult(domains[i], ErrMissingFallbackResult)
+		}
+	}
+
+	return nil
+}
+
+// errorResult is the result of a domain whose availability couldn't be determined.
+func errorResult(domain string, err error) namecheap.Result {
+	return namecheap.Result{ //nolint:exhaustruct
+		Domain:       domain,
+		Availability: namecheap.AvailabilityUnknown,
+		Status:       namecheap.StatusError,
+		Error:        err.Error(),
+	}
+}
+
+func (s *Service) lookup(ctx context.Context, servers map[string]string, domain string) (namecheap.Result, error) {
+	name := strings.ToLower(strings.TrimSuffix(domain, "."))
+	tld := name[strings.LastIndex(name, ".")+1:]
+
+	server, ok := servers[tld]
+	if !ok {
+		return namecheap.Result{}, fmt.Errorf("%w %q", ErrNoRDAPServer, tld)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server+"domain/"+name, nil)
+	if err != nil {
+		return namecheap.Result{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/rdap+json")
+
+	start := time.Now()
+	resp, err := s.client.Do(req)
+	metrics.UpstreamRequest(metricsProvider, time.Since(start), resp, err)
+
+	if err != nil {
+		return namecheap.Result{}, fmt.Errorf("HTTP request failed: %w", err)
+	}
+
+	_ = resp.Body.Close()
+
+	result := namecheap.Result{
+		Domain:                    domain,
+		Available:                 false,
+		Availability:              "",
+		Status:                    "",
+		IsPremiumName:             false,
+		PremiumRegistrationPrice:  0,
+		PremiumRenewalPrice:       0,
+		IcannFee:                  0,
+		EapFee:                    0,
+		StandardRegistrationPrice: 0,
+		Currency:                  "",
+		Error:                     "",
+		ErrorCode:                 0,
+		RegisterURL:               "",
+		Blocked:                   false,
+		BlockReason:               "",
+		CorrelationID:             "",
+		Input:                     "",
+		ReferencePrice:            0,
+		ReferencePriceDelta:       0,
+		Raw:                       nil,
+	}
+
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		result.Available = true
+		result.Availability = namecheap.AvailabilityAvailable
+	case http.StatusOK:
+		result.Availability = namecheap.AvailabilityTaken
+	default:
+		return namecheap.Result{}, fmt.Errorf("%w: %d", ErrUnexpectedStatus, resp.StatusCode)
+	}
+
+	result.Status = namecheap.StatusOf(result)
+
+	return result, nil
+}
+
+// bootstrap returns the TLD to RDAP base URL map, fetching the registry when the cached
+// copy is missing or expired. Base URLs always end with a slash.
+func (s *Service) bootstrap(ctx context.Context) (map[string]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.servers != nil && time.Since(s.fetchedAt) < bootstrapTTL {
+		return s.servers, nil
+	}
+
+	s.logger.Debug("Fetching RDAP bootstrap registry", zap.String("url", s.config.BootstrapURL))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.config.BootstrapURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bootstrap request: %w", err)
+	}
+
+	start := time.Now()
+	resp, err := s.client.Do(req)
+	metrics.UpstreamRequest(metricsProvider, time.Since(start), resp, err)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch RDAP bootstrap registry: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	var registry bootstrapRegistry
+
+	err = json.NewDecoder(resp.Body).Decode(&registry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode RDAP bootstrap registry: %w", err)
+	}
+
+	servers := make(map[string]string)
+
+	for _, service := range registry.Services {
+		if len(service) < 2 || len(service[1]) == 0 {
+			continue
+		}
+
+		base := service[1][0]
+		if !strings.HasSuffix(base, "/") {
+			base += "/"
+		}
+
+		for _, tld := range service[0] {
+			servers[strings.ToLower(tld)] = base
+		}
+	}
+
+	s.servers = servers
+	s.fetchedAt = time.Now()
+
+	return servers, nil
+}
@@ -0,0 +1,159 @@
+package rdap_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/rdap"
+	"go.uber.org/zap"
+)
+
+// fallbackChecker implements namecheap.DomainChecker, reporting every domain as available.
+type fallbackChecker struct {
+	checked []string
+}
+
+func (f *fallbackChecker) DomainsCheck(_ context.Context, domains []string) ([]namecheap.Result, error) {
+	f.checked = append(f.checked, domains...)
+
+	results := make([]namecheap.Result, 0, len(domains))
+	for _, domain := range domains {
+		results = append(results, namecheap.Result{ //nolint:exhaustruct
+			Domain:       domain,
+			Available:    true,
+			Availability: namecheap.AvailabilityAvailable,
+		})
+	}
+
+	return results, nil
+}
+
+func (f *fallbackChecker) Name() string {
+	return "fallback"
+}
+
+func (f *fallbackChecker) Description() string {
+	return "fallback"
+}
+
+func TestDomainsCheck(t *testing.T) {
+	t.Parallel()
+
+	var bootstrapFetches atomic.Int32
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/dns.json", func(w http.ResponseWriter, _ *http.Request) {
+		bootstrapFetches.Add(1)
+
+		_, _ = w.Write([]byte(`{"version":"1.0","services":[[["com","net"],["` + server.URL + `/com/v1"]]]}`))
+	})
+	mux.HandleFunc("/com/v1/domain/{name}", func(w http.ResponseWriter, r *http.Request) {
+		switch r.PathValue("name") {
+		case "taken.com":
+			_, _ = w.Write([]byte(`{"objectClassName":"domain","ldhName":"TAKEN.COM"}`))
+		case "broken.com":
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	service := rdap.NewService(zap.NewNop(), rdap.Config{
+		BootstrapURL:       server.URL + "/dns.json",
+		RequestTimeout:     0,
+		MaxRetries:         0,
+		RateLimitPerSecond: 0,
+		Fallback:           nil,
+	})
+
+	domains := []string{"Fresh.com", "taken.com", "broken.com", "example.zz"}
+
+	results, err := service.DomainsCheck(context.Background(), domains)
+	if err != nil {
+		t.Fatalf("DomainsCheck() unexpected error: %v", err)
+	}
+
+	want := []struct {
+		availability string
+		errContains  string
+	}{
+		{availability: namecheap.AvailabilityAvailable, errContains: ""},
+		{availability: namecheap.AvailabilityTaken, errContains: ""},
+		{availability: namecheap.AvailabilityUnknown, errContains: "429"},
+		{availability: namecheap.AvailabilityUnknown, errContains: "no RDAP server"},
+	}
+
+	if len(results) != len(want) {
+		t.Fatalf("DomainsCheck() returned %d results, want %d", len(results), len(want))
+	}
+
+	for i, w := range want {
+		got := results[i]
+		if got.Domain != domains[i] || got.Availability != w.availability ||
+			got.Available != (w.availability == namecheap.AvailabilityAvailable) ||
+			!strings.Contains(got.Error, w.errContains) || (w.errContains == "") != (got.Error == "") {
+			t.Errorf("results[%d] = %+v, want availability %q and error containing %q",
+				i, got, w.availability, w.errContains)
+		}
+	}
+
+	_, err = service.DomainsCheck(context.Background(), []string{"again.com"})
+	if err != nil {
+		t.Fatalf("DomainsCheck() unexpected error: %v", err)
+	}
+
+	if got := bootstrapFetches.Load(); got != 1 {
+		t.Errorf("bootstrap fetches = %d, want the registry cached after the first check", got)
+	}
+}
+
+func TestDomainsCheck_Fallback(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/dns.json", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"services":[[["com"],["` + server.URL + `/"]]]}`))
+	})
+	mux.HandleFunc("/domain/{name}", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"objectClassName":"domain"}`))
+	})
+
+	fallback := &fallbackChecker{checked: nil}
+	service := rdap.NewService(zap.NewNop(), rdap.Config{
+		BootstrapURL:       server.URL + "/dns.json",
+		RequestTimeout:     0,
+		MaxRetries:         0,
+		RateLimitPerSecond: 0,
+		Fallback:           fallback,
+	})
+
+	results, err := service.DomainsCheck(context.Background(), []string{"brand.de", "brand.com", "brand.nl"})
+	if err != nil {
+		t.Fatalf("DomainsCheck() unexpected error: %v", err)
+	}
+
+	got := make([]string, 0, len(results))
+	for _, result := range results {
+		got = append(got, result.Domain+"="+result.Availability)
+	}
+
+	want := []string{"brand.de=available", "brand.com=taken", "brand.nl=available"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("results = %v, want %v", got, want)
+	}
+
+	if strings.Join(fallback.checked, " ") != "brand.de brand.nl" {
+		t.Errorf("fallback checked %v, want only the domains without an RDAP server", fallback.checked)
+	}
+}
@@ -0,0 +1,94 @@
+// Package refprice compares the registration prices of check results with a reference
+// price table, e.g. another registrar's list prices, so users can see whether the
+// backend is competitive. Checker decorates any namecheap.DomainChecker.
+package refprice
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+)
+
+// Table maps lowercase TLDs, without the leading dot, to their reference registration
+// price in US dollars.
+type Table map[string]float64
+
+// Lookup returns the reference price of domain's TLD. Multi-label suffixes such as
+// "co.uk" take precedence over their last label.
+func (t Table) Lookup(domain string) (float64, bool) {
+	name := namecheap.NormalizeDomain(domain)
+
+	for {
+		_, suffix, found := strings.Cut(name, ".")
+		if !found {
+			return 0, false
+		}
+
+		if price, ok := t[suffix]; ok {
+			return price, true
+		}
+
+		name = suffix
+	}
+}
+
+// Checker is a DomainChecker that sets ReferencePrice and ReferencePriceDelta on the
+// results that carry a registration price in US dollars and whose TLD is in the table.
+// Premium names are compared by their premium price, others by their standard price.
+// Prices quoted in another currency are left uncompared, as the table is in US dollars.
+// The wrapped checker's results are otherwise returned untouched.
+type Checker struct {
+	checker namecheap.DomainChecker
+	table   Table
+}
+
+// NewChecker wraps checker so its priced results are compared with table.
+func NewChecker(checker namecheap.DomainChecker, table Table) *Checker {
+	return &Checker{
+		checker: checker,
+		table:   table,
+	}
+}
+
+// Name returns the name of the wrapped checker.
+func (c *Checker) Name() string {
+	return c.checker.Name()
+}
+
+// Description returns the description of the wrapped checker.
+func (c *Checker) Description() string {
+	return c.checker.Description()
+}
+
+// DomainsCheck checks domains with the wrapped checker and compares the priced results
+// with the reference table.
+func (c *Checker) DomainsCheck(ctx context.Context, domains []string) ([]namecheap.Result, error) {
+	results, err := c.checker.DomainsCheck(ctx, domains)
+	if err != nil && !namecheap.IsPartial(err) {
+		return nil, err
+	}
+
+	for i := range results {
+		price := results[i].RegistrationPrice()
+		if price <= 0 || !isUSD(results[i].Currency) {
+			continue
+		}
+
+		reference, ok := c.table.Lookup(results[i].Domain)
+		if !ok {
+			continue
+		}
+
+		results[i].ReferencePrice = reference
+		results[i].ReferencePriceDelta = namecheap.RoundCents(price - reference)
+	}
+
+	return results, err
+}
+
+// isUSD reports whether prices in currency are US dollars; an empty currency is taken as
+// USD, as not every backend sets it.
+func isUSD(currency string) bool {
+	return currency == "" || strings.EqualFold(currency, namecheap.CurrencyUSD)
+}
@@ -0,0 +1,52 @@
+package refprice_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap/namecheaptest"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/refprice"
+)
+
+func TestChecker_ComparesPricedResults(t *testing.T) {
+	t.Parallel()
+
+	checker := refprice.NewChecker(&namecheaptest.Checker{Answers: map[string]namecheap.Result{ //nolint:exhaustruct
+		"gold.com":    {PremiumRegistrationPrice: 13.48, IsPremiumName: true},             //nolint:exhaustruct
+		"cheap.io":    {PremiumRegistrationPrice: 29.99, Currency: namecheap.CurrencyUSD}, //nolint:exhaustruct
+		"brand.co.uk": {StandardRegistrationPrice: 8.5, Currency: namecheap.CurrencyUSD},  //nolint:exhaustruct
+		"plain.com":   {},                                                                 //nolint:exhaustruct
+		"other.dev":   {StandardRegistrationPrice: 15},                                    //nolint:exhaustruct
+		"local.com":   {StandardRegistrationPrice: 9, Currency: "EUR"},                    //nolint:exhaustruct
+	}}, refprice.Table{"com": 10.28, "io": 39.98, "co.uk": 6, "uk": 100})
+
+	domains := []string{"gold.com", "cheap.io", "brand.co.uk", "plain.com", "other.dev", "local.com"}
+
+	results, err := checker.DomainsCheck(context.Background(), domains)
+	if err != nil {
+		t.Fatalf("DomainsCheck() unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		domain        string
+		wantReference float64
+		wantDelta     float64
+	}{
+		{domain: "gold.com", wantReference: 10.28, wantDelta: 3.2},
+		{domain: "cheap.io", wantReference: 39.98, wantDelta: -9.99},
+		{domain: "brand.co.uk", wantReference: 6, wantDelta: 2.5},
+		{domain: "plain.com", wantReference: 0, wantDelta: 0},
+		{domain: "other.dev", wantReference: 0, wantDelta: 0},
+		// The table is in US dollars, so prices in another currency aren't compared.
+		{domain: "local.com", wantReference: 0, wantDelta: 0},
+	}
+
+	for i, tt := range tests {
+		got := results[i]
+		if got.Domain != tt.domain || got.ReferencePrice != tt.wantReference || got.ReferencePriceDelta != tt.wantDelta {
+			t.Errorf("%s: ReferencePrice = %v, ReferencePriceDelta = %v; want %v, %v",
+				got.Domain, got.ReferencePrice, got.ReferencePriceDelta, tt.wantReference, tt.wantDelta)
+		}
+	}
+}
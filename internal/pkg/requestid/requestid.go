@@ -0,0 +1,94 @@
+// Package requestid carries the ID of an HTTP request through the context of the work it
+// causes, so the logs and results of one request can be correlated with client reports.
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// Header is the HTTP header a request ID is read from and echoed in.
+	Header = "X-Request-ID"
+	// LogField is the name of the log field holding the request ID.
+	LogField = "request_id"
+
+	// maxLength bounds the incoming IDs that are accepted.
+	maxLength = 128
+	// idBytes is the number of random bytes of a generated ID.
+	idBytes = 16
+)
+
+// contextKey is the context key of the request ID.
+type contextKey struct{}
+
+// New returns a random request ID of 32 hex digits.
+func New() string {
+	id := make([]byte, idBytes)
+	_, _ = rand.Read(id)
+
+	return hex.EncodeToString(id)
+}
+
+// NewContext returns a copy of ctx carrying id.
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID carried by ctx, or "" when there is none.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+
+	return id
+}
+
+// Field returns the log field of the request ID carried by ctx, or a field that is left
+// out of the entry when there is none.
+func Field(ctx context.Context) zap.Field {
+	id := FromContext(ctx)
+	if id == "" {
+		return zap.Skip()
+	}
+
+	return zap.String(LogField, id)
+}
+
+// Middleware gives every request an ID: the incoming X-Request-ID when it is valid, a new
+// random one otherwise. The ID is set on the request header and context for the handlers
+// down the chain, and echoed in the response header.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(Header)
+		if !Valid(id) {
+			id = New()
+		}
+
+		r.Header.Set(Header, id)
+		w.Header().Set(Header, id)
+
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), id)))
+	})
+}
+
+// Valid reports whether id is accepted as a request ID: 1 to 128 letters, digits and
+// "-", "_", ".", ":" only, so a client can't inject anything into the logs.
+func Valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
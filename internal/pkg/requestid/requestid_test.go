@@ -0,0 +1,55 @@
+package requestid_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/requestid"
+)
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		incoming string
+		wantKept bool
+	}{
+		{name: "valid incoming ID is kept", incoming: "client-42.a:b_c", wantKept: true},
+		{name: "missing ID is generated", incoming: "", wantKept: false},
+		{name: "ID with spaces is replaced", incoming: "id\nforged log line", wantKept: false},
+		{name: "overlong ID is replaced", incoming: strings.Repeat("a", 129), wantKept: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var fromContext, fromHeader string
+
+			handler := requestid.Middleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				fromContext = requestid.FromContext(r.Context())
+				fromHeader = r.Header.Get(requestid.Header)
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			if tt.incoming != "" {
+				req.Header.Set(requestid.Header, tt.incoming)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			echoed := rec.Header().Get(requestid.Header)
+			if !requestid.Valid(echoed) || fromContext != echoed || fromHeader != echoed {
+				t.Fatalf("echoed %q, context %q, header %q; want one valid ID", echoed, fromContext, fromHeader)
+			}
+
+			if kept := echoed == tt.incoming; kept != tt.wantKept {
+				t.Errorf("ID = %q, kept incoming = %v, want %v", echoed, kept, tt.wantKept)
+			}
+		})
+	}
+}
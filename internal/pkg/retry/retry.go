@@ -0,0 +1,71 @@
+// Package retry provides an http.RoundTripper that retries transient network failures.
+package retry
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// Transport retries requests whose round trip fails with a retriable error.
+// Retries back off exponentially from the configured delay and stop early when
+// the request context is done.
+type Transport struct {
+	base       http.RoundTripper
+	maxRetries int
+	backoff    time.Duration
+}
+
+// NewTransport wraps base so that retriable failures are retried up to maxRetries
+// times, waiting backoff before the first retry and doubling it after each attempt.
+func NewTransport(base http.RoundTripper, maxRetries int, backoff time.Duration) *Transport {
+	return &Transport{
+		base:       base,
+		maxRetries: maxRetries,
+		backoff:    backoff,
+	}
+}
+
+// RoundTrip executes the request, retrying it while the error is retriable.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	delay := t.backoff
+
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if err == nil || attempt >= t.maxRetries || !IsRetriable(err) {
+			return resp, err
+		}
+
+		// A consumed body can only be replayed if the request knows how to rebuild it.
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return resp, err
+			}
+
+			req.Body, err = req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("failed to rewind request body: %w", err)
+			}
+		}
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		delay *= 2
+	}
+}
+
+// IsRetriable reports whether err is a transient failure worth retrying.
+// Connection resets are typical of rate-limited upstreams dropping connections.
+func IsRetriable(err error) bool {
+	return errors.Is(err, syscall.ECONNRESET)
+}
@@ -0,0 +1,111 @@
+package retry_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/retry"
+)
+
+var errPermanent = errors.New("permanent failure")
+
+// scriptedTransport returns the scripted errors in order, then succeeds.
+type scriptedTransport struct {
+	errs  []error
+	calls int
+}
+
+func (s *scriptedTransport) RoundTrip(_ *http.Request) (*http.Response, error) {
+	s.calls++
+
+	if len(s.errs) > 0 {
+		err := s.errs[0]
+		s.errs = s.errs[1:]
+
+		return nil, err
+	}
+
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil //nolint:exhaustruct
+}
+
+// connectionReset builds the error shape net/http reports for a reset connection.
+func connectionReset() error {
+	return &net.OpError{ //nolint:exhaustruct
+		Op:  "read",
+		Net: "tcp",
+		Err: os.NewSyscallError("read", syscall.ECONNRESET),
+	}
+}
+
+func TestTransport_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		errs       []error
+		maxRetries int
+		wantErr    error
+		wantCalls  int
+	}{
+		{
+			name:       "reset recovers on retry",
+			errs:       []error{connectionReset()},
+			maxRetries: 2,
+			wantErr:    nil,
+			wantCalls:  2,
+		},
+		{
+			name:       "resets exhaust retries",
+			errs:       []error{connectionReset(), connectionReset(), connectionReset()},
+			maxRetries: 2,
+			wantErr:    syscall.ECONNRESET,
+			wantCalls:  3,
+		},
+		{
+			name:       "non-retriable error is returned immediately",
+			errs:       []error{errPermanent},
+			maxRetries: 2,
+			wantErr:    errPermanent,
+			wantCalls:  1,
+		},
+		{
+			name:       "retries disabled",
+			errs:       []error{connectionReset()},
+			maxRetries: 0,
+			wantErr:    syscall.ECONNRESET,
+			wantCalls:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			base := &scriptedTransport{errs: tt.errs, calls: 0}
+			client := &http.Client{Transport: retry.NewTransport(base, tt.maxRetries, 0)} //nolint:exhaustruct
+
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://example.test", nil)
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+
+			resp, err := client.Do(req)
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Do() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if base.calls != tt.wantCalls {
+				t.Errorf("round trips = %d, want %d", base.calls, tt.wantCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,339 @@
+// Package route53 provides domain availability checking through the AWS Route 53 Domains
+// CheckDomainAvailability API. It authenticates with the standard AWS credential chain
+// (environment, shared config, instance profile) and signs its requests with SigV4. Its
+// Service implements namecheap.DomainChecker, so it plugs into the same tools and
+// decorators as the other providers.
+package route53
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/concurrency"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/limit"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/metrics"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/requestid"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/retry"
+	"go.uber.org/zap"
+)
+
+const (
+	// metricsProvider labels the metrics of this backend.
+	metricsProvider = "route53"
+
+	// DefaultRegion is the only region Route 53 Domains is served from.
+	DefaultRegion = "us-east-1"
+
+	// defaultRequestTimeout is the per-request HTTP timeout when Config.RequestTimeout is unset.
+	defaultRequestTimeout = 30 * time.Second
+	// retryBackoff is the delay before the first retry.
+	retryBackoff = 250 * time.Millisecond
+	// maxConcurrentChecks bounds the API requests in flight for one check, as the API
+	// checks one domain per call.
+	maxConcurrentChecks = 4
+
+	// signingName is the service name requests are signed for.
+	signingName = "route53domains"
+	// targetCheckDomainAvailability is the X-Amz-Target of the availability operation.
+	targetCheckDomainAvailability = "Route53Domains_v20140515.CheckDomainAvailability"
+	// contentTypeJSON is the content type of the JSON 1.1 protocol the API speaks.
+	contentTypeJSON = "application/x-amz-json-1.1"
+)
+
+// Values of the CheckDomainAvailability Availability enum.
+const (
+	availabilityAvailable             = "AVAILABLE"
+	availabilityAvailableReserved     = "AVAILABLE_RESERVED"
+	availabilityAvailablePreorder     = "AVAILABLE_PREORDER"
+	availabilityUnavailable           = "UNAVAILABLE"
+	availabilityUnavailablePremium    = "UNAVAILABLE_PREMIUM"
+	availabilityUnavailableRestricted = "UNAVAILABLE_RESTRICTED"
+	availabilityReserved              = "RESERVED"
+)
+
+var (
+	// ErrAPIError is returned when the Route 53 Domains API answers with an error status.
+	ErrAPIError = errors.New("route 53 domains API error")
+	// ErrUnknownAvailability is reported per domain for an Availability that gives no
+	// answer, such as DONT_KNOW or PENDING.
+	ErrUnknownAvailability = errors.New("route 53 domains couldn't tell the availability")
+)
+
+// Config holds the configuration for the Route 53 Domains API client.
+type Config struct {
+	// Region is the AWS region of the API; empty uses DefaultRegion
+	Region string
+	// Endpoint is the base URL of the API; empty uses the regional endpoint
+	Endpoint string
+	// Credentials, when set, replaces the default AWS credential chain, e.g. in tests
+	Credentials aws.CredentialsProvider
+	// RequestTimeout bounds each API request; zero uses a 30 second default
+	RequestTimeout time.Duration
+	// MaxRetries is how many times a transient network failure is retried; zero disables retries
+	MaxRetries int
+	// RateLimitPerSecond caps outbound API requests per second; zero or less means unlimited
+	RateLimitPerSecond float64
+	// IncludeRaw attaches the raw Availability of each answer to its Result
+	IncludeRaw bool
+}
+
+// checkRequest is the body of a CheckDomainAvailability request.
+type checkRequest struct {
+	DomainName string `json:"DomainName"`
+}
+
+// checkResponse is the answer of a CheckDomainAvailability request.
+type checkResponse struct {
+	Availability string `json:"Availability"`
+}
+
+// apiError is the body of a failed request.
+type apiError struct {
+	Type    string `json:"__type"`
+	Message string `json:"message"`
+}
+
+// Service checks domain availability using the Route 53 Domains API.
+type Service struct {
+	logger      *zap.Logger
+	config      Config
+	client      *http.Client
+	credentials aws.CredentialsProvider
+	signer      *v4.Signer
+}
+
+// NewService creates a new Route 53 Domains service with the given configuration,
+// resolving the credentials through the default AWS chain unless Config.Credentials is
+// set. The credentials themselves are only retrieved when a request is made.
+func NewService(ctx context.Context, logger *zap.Logger, config Config) (*Service, error) {
+	if config.Region == "" {
+		config.Region = DefaultRegion
+	}
+
+	if config.Endpoint == "" {
+		config.Endpoint = "https://route53domains." + config.Region + ".amazonaws.com"
+	}
+
+	credentials := config.Credentials
+	if credentials == nil {
+		awsCfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(config.Region))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+		}
+
+		credentials = awsCfg.Credentials
+	}
+
+	timeout := config.RequestTimeout
+	if timeout <= 0 {
+		timeout = defaultRequestTimeout
+	}
+
+	return &Service{
+		logger: logger,
+		config: config,
+		client: &http.Client{ //nolint:exhaustruct
+			Timeout: timeout,
+			Transport: retry.NewTransport(
+				limit.NewTransport(http.DefaultTransport, config.RateLimitPerSecond), config.MaxRetries, retryBackoff),
+		},
+		credentials: credentials,
+		signer:      v4.NewSigner(),
+	}, nil
+}
+
+// Name returns the name of the Route 53 Domains service.
+func (s *Service) Name() string {
+	return "check_availability_route53"
+}
+
+// Description returns a description of the Route 53 Domains service.
+func (s *Service) Description() string {
+	return "Check domain availability using AWS Route 53 Domains API"
+}
+
+// DomainsCheck checks each domain with its own CheckDomainAvailability call, at most
+// four at a time, returning the results in input order. A failed call is reported in
+// that domain's Result.Error; only a cancelled or expired ctx aborts the whole check.
+func (s *Service) DomainsCheck(ctx context.Context, domains []string) ([]namecheap.Result, error) {
+	if len(domains) == 0 {
+		return nil, namecheap.ErrMissingDomains
+	}
+
+	metrics.DomainsCheck(metricsProvider, len(domains))
+
+	results, err := concurrency.MapBounded(ctx, domains, maxConcurrentChecks, s.checkDomain)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	// checkErrs stays nil when every domain was checked.
+	var checkErrs concurrency.Errors
+
+	errors.As(err, &checkErrs)
+
+	for i, domain := range domains {
+		if checkErr := checkErrs.Err(i); checkErr != nil {
+			results[i] = namecheap.Result{ //nolint:exhaustruct
+				Domain:       domain,
+				Availability: namecheap.AvailabilityUnknown,
+				Status:       namecheap.StatusError,
+				Error:        checkErr.Error(),
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// VerifyCredentials makes a single availability check and returns the error when the
+// credentials can't be retrieved or are rejected.
+func (s *Service) VerifyCredentials(ctx context.Context) error {
+	_, err := s.check(ctx, "amazon.com")
+	if err != nil {
+		return fmt.Errorf("failed to verify credentials: %w", err)
+	}
+
+	return nil
+}
+
+// checkDomain checks one domain and maps its Availability to a Result.
+func (s *Service) checkDomain(ctx context.Context, domain string) (namecheap.Result, error) {
+	availability, err := s.check(ctx, domain)
+	if err != nil {
+		return namecheap.Result{}, err
+	}
+
+	result := namecheap.Result{
+		Domain:                    domain,
+		Available:                 false,
+		Availability:              namecheap.AvailabilityTaken,
+		Status:                    "",
+		IsPremiumName:             false,
+		PremiumRegistrationPrice:  0,
+		PremiumRenewalPrice:       0,
+		IcannFee:                  0,
+		EapFee:                    0,
+		StandardRegistrationPrice: 0,
+		Currency:                  "",
+		Error:                     "",
+		ErrorCode:                 0,
+		RegisterURL:               "",
+		Blocked:                   false,
+		BlockReason:               "",
+		CorrelationID:             "",
+		Input:                     "",
+		ReferencePrice:            0,
+		ReferencePriceDelta:       0,
+		Raw:                       nil,
+	}
+
+	switch availability {
+	case availabilityAvailable, availabilityAvailablePreorder:
+		result.Available = true
+		result.Availability = namecheap.AvailabilityAvailable
+		result.Status = namecheap.StatusAvailable
+	case availabilityUnavailable:
+		result.Status = namecheap.StatusRegistered
+	case availabilityUnavailablePremium:
+		// A premium name Route 53 doesn't sell.
+		result.IsPremiumName = true
+		result.Status = namecheap.StatusReserved
+	case availabilityAvailableReserved, availabilityReserved, availabilityUnavailableRestricted:
+		result.Status = namecheap.StatusReserved
+	default:
+		return namecheap.Result{}, fmt.Errorf("%w: %s", ErrUnknownAvailability, availability)
+	}
+
+	if s.config.IncludeRaw {
+		result.Raw = map[string]string{"availability": availability}
+	}
+
+	return result, nil
+}
+
+// check calls CheckDomainAvailability for domain and returns its Availability.
+func (s *Service) check(ctx context.Context, domain string) (string, error) {
+	body, err := json.Marshal(checkRequest{DomainName: domain})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := s.newSignedRequest(ctx, targetCheckDomainAvailability, body)
+	if err != nil {
+		return "", err
+	}
+
+	s.logger.Debug("Making Route 53 Domains API call", zap.String("domain", domain), requestid.Field(ctx))
+
+	start := time.Now()
+	resp, err := s.client.Do(req)
+	metrics.UpstreamRequest(metricsProvider, time.Since(start), resp, err)
+
+	if err != nil {
+		return "", fmt.Errorf("HTTP request failed: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr apiError
+
+		_ = json.NewDecoder(resp.Body).Decode(&apiErr)
+
+		// __type may be namespaced, e.g. "com.amazonaws...#InvalidInput".
+		errType := apiErr.Type[strings.LastIndex(apiErr.Type, "#")+1:]
+
+		return "", fmt.Errorf("%w: %d %s: %s", ErrAPIError, resp.StatusCode, errType, apiErr.Message)
+	}
+
+	var checkResp checkResponse
+
+	err = json.NewDecoder(resp.Body).Decode(&checkResp)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode JSON response: %w", err)
+	}
+
+	return checkResp.Availability, nil
+}
+
+// newSignedRequest builds a JSON 1.1 request for target and signs it with SigV4.
+func (s *Service) newSignedRequest(ctx context.Context, target string, body []byte) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(s.config.Endpoint, "/")+"/",
+		bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", contentTypeJSON)
+	req.Header.Set("X-Amz-Target", target)
+
+	credentials, err := s.credentials.Retrieve(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve AWS credentials: %w", err)
+	}
+
+	payloadHash := sha256.Sum256(body)
+
+	err = s.signer.SignHTTP(ctx, credentials, req, hex.EncodeToString(payloadHash[:]), signingName,
+		s.config.Region, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign request: %w", err)
+	}
+
+	return req, nil
+}
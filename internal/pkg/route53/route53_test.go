@@ -0,0 +1,145 @@
+package route53_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/route53"
+	"go.uber.org/zap"
+)
+
+// answers are the stubbed Availability values, keyed by domain. Domains not listed are
+// rejected as invalid input.
+//
+//nolint:gochecknoglobals
+var answers = map[string]string{
+	"fresh.com":   "AVAILABLE",
+	"taken.com":   "UNAVAILABLE",
+	"premium.com": "UNAVAILABLE_PREMIUM",
+	"held.com":    "RESERVED",
+	"unsure.com":  "DONT_KNOW",
+	"amazon.com":  "UNAVAILABLE",
+}
+
+func newTestService(t *testing.T, accessKeyID string) *route53.Service {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization := r.Header.Get("Authorization")
+		if !strings.Contains(authorization, "Credential=AKID/") ||
+			!strings.Contains(authorization, "/us-east-1/route53domains/aws4_request") {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"__type":"UnrecognizedClientException","message":"The security token is invalid"}`))
+
+			return
+		}
+
+		if r.Header.Get("X-Amz-Target") != "Route53Domains_v20140515.CheckDomainAvailability" {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		var req struct {
+			DomainName string `json:"DomainName"`
+		}
+
+		_ = json.NewDecoder(r.Body).Decode(&req)
+
+		availability, ok := answers[req.DomainName]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"__type":"com.amazonaws.route53domains#InvalidInput","message":"bad TLD"}`))
+
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]string{"Availability": availability})
+	}))
+	t.Cleanup(server.Close)
+
+	service, err := route53.NewService(context.Background(), zap.NewNop(), route53.Config{
+		Region:   "",
+		Endpoint: server.URL,
+		Credentials: aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+			return aws.Credentials{AccessKeyID: accessKeyID, SecretAccessKey: "secret"}, nil //nolint:exhaustruct
+		}),
+		RequestTimeout:     0,
+		MaxRetries:         0,
+		RateLimitPerSecond: 0,
+		IncludeRaw:         true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+
+	return service
+}
+
+func TestDomainsCheck(t *testing.T) {
+	t.Parallel()
+
+	domains := []string{"fresh.com", "taken.com", "premium.com", "held.com", "unsure.com", "bad.zz"}
+
+	want := []struct {
+		available    bool
+		availability string
+		status       string
+		wantError    bool
+	}{
+		{available: true, availability: namecheap.AvailabilityAvailable, status: namecheap.StatusAvailable, wantError: false},
+		{available: false, availability: namecheap.AvailabilityTaken, status: namecheap.StatusRegistered, wantError: false},
+		{available: false, availability: namecheap.AvailabilityTaken, status: namecheap.StatusReserved, wantError: false},
+		{available: false, availability: namecheap.AvailabilityTaken, status: namecheap.StatusReserved, wantError: false},
+		{available: false, availability: namecheap.AvailabilityUnknown, status: namecheap.StatusError, wantError: true},
+		{available: false, availability: namecheap.AvailabilityUnknown, status: namecheap.StatusError, wantError: true},
+	}
+
+	results, err := newTestService(t, "AKID").DomainsCheck(context.Background(), domains)
+	if err != nil {
+		t.Fatalf("DomainsCheck() unexpected error: %v", err)
+	}
+
+	if len(results) != len(domains) {
+		t.Fatalf("DomainsCheck() returned %d results, want %d", len(results), len(domains))
+	}
+
+	for i, got := range results {
+		expected := want[i]
+
+		if got.Domain != domains[i] || got.Available != expected.available ||
+			got.Availability != expected.availability || got.Status != expected.status ||
+			(got.Error != "") != expected.wantError {
+			t.Errorf("results[%d] = %+v, want %+v", i, got, expected)
+		}
+	}
+
+	if !results[2].IsPremiumName || results[2].Raw["availability"] != "UNAVAILABLE_PREMIUM" {
+		t.Errorf("results[2] = %+v, want a premium name with its raw availability", results[2])
+	}
+
+	if !strings.Contains(results[5].Error, "InvalidInput: bad TLD") {
+		t.Errorf("results[5].Error = %q, want the API error", results[5].Error)
+	}
+}
+
+func TestVerifyCredentials(t *testing.T) {
+	t.Parallel()
+
+	err := newTestService(t, "AKID").VerifyCredentials(context.Background())
+	if err != nil {
+		t.Errorf("VerifyCredentials() unexpected error: %v", err)
+	}
+
+	err = newTestService(t, "WRONG").VerifyCredentials(context.Background())
+	if !errors.Is(err, route53.ErrAPIError) {
+		t.Errorf("VerifyCredentials() error = %v, want %v", err, route53.ErrAPIError)
+	}
+}
@@ -0,0 +1,169 @@
+package suggest
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/concurrency"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/tool"
+)
+
+const (
+	// alternativesChunkSize is how many candidates go into one DomainsCheck call.
+	alternativesChunkSize = 50
+	// maxConcurrentChunks bounds the DomainsCheck calls in flight for one lookup.
+	maxConcurrentChunks = 4
+)
+
+// popularTLDs ranks TLDs by how widely they are used, most popular first. TLDs not
+// listed rank after all of these, alphabetically.
+//
+//nolint:gochecknoglobals
+var popularTLDs = []string{
+	"com", "net", "org", "io", "co", "ai", "app", "dev", "me", "info",
+	"xyz", "online", "site", "tech", "store", "shop", "biz", "us", "uk", "de",
+}
+
+// AlternativesParamsIn represents the input parameters for an alternative TLD lookup.
+type AlternativesParamsIn struct {
+	// Name is the base name, either bare or with the TLD that was taken
+	Name string `json:"name" jsonschema:"The base name, e.g. example or the taken example.com"`
+	// TLDs overrides the configured candidate TLDs
+	TLDs []string `json:"tlds,omitempty" jsonschema:"Candidate TLDs to try (default: server configured set)"`
+	// MaxResults caps the number of alternatives returned; zero returns all
+	MaxResults int `json:"maxResults,omitempty" jsonschema:"Return at most this many alternatives, most popular TLD first"`
+}
+
+// AlternativesParamsOut represents the available alternatives.
+type AlternativesParamsOut struct {
+	// Name is the sanitized base name that was checked
+	Name string `json:"name" jsonschema:"The base name that was checked"`
+	// Alternatives are the available domains, most popular TLD first
+	Alternatives []namecheap.Result `json:"alternatives" jsonschema:"Available domains, most popular TLD first"`
+	// Summary reports the total number of alternatives and whether the list was truncated
+	Summary Summary `json:"summary" jsonschema:"Total available alternatives and whether the list was truncated"`
+}
+
+// AlternativesService suggests available domains on other TLDs for a base name.
+type AlternativesService struct {
+	checker namecheap.DomainChecker
+	tlds    []string
+}
+
+// NewAlternativesService creates an alternatives service. tlds is the default set of
+// candidate TLDs checked when the caller doesn't provide any.
+func NewAlternativesService(checker namecheap.DomainChecker, tlds []string) *AlternativesService {
+	return &AlternativesService{
+		checker: checker,
+		tlds:    tlds,
+	}
+}
+
+// Name returns the name of the alternatives service.
+func (a *AlternativesService) Name() string {
+	return "suggest_alternatives"
+}
+
+// Description returns a description of the alternatives service.
+func (a *AlternativesService) Description() string {
+	return "Suggest available alternatives for a taken domain: check the base name on other TLDs and " +
+		"return only the available ones, most popular TLD first"
+}
+
+// Execute checks the base name on every candidate TLD and returns the available domains
+// ranked by TLD popularity, at most MaxResults of them. When the name carries a TLD, that
+// TLD isn't suggested back.
+func (a *AlternativesService) Execute(ctx context.Context, in AlternativesParamsIn) (AlternativesParamsOut, error) {
+	base, taken, _ := strings.Cut(namecheap.NormalizeDomain(in.Name), ".")
+
+	name := sanitizeLabel(base)
+	if name == "" {
+		return AlternativesParamsOut{}, fmt.Errorf("%w: %w", tool.ErrInvalidInput, ErrMissingName)
+	}
+
+	domains := make([]string, 0, len(a.tlds))
+	for _, tld := range resolveTLDs(in.TLDs, a.tlds) {
+		if tld != taken {
+			domains = append(domains, name+"."+tld)
+		}
+	}
+
+	if len(domains) == 0 {
+		return AlternativesParamsOut{Name: name, Alternatives: []namecheap.Result{}, Summary: Summary{}}, nil
+	}
+
+	results, err := a.check(ctx, domains)
+	if err != nil {
+		return AlternativesParamsOut{}, err
+	}
+
+	alternatives := make([]namecheap.Result, 0, len(results))
+
+	for _, result := range results {
+		if result.Available && result.Error == "" {
+			alternatives = append(alternatives, result)
+		}
+	}
+
+	slices.SortStableFunc(alternatives, func(x, y namecheap.Result) int {
+		return compareTLDPopularity(tldOf(x.Domain), tldOf(y.Domain))
+	})
+
+	alternatives, summary := truncate(alternatives, in.MaxResults)
+
+	return AlternativesParamsOut{Name: name, Alternatives: alternatives, Summary: summary}, nil
+}
+
+// check checks domains in chunks of alternativesChunkSize, at most maxConcurrentChunks
+// at a time, so long candidate lists don't wait on a single backend call. A failed chunk
+// only drops its own candidates; the error is returned when every chunk failed.
+func (a *AlternativesService) check(ctx context.Context, domains []string) ([]namecheap.Result, error) {
+	chunks := slices.Collect(slices.Chunk(domains, alternativesChunkSize))
+
+	chunkResults, err := concurrency.MapBounded(ctx, chunks, maxConcurrentChunks,
+		func(ctx context.Context, chunk []string) ([]namecheap.Result, error) {
+			results, err := a.checker.DomainsCheck(ctx, chunk)
+			if namecheap.IsPartial(err) {
+				// The failed domains carry their error and aren't suggested.
+				return results, nil
+			}
+
+			return results, err
+		})
+
+	var chunkErrs concurrency.Errors
+	if errors.As(err, &chunkErrs) && len(chunkErrs.Unwrap()) == len(chunks) {
+		return nil, chunkErrs[0]
+	}
+
+	return slices.Concat(chunkResults...), nil
+}
+
+// compareTLDPopularity orders TLDs by their popularTLDs rank, unranked ones last and
+// alphabetically.
+func compareTLDPopularity(x, y string) int {
+	rankX, rankY := slices.Index(popularTLDs, x), slices.Index(popularTLDs, y)
+
+	switch {
+	case rankX >= 0 && rankY >= 0:
+		return cmp.Compare(rankX, rankY)
+	case rankX >= 0:
+		return -1
+	case rankY >= 0:
+		return 1
+	default:
+		return strings.Compare(x, y)
+	}
+}
+
+// tldOf returns everything after the first label of domain, e.g. "co.uk" for "acme.co.uk".
+func tldOf(domain string) string {
+	_, tld, _ := strings.Cut(strings.ToLower(domain), ".")
+
+	return tld
+}
@@ -0,0 +1,141 @@
+package suggest_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/suggest"
+)
+
+func TestAlternativesService_Execute(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		in          suggest.AlternativesParamsIn
+		wantChecked []string
+		wantDomains []string
+	}{
+		{
+			name:        "ranked by popularity",
+			in:          suggest.AlternativesParamsIn{Name: "acme", TLDs: nil, MaxResults: 0},
+			wantChecked: []string{"acme.xyz", "acme.shop", "acme.dev", "acme.com", "acme.io", "acme.zz"},
+			wantDomains: []string{"acme.io", "acme.dev", "acme.xyz", "acme.shop", "acme.zz"},
+		},
+		{
+			name:        "taken TLD left out",
+			in:          suggest.AlternativesParamsIn{Name: "ACME.com", TLDs: nil, MaxResults: 0},
+			wantChecked: []string{"acme.xyz", "acme.shop", "acme.dev", "acme.io", "acme.zz"},
+			wantDomains: []string{"acme.io", "acme.dev", "acme.xyz", "acme.shop", "acme.zz"},
+		},
+		{
+			name:        "requested TLDs",
+			in:          suggest.AlternativesParamsIn{Name: "acme", TLDs: []string{".zz", "IO", "com"}, MaxResults: 0},
+			wantChecked: []string{"acme.zz", "acme.io", "acme.com"},
+			wantDomains: []string{"acme.io", "acme.zz"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			checker := availableChecker(map[string]bool{
+				"acme.xyz":  true,
+				"acme.shop": true,
+				"acme.dev":  true,
+				"acme.io":   true,
+				"acme.zz":   true,
+			})
+
+			service := suggest.NewAlternativesService(checker, []string{"xyz", "shop", "dev", "com", "io", "zz"})
+
+			out, err := service.Execute(context.Background(), tt.in)
+			if err != nil {
+				t.Fatalf("Execute() unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(checker.Checked(), tt.wantChecked) {
+				t.Errorf("checked = %v, want %v", checker.Checked(), tt.wantChecked)
+			}
+
+			got := make([]string, 0, len(out.Alternatives))
+			for _, result := range out.Alternatives {
+				got = append(got, result.Domain)
+			}
+
+			if !reflect.DeepEqual(got, tt.wantDomains) {
+				t.Errorf("alternatives = %v, want %v", got, tt.wantDomains)
+			}
+
+			if out.Name != "acme" {
+				t.Errorf("Name = %q, want %q", out.Name, "acme")
+			}
+		})
+	}
+}
+
+func TestAlternativesService_MaxResults(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		maxResults  int
+		wantDomains []string
+		wantSummary suggest.Summary
+	}{
+		{
+			name:        "truncated to the most popular",
+			maxResults:  2,
+			wantDomains: []string{"acme.com", "acme.io"},
+			wantSummary: suggest.Summary{TotalMatched: 3, Truncated: true},
+		},
+		{
+			name:        "limit above the matches",
+			maxResults:  5,
+			wantDomains: []string{"acme.com", "acme.io", "acme.zz"},
+			wantSummary: suggest.Summary{TotalMatched: 3, Truncated: false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			checker := availableChecker(map[string]bool{"acme.com": true, "acme.io": true, "acme.zz": true})
+			service := suggest.NewAlternativesService(checker, []string{"zz", "io", "com"})
+
+			out, err := service.Execute(context.Background(),
+				suggest.AlternativesParamsIn{Name: "acme", TLDs: nil, MaxResults: tt.maxResults})
+			if err != nil {
+				t.Fatalf("Execute() unexpected error: %v", err)
+			}
+
+			got := make([]string, 0, len(out.Alternatives))
+			for _, result := range out.Alternatives {
+				got = append(got, result.Domain)
+			}
+
+			if !reflect.DeepEqual(got, tt.wantDomains) {
+				t.Errorf("alternatives = %v, want %v", got, tt.wantDomains)
+			}
+
+			if out.Summary != tt.wantSummary {
+				t.Errorf("Summary = %+v, want %+v", out.Summary, tt.wantSummary)
+			}
+		})
+	}
+}
+
+func TestAlternativesService_MissingName(t *testing.T) {
+	t.Parallel()
+
+	service := suggest.NewAlternativesService(availableChecker(nil), []string{"com"})
+
+	_, err := service.Execute(context.Background(), suggest.AlternativesParamsIn{Name: "  ", TLDs: nil, MaxResults: 0})
+	if !errors.Is(err, suggest.ErrMissingName) {
+		t.Errorf("Execute() error = %v, want %v", err, suggest.ErrMissingName)
+	}
+}
@@ -0,0 +1,246 @@
+package suggest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/tool"
+)
+
+const (
+	// maxKeywords is the number of extracted keywords used to build names.
+	maxKeywords = 4
+	// maxPairedKeywords is the number of top keywords combined pairwise into names.
+	maxPairedKeywords = 3
+	// minKeywordLength drops words too short to make a meaningful name.
+	minKeywordLength = 3
+)
+
+// ErrMissingDescription is returned when the description yields no keywords.
+var ErrMissingDescription = errors.New("missing description keywords")
+
+// KeywordExtractor picks the keywords of a free-text description, most relevant first.
+type KeywordExtractor interface {
+	// Keywords returns at most limit keywords with their relevance weight.
+	Keywords(text string, limit int) []Keyword
+}
+
+// Keyword is an extracted keyword and its relevance weight.
+type Keyword struct {
+	// Word is the extracted keyword
+	Word string `json:"word" jsonschema:"The extracted keyword"`
+	// Weight is the keyword's relevance, higher is more relevant
+	Weight int `json:"weight" jsonschema:"Relevance of the keyword"`
+}
+
+// FrequencyExtractor is a deterministic KeywordExtractor: it removes stopwords and
+// ranks the remaining words by frequency, breaking ties by first occurrence.
+type FrequencyExtractor struct {
+	stopwords map[string]struct{}
+}
+
+// NewFrequencyExtractor creates a frequency extractor using the bundled English stopwords.
+func NewFrequencyExtractor() *FrequencyExtractor {
+	stopwords := make(map[string]struct{})
+
+	for word := range strings.FieldsSeq(englishStopwords) {
+		stopwords[word] = struct{}{}
+	}
+
+	return &FrequencyExtractor{stopwords: stopwords}
+}
+
+// Keywords returns the most frequent non-stopwords of text.
+func (f *FrequencyExtractor) Keywords(text string, limit int) []Keyword {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	counts := make(map[string]int)
+	order := make([]string, 0, len(words))
+
+	for _, word := range words {
+		word = sanitizeLabel(word)
+		if len(word) < minKeywordLength {
+			continue
+		}
+
+		if _, ok := f.stopwords[word]; ok {
+			continue
+		}
+
+		if counts[word] == 0 {
+			order = append(order, word)
+		}
+
+		counts[word]++
+	}
+
+	// order is in first-occurrence order, so a stable sort keeps ties deterministic.
+	slices.SortStableFunc(order, func(a, b string) int {
+		return counts[b] - counts[a]
+	})
+
+	keywords := make([]Keyword, 0, min(limit, len(order)))
+	for _, word := range order[:min(limit, len(order))] {
+		keywords = append(keywords, Keyword{Word: word, Weight: counts[word]})
+	}
+
+	return keywords
+}
+
+// englishStopwords are common words that never make useful domain names.
+const englishStopwords = `a about after all also an and any app are as at be because been
+best but by can company could do does easy every for from get gets has have help helps how
+into is it its just like make makes more most my new of on one or our out over people platform
+product service so such than that the their them then there these they this those through to
+tool up us use used uses using via was we what when where which while who will with without
+you your`
+
+// KeywordParamsIn represents the input parameters for description-based suggestions.
+type KeywordParamsIn struct {
+	// Description is the free-text description of the product or company
+	Description string `json:"description" jsonschema:"A sentence describing the product or company"`
+	// TLDs overrides the configured TLDs to combine each name with
+	TLDs []string `json:"tlds,omitempty" jsonschema:"TLDs to try, e.g. com,io (default: server configured set)"`
+	// MaxResults caps the number of suggestions returned; zero returns all
+	MaxResults int `json:"maxResults,omitempty" jsonschema:"Return at most this many suggestions, most relevant first"`
+}
+
+// KeywordSuggestion is an available domain generated from the description.
+type KeywordSuggestion struct {
+	// Result is the availability result of the domain
+	Result namecheap.Result `json:"result" jsonschema:"The availability result of the domain"`
+	// Keywords are the description keywords the name was built from
+	Keywords []string `json:"keywords" jsonschema:"Description keywords the name was built from"`
+	// Relevance is the combined weight of the keywords, higher is more relevant
+	Relevance int `json:"relevance" jsonschema:"Relevance score, higher is more relevant"`
+}
+
+// KeywordParamsOut represents the extracted keywords and the ranked available suggestions.
+type KeywordParamsOut struct {
+	// Keywords are the keywords extracted from the description
+	Keywords []Keyword `json:"keywords" jsonschema:"Keywords extracted from the description"`
+	// Suggestions are the available domains, most relevant first
+	Suggestions []KeywordSuggestion `json:"suggestions" jsonschema:"Available domains, most relevant first"`
+	// Summary reports the total number of suggestions and whether they were truncated
+	Summary Summary `json:"summary" jsonschema:"Total available suggestions and whether the list was truncated"`
+}
+
+// KeywordService suggests available domains built from the keywords of a description.
+type KeywordService struct {
+	checker   namecheap.DomainChecker
+	extractor KeywordExtractor
+	tlds      []string
+}
+
+// NewKeywordService creates a description suggestion service. tlds is the default set
+// of TLDs combined with every name when the caller doesn't provide any.
+func NewKeywordService(checker namecheap.DomainChecker, extractor KeywordExtractor, tlds []string) *KeywordService {
+	return &KeywordService{
+		checker:   checker,
+		extractor: extractor,
+		tlds:      tlds,
+	}
+}
+
+// Name returns the name of the description suggestion service.
+func (k *KeywordService) Name() string {
+	return "suggest_domains_from_description"
+}
+
+// Description returns a description of the description suggestion service.
+func (k *KeywordService) Description() string {
+	return "Extract keywords from a product or company description, build candidate domains from them " +
+		"and return the available ones ranked by relevance"
+}
+
+// candidate is a name built from one or two keywords.
+type candidate struct {
+	name      string
+	keywords  []string
+	relevance int
+}
+
+// Execute extracts keywords, checks the generated domains and returns the available ones by relevance.
+func (k *KeywordService) Execute(ctx context.Context, in KeywordParamsIn) (KeywordParamsOut, error) {
+	keywords := k.extractor.Keywords(in.Description, maxKeywords)
+	if len(keywords) == 0 {
+		return KeywordParamsOut{}, fmt.Errorf("%w: %w", tool.ErrInvalidInput, ErrMissingDescription)
+	}
+
+	tlds := resolveTLDs(in.TLDs, k.tlds)
+
+	candidates := buildCandidates(keywords)
+
+	domains := make([]string, 0, len(candidates)*len(tlds))
+	byDomain := make(map[string]candidate, len(candidates)*len(tlds))
+
+	for _, c := range candidates {
+		for _, tld := range tlds {
+			domain := c.name + "." + tld
+			domains = append(domains, domain)
+			byDomain[domain] = c
+		}
+	}
+
+	results, err := k.checker.DomainsCheck(ctx, domains)
+	if err != nil && !namecheap.IsPartial(err) {
+		return KeywordParamsOut{}, err
+	}
+
+	suggestions := make([]KeywordSuggestion, 0, len(results))
+
+	for _, result := range results {
+		if !result.Available || result.Error != "" {
+			continue
+		}
+
+		c := byDomain[strings.ToLower(result.Domain)]
+		suggestions = append(suggestions, KeywordSuggestion{
+			Result:    result,
+			Keywords:  c.keywords,
+			Relevance: c.relevance,
+		})
+	}
+
+	slices.SortStableFunc(suggestions, func(a, b KeywordSuggestion) int {
+		return b.Relevance - a.Relevance
+	})
+
+	suggestions, summary := truncate(suggestions, in.MaxResults)
+
+	return KeywordParamsOut{Keywords: keywords, Suggestions: suggestions, Summary: summary}, nil
+}
+
+// buildCandidates returns each keyword on its own followed by pairs of the top keywords.
+func buildCandidates(keywords []Keyword) []candidate {
+	candidates := make([]candidate, 0, len(keywords)+maxPairedKeywords)
+
+	for _, keyword := range keywords {
+		candidates = append(candidates, candidate{
+			name:      keyword.Word,
+			keywords:  []string{keyword.Word},
+			relevance: keyword.Weight,
+		})
+	}
+
+	paired := keywords[:min(maxPairedKeywords, len(keywords))]
+
+	for i, first := range paired {
+		for _, second := range paired[i+1:] {
+			candidates = append(candidates, candidate{
+				name:      first.Word + second.Word,
+				keywords:  []string{first.Word, second.Word},
+				relevance: first.Weight + second.Weight,
+			})
+		}
+	}
+
+	return candidates
+}
@@ -0,0 +1,132 @@
+package suggest_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/suggest"
+)
+
+func TestKeywordService_Execute(t *testing.T) {
+	t.Parallel()
+
+	checker := availableChecker(map[string]bool{
+		"backup.com":      true,
+		"cloudbackup.com": true,
+		"secure.com":      true,
+	})
+
+	service := suggest.NewKeywordService(checker, suggest.NewFrequencyExtractor(), []string{"com"})
+
+	out, err := service.Execute(context.Background(), suggest.KeywordParamsIn{
+		Description: "Cloud backup for your photos. We make secure cloud photo backup easy!",
+		TLDs:        nil,
+		MaxResults:  0,
+	})
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+
+	wantKeywords := []suggest.Keyword{
+		{Word: "cloud", Weight: 2},
+		{Word: "backup", Weight: 2},
+		{Word: "photos", Weight: 1},
+		{Word: "secure", Weight: 1},
+	}
+	if !reflect.DeepEqual(out.Keywords, wantKeywords) {
+		t.Errorf("Keywords = %v, want %v", out.Keywords, wantKeywords)
+	}
+
+	wantChecked := []string{
+		"cloud.com", "backup.com", "photos.com", "secure.com",
+		"cloudbackup.com", "cloudphotos.com", "backupphotos.com",
+	}
+	if !reflect.DeepEqual(checker.Checked(), wantChecked) {
+		t.Errorf("checked = %v, want %v", checker.Checked(), wantChecked)
+	}
+
+	got := make([]string, 0, len(out.Suggestions))
+	for _, suggestion := range out.Suggestions {
+		got = append(got, suggestion.Result.Domain)
+	}
+
+	wantSuggestions := []string{"cloudbackup.com", "backup.com", "secure.com"}
+	if !reflect.DeepEqual(got, wantSuggestions) {
+		t.Errorf("suggestions = %v, want %v", got, wantSuggestions)
+	}
+}
+
+func TestKeywordService_MaxResults(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		maxResults  int
+		wantDomains []string
+		wantSummary suggest.Summary
+	}{
+		{
+			name:        "truncated to the most relevant",
+			maxResults:  2,
+			wantDomains: []string{"cloudbackup.com", "backup.com"},
+			wantSummary: suggest.Summary{TotalMatched: 3, Truncated: true},
+		},
+		{
+			name:        "limit above the matches",
+			maxResults:  5,
+			wantDomains: []string{"cloudbackup.com", "backup.com", "secure.com"},
+			wantSummary: suggest.Summary{TotalMatched: 3, Truncated: false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			checker := availableChecker(map[string]bool{
+				"backup.com":      true,
+				"cloudbackup.com": true,
+				"secure.com":      true,
+			})
+
+			service := suggest.NewKeywordService(checker, suggest.NewFrequencyExtractor(), []string{"com"})
+
+			out, err := service.Execute(context.Background(), suggest.KeywordParamsIn{
+				Description: "Cloud backup for your photos. We make secure cloud photo backup easy!",
+				TLDs:        nil,
+				MaxResults:  tt.maxResults,
+			})
+			if err != nil {
+				t.Fatalf("Execute() unexpected error: %v", err)
+			}
+
+			got := make([]string, 0, len(out.Suggestions))
+			for _, suggestion := range out.Suggestions {
+				got = append(got, suggestion.Result.Domain)
+			}
+
+			if !reflect.DeepEqual(got, tt.wantDomains) {
+				t.Errorf("suggestions = %v, want %v", got, tt.wantDomains)
+			}
+
+			if out.Summary != tt.wantSummary {
+				t.Errorf("Summary = %+v, want %+v", out.Summary, tt.wantSummary)
+			}
+		})
+	}
+}
+
+func TestKeywordService_MissingDescription(t *testing.T) {
+	t.Parallel()
+
+	service := suggest.NewKeywordService(availableChecker(nil),
+		suggest.NewFrequencyExtractor(), []string{"com"})
+
+	_, err := service.Execute(context.Background(),
+		suggest.KeywordParamsIn{Description: "the and of it", TLDs: nil, MaxResults: 0})
+	if !errors.Is(err, suggest.ErrMissingDescription) {
+		t.Errorf("Execute() error = %v, want %v", err, suggest.ErrMissingDescription)
+	}
+}
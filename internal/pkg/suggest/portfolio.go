@@ -0,0 +1,240 @@
+package suggest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/currency"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/tool"
+)
+
+// centsPerUnit converts prices to whole cents, so sums over the budget don't drift.
+const centsPerUnit = 100
+
+// ErrInvalidBudget is returned when the budget isn't a positive amount.
+var ErrInvalidBudget = errors.New("budget must be greater than zero")
+
+// TLDPricer quotes the standard one-year prices of TLDs in US dollars. *namecheap.Service
+// implements it.
+type TLDPricer interface {
+	TLDPricing(ctx context.Context, tlds []string) ([]namecheap.TLDPrice, error)
+}
+
+// PortfolioParamsIn represents the input parameters for a TLD portfolio plan.
+type PortfolioParamsIn struct {
+	// Name is the second-level name to register, without a TLD
+	Name string `json:"name" jsonschema:"The brand name to register, without a TLD, e.g. acme"`
+	// Budget is the most the portfolio may cost, in the service's currency
+	Budget float64 `json:"budget" jsonschema:"Maximum total one-year registration cost in the result currency"`
+	// TLDs overrides the configured TLDs, most important first
+	TLDs []string `json:"tlds,omitempty" jsonschema:"TLDs to consider, most important first (default: server set)"`
+}
+
+// PortfolioDomain is a domain selected for the portfolio.
+type PortfolioDomain struct {
+	// Domain is the domain to register
+	Domain string `json:"domain" jsonschema:"The domain to register"`
+	// Price is its one-year registration price
+	Price float64 `json:"price" jsonschema:"One-year registration price"`
+}
+
+// PortfolioParamsOut represents the selected portfolio.
+type PortfolioParamsOut struct {
+	// Name is the sanitized name that was checked
+	Name string `json:"name" jsonschema:"The name that was checked"`
+	// Domains are the selected domains, in TLD priority order
+	Domains []PortfolioDomain `json:"domains" jsonschema:"Selected domains, most important TLD first"`
+	// Total is the cost of the selected domains
+	Total float64 `json:"total" jsonschema:"Total one-year registration cost of the selection"`
+	// Remaining is the part of the budget left over
+	Remaining float64 `json:"remaining" jsonschema:"Budget left after the selection"`
+	// Skipped are the available domains left out because they didn't fit the budget or
+	// have no known price
+	Skipped []string `json:"skipped,omitempty" jsonschema:"Available domains that didn't fit or have no price"`
+	// Currency is the ISO 4217 code of the budget and prices
+	Currency string `json:"currency" jsonschema:"ISO 4217 currency of the budget and prices, e.g. USD"`
+}
+
+// PortfolioService picks the TLDs worth registering for a name within a budget.
+type PortfolioService struct {
+	checker  namecheap.DomainChecker
+	pricer   TLDPricer
+	tlds     []string
+	rates    currency.ExchangeRateProvider
+	currency string
+}
+
+// NewPortfolioService creates a portfolio service. pricer quotes the standard price of
+// non-premium domains; tlds is the default set, most important first.
+func NewPortfolioService(checker namecheap.DomainChecker, pricer TLDPricer, tlds []string) *PortfolioService {
+	return &PortfolioService{
+		checker:  checker,
+		pricer:   pricer,
+		tlds:     tlds,
+		rates:    nil,
+		currency: namecheap.CurrencyUSD,
+	}
+}
+
+// WithCurrency sets the currency of the budget and prices, e.g. the PRICE_CURRENCY the
+// checker converts its prices into; rates convert the prices quoted in other currencies,
+// such as the pricer's US dollar quotes. The default is US dollars.
+func (p *PortfolioService) WithCurrency(rates currency.ExchangeRateProvider, code string) *PortfolioService {
+	p.rates = rates
+	p.currency = strings.ToUpper(code)
+
+	return p
+}
+
+// Name returns the name of the portfolio service.
+func (p *PortfolioService) Name() string {
+	return "plan_tld_portfolio"
+}
+
+// Description returns a description of the portfolio service.
+func (p *PortfolioService) Description() string {
+	return "Check a brand name across TLDs and pick the available ones to register within a budget, " +
+		"most important TLD first"
+}
+
+// Execute checks the name across the TLDs and greedily selects the available domains in
+// TLD priority order, skipping any whose price no longer fits the remaining budget.
+// Domains cost the registration price of their result, or the pricer's standard TLD
+// price when the result has none, converted into the service's currency.
+func (p *PortfolioService) Execute(ctx context.Context, in PortfolioParamsIn) (PortfolioParamsOut, error) {
+	name := sanitizeLabel(in.Name)
+	if name == "" {
+		return PortfolioParamsOut{}, fmt.Errorf("%w: %w", tool.ErrInvalidInput, ErrMissingName)
+	}
+
+	if in.Budget <= 0 {
+		return PortfolioParamsOut{}, fmt.Errorf("%w: %w", tool.ErrInvalidInput, ErrInvalidBudget)
+	}
+
+	tlds := resolveTLDs(in.TLDs, p.tlds)
+
+	domains := make([]string, 0, len(tlds))
+	for _, tld := range tlds {
+		domains = append(domains, name+"."+tld)
+	}
+
+	results, err := p.checker.DomainsCheck(ctx, domains)
+	if err != nil && !namecheap.IsPartial(err) {
+		return PortfolioParamsOut{}, err
+	}
+
+	prices, err := p.standardPrices(ctx, tlds)
+	if err != nil {
+		return PortfolioParamsOut{}, err
+	}
+
+	available := make(map[string]namecheap.Result, len(results))
+
+	for _, result := range results {
+		if result.Available && result.Error == "" {
+			available[strings.ToLower(result.Domain)] = result
+		}
+	}
+
+	out := PortfolioParamsOut{
+		Name:      name,
+		Domains:   []PortfolioDomain{},
+		Total:     0,
+		Remaining: 0,
+		Skipped:   nil,
+		Currency:  p.currency,
+	}
+	remaining := toCents(in.Budget)
+	// rates holds the rate into the service's currency of each currency seen; zero marks
+	// a failed lookup.
+	rates := make(map[string]float64)
+
+	for i, tld := range tlds {
+		result, ok := available[domains[i]]
+		if !ok {
+			continue
+		}
+
+		price, quoted := result.RegistrationPrice(), result.Currency
+		if price <= 0 {
+			price, quoted = prices[tld], namecheap.CurrencyUSD
+		}
+
+		price = p.convert(ctx, rates, price, quoted)
+
+		cents := toCents(price)
+		if cents <= 0 || cents > remaining {
+			out.Skipped = append(out.Skipped, domains[i])
+
+			continue
+		}
+
+		out.Domains = append(out.Domains, PortfolioDomain{Domain: domains[i], Price: price})
+		out.Total = fromCents(toCents(out.Total) + cents)
+		remaining -= cents
+	}
+
+	out.Remaining = fromCents(remaining)
+
+	return out, nil
+}
+
+// convert returns amount, quoted in the given currency, in the service's currency, or
+// zero when there is no rate for it. Results without a currency are in US dollars.
+func (p *PortfolioService) convert(ctx context.Context, rates map[string]float64, amount float64,
+	quoted string,
+) float64 {
+	from := strings.ToUpper(quoted)
+	if from == "" {
+		from = namecheap.CurrencyUSD
+	}
+
+	if amount <= 0 || from == p.currency {
+		return amount
+	}
+
+	rate, ok := rates[from]
+	if !ok {
+		if p.rates != nil {
+			rate, _ = p.rates.Rate(ctx, from, p.currency)
+		}
+
+		rates[from] = rate
+	}
+
+	return namecheap.RoundCents(amount * rate)
+}
+
+func toCents(amount float64) int64 {
+	return int64(math.Round(amount * centsPerUnit))
+}
+
+func fromCents(cents int64) float64 {
+	return float64(cents) / centsPerUnit
+}
+
+// standardPrices returns the one-year registration price of every TLD that has one.
+func (p *PortfolioService) standardPrices(ctx context.Context, tlds []string) (map[string]float64, error) {
+	prices := make(map[string]float64, len(tlds))
+
+	if p.pricer == nil || len(tlds) == 0 {
+		return prices, nil
+	}
+
+	quotes, err := p.pricer.TLDPricing(ctx, tlds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to price TLDs: %w", err)
+	}
+
+	for _, quote := range quotes {
+		if quote.Error == "" && quote.Register > 0 {
+			prices[quote.TLD] = quote.Register
+		}
+	}
+
+	return prices, nil
+}
@@ -0,0 +1,181 @@
+package suggest_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/currency"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap/namecheaptest"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/suggest"
+)
+
+// fakePricer implements suggest.TLDPricer with fixed one-year prices.
+type fakePricer map[string]float64
+
+func (f fakePricer) TLDPricing(_ context.Context, tlds []string) ([]namecheap.TLDPrice, error) {
+	prices := make([]namecheap.TLDPrice, 0, len(tlds))
+	for _, tld := range tlds {
+		prices = append(prices, namecheap.TLDPrice{
+			TLD: tld, Currency: "USD", Register: f[tld], Renew: f[tld], Transfer: 0, Error: "",
+		})
+	}
+
+	return prices, nil
+}
+
+func TestPortfolioService_Execute(t *testing.T) {
+	t.Parallel()
+
+	pricer := fakePricer{"com": 10.28, "net": 12.98, "org": 7.48, "io": 39.98, "dev": 12.98}
+
+	tests := []struct {
+		name          string
+		budget        float64
+		wantDomains   []suggest.PortfolioDomain
+		wantTotal     float64
+		wantRemaining float64
+		wantSkipped   []string
+	}{
+		{
+			name:   "priority order wins over cheaper TLDs",
+			budget: 25,
+			wantDomains: []suggest.PortfolioDomain{
+				{Domain: "acme.com", Price: 10.28},
+				{Domain: "acme.net", Price: 12.98},
+			},
+			wantTotal:     23.26,
+			wantRemaining: 1.74,
+			wantSkipped:   []string{"acme.org", "acme.io", "acme.dev"},
+		},
+		{
+			name:   "a skipped TLD doesn't stop cheaper ones further down",
+			budget: 45,
+			wantDomains: []suggest.PortfolioDomain{
+				{Domain: "acme.com", Price: 10.28},
+				{Domain: "acme.net", Price: 12.98},
+				{Domain: "acme.org", Price: 7.48},
+				{Domain: "acme.dev", Price: 12.98},
+			},
+			wantTotal:     43.72,
+			wantRemaining: 1.28,
+			wantSkipped:   []string{"acme.io"},
+		},
+		{
+			name:          "budget below every price",
+			budget:        5,
+			wantDomains:   []suggest.PortfolioDomain{},
+			wantTotal:     0,
+			wantRemaining: 5,
+			wantSkipped:   []string{"acme.com", "acme.net", "acme.org", "acme.io", "acme.dev"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			checker := availableChecker(map[string]bool{
+				"acme.com": true, "acme.net": true, "acme.org": true, "acme.io": true, "acme.dev": true,
+			})
+
+			service := suggest.NewPortfolioService(checker, pricer, []string{"com", "net", "org", "io", "dev", "app"})
+
+			out, err := service.Execute(context.Background(),
+				suggest.PortfolioParamsIn{Name: "Acme", Budget: tt.budget, TLDs: nil})
+			if err != nil {
+				t.Fatalf("Execute() unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(out.Domains, tt.wantDomains) {
+				t.Errorf("Domains = %+v, want %+v", out.Domains, tt.wantDomains)
+			}
+
+			if out.Total != tt.wantTotal || out.Total > tt.budget {
+				t.Errorf("Total = %v, want %v within budget %v", out.Total, tt.wantTotal, tt.budget)
+			}
+
+			if out.Remaining != tt.wantRemaining {
+				t.Errorf("Remaining = %v, want %v", out.Remaining, tt.wantRemaining)
+			}
+
+			if !reflect.DeepEqual(out.Skipped, tt.wantSkipped) {
+				t.Errorf("Skipped = %v, want %v", out.Skipped, tt.wantSkipped)
+			}
+		})
+	}
+}
+
+func TestPortfolioService_WithCurrency(t *testing.T) {
+	t.Parallel()
+
+	premium := namecheaptest.Available("acme.com")
+	premium.IsPremiumName = true
+	premium.PremiumRegistrationPrice = 20
+	premium.Currency = "EUR"
+
+	standard := namecheaptest.Available("acme.org")
+	standard.StandardRegistrationPrice = 4
+	standard.Currency = "EUR"
+
+	// The checker's prices are already in euros; acme.net has none, so it costs the
+	// pricer's US dollar quote, converted.
+	checker := &namecheaptest.Checker{Answers: map[string]namecheap.Result{ //nolint:exhaustruct
+		"acme.com": premium,
+		"acme.net": namecheaptest.Available("acme.net"),
+		"acme.org": standard,
+	}}
+	pricer := fakePricer{"com": 10.28, "net": 10, "org": 7.48}
+
+	service := suggest.NewPortfolioService(checker, pricer, []string{"com", "net", "org"}).
+		WithCurrency(currency.StaticRates{"EUR": 0.5}, "eur")
+
+	out, err := service.Execute(context.Background(),
+		suggest.PortfolioParamsIn{Name: "acme", Budget: 30, TLDs: nil})
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+
+	wantDomains := []suggest.PortfolioDomain{
+		{Domain: "acme.com", Price: 20},
+		{Domain: "acme.net", Price: 5},
+		{Domain: "acme.org", Price: 4},
+	}
+	if !reflect.DeepEqual(out.Domains, wantDomains) {
+		t.Errorf("Domains = %+v, want %+v", out.Domains, wantDomains)
+	}
+
+	if out.Total != 29 || out.Remaining != 1 || out.Currency != "EUR" {
+		t.Errorf("Total = %v, Remaining = %v, Currency = %q; want 29, 1, EUR", out.Total, out.Remaining, out.Currency)
+	}
+}
+
+func TestPortfolioService_InvalidInput(t *testing.T) {
+	t.Parallel()
+
+	service := suggest.NewPortfolioService(availableChecker(nil), fakePricer{}, []string{"com"})
+
+	tests := []struct {
+		name    string
+		in      suggest.PortfolioParamsIn
+		wantErr error
+	}{
+		{name: "missing name", in: suggest.PortfolioParamsIn{Name: "..", Budget: 10, TLDs: nil},
+			wantErr: suggest.ErrMissingName},
+		{name: "zero budget", in: suggest.PortfolioParamsIn{Name: "acme", Budget: 0, TLDs: nil},
+			wantErr: suggest.ErrInvalidBudget},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := service.Execute(context.Background(), tt.in)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+// Package suggest provides MCP tools that brainstorm candidate domains and
+// report the ones that are available for registration.
+package suggest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/tool"
+)
+
+// maxSynonyms caps how many related words are expanded per keyword, keeping the
+// candidate list within a single check request for the default TLD set.
+const maxSynonyms = 8
+
+// ErrMissingKeyword is returned when no keyword is provided.
+var ErrMissingKeyword = errors.New("missing keyword")
+
+// SynonymProvider expands a keyword into related words.
+type SynonymProvider interface {
+	// Synonyms returns words related to keyword, excluding the keyword itself.
+	Synonyms(keyword string) []string
+}
+
+// Thesaurus is a static SynonymProvider keyed by lowercase keyword.
+type Thesaurus map[string][]string
+
+// Synonyms returns the thesaurus entry for keyword.
+func (t Thesaurus) Synonyms(keyword string) []string {
+	return t[strings.ToLower(keyword)]
+}
+
+// DefaultThesaurus returns the small thesaurus bundled with the server.
+func DefaultThesaurus() Thesaurus {
+	return Thesaurus{
+		"build":  {"make", "craft", "forge", "create", "assemble"},
+		"cloud":  {"sky", "nimbus", "vapor", "stratus"},
+		"data":   {"info", "facts", "insight", "metrics"},
+		"fast":   {"quick", "rapid", "swift", "speedy", "brisk"},
+		"green":  {"eco", "leaf", "verdant", "sprout"},
+		"home":   {"house", "nest", "haven", "dwelling"},
+		"money":  {"cash", "coin", "fund", "capital"},
+		"shop":   {"store", "market", "mart", "boutique"},
+		"smart":  {"clever", "bright", "wise", "sharp", "savvy"},
+		"travel": {"journey", "trip", "voyage", "roam", "trek"},
+	}
+}
+
+// SynonymParamsIn represents the input parameters for synonym-based suggestions.
+type SynonymParamsIn struct {
+	// Keyword is the word to expand into candidate names
+	Keyword string `json:"keyword" jsonschema:"The keyword to expand with synonyms, e.g. fast"`
+	// TLDs overrides the configured TLDs to combine each word with
+	TLDs []string `json:"tlds,omitempty" jsonschema:"TLDs to try, e.g. com,io (default: server configured set)"`
+	// MaxResults caps the number of domains returned across all groups; zero returns all
+	MaxResults int `json:"maxResults,omitempty" jsonschema:"Return at most this many domains across all groups"`
+}
+
+// SynonymGroup holds the available domains generated from one source word.
+type SynonymGroup struct {
+	// Synonym is the word the domains were generated from
+	Synonym string `json:"synonym" jsonschema:"The source word the domains were generated from"`
+	// Domains are the available domains for the word
+	Domains []namecheap.Result `json:"domains" jsonschema:"Available domains generated from the word"`
+}
+
+// SynonymParamsOut represents the available suggestions grouped by source word.
+type SynonymParamsOut struct {
+	// Groups contains one entry per word that produced an available domain
+	Groups []SynonymGroup `json:"groups" jsonschema:"Available domains grouped by source word"`
+	// Summary reports the total number of available domains and whether they were truncated
+	Summary Summary `json:"summary" jsonschema:"Total available domains and whether the groups were truncated"`
+}
+
+// SynonymService suggests available domains built from a keyword and its synonyms.
+type SynonymService struct {
+	checker  namecheap.DomainChecker
+	synonyms SynonymProvider
+	tlds     []string
+}
+
+// NewSynonymService creates a synonym suggestion service. tlds is the default set
+// of TLDs combined with every word when the caller doesn't provide any.
+func NewSynonymService(checker namecheap.DomainChecker, synonyms SynonymProvider, tlds []string) *SynonymService {
+	return &SynonymService{
+		checker:  checker,
+		synonyms: synonyms,
+		tlds:     tlds,
+	}
+}
+
+// Name returns the name of the synonym suggestion service.
+func (s *SynonymService) Name() string {
+	return "suggest_synonym_domains"
+}
+
+// Description returns a description of the synonym suggestion service.
+func (s *SynonymService) Description() string {
+	return "Expand a keyword with synonyms, check the resulting domains and return the available ones " +
+		"grouped by source word"
+}
+
+// Execute expands the keyword, checks every word across the TLDs and returns the available domains.
+func (s *SynonymService) Execute(ctx context.Context, in SynonymParamsIn) (SynonymParamsOut, error) {
+	keyword := sanitizeLabel(in.Keyword)
+	if keyword == "" {
+		return SynonymParamsOut{}, fmt.Errorf("%w: %w", tool.ErrInvalidInput, ErrMissingKeyword)
+	}
+
+	tlds := resolveTLDs(in.TLDs, s.tlds)
+
+	words := s.expand(keyword)
+
+	domains := make([]string, 0, len(words)*len(tlds))
+	sources := make(map[string]string, len(words)*len(tlds))
+
+	for _, word := range words {
+		for _, tld := range tlds {
+			domain := word + "." + tld
+			domains = append(domains, domain)
+			sources[domain] = word
+		}
+	}
+
+	results, err := s.checker.DomainsCheck(ctx, domains)
+	if err != nil && !namecheap.IsPartial(err) {
+		return SynonymParamsOut{}, err
+	}
+
+	available := make(map[string][]namecheap.Result, len(words))
+
+	for _, result := range results {
+		if !result.Available || result.Error != "" {
+			continue
+		}
+
+		word := sources[strings.ToLower(result.Domain)]
+		available[word] = append(available[word], result)
+	}
+
+	// Flatten in word order so truncation keeps the keyword's own domains first.
+	matched := make([]namecheap.Result, 0, len(results))
+	for _, word := range words {
+		matched = append(matched, available[word]...)
+	}
+
+	matched, summary := truncate(matched, in.MaxResults)
+
+	groups := make([]SynonymGroup, 0, len(available))
+
+	for _, result := range matched {
+		word := sources[strings.ToLower(result.Domain)]
+		if len(groups) == 0 || groups[len(groups)-1].Synonym != word {
+			groups = append(groups, SynonymGroup{Synonym: word, Domains: nil})
+		}
+
+		last := &groups[len(groups)-1]
+		last.Domains = append(last.Domains, result)
+	}
+
+	return SynonymParamsOut{Groups: groups, Summary: summary}, nil
+}
+
+// expand returns the keyword followed by its unique, sanitized synonyms.
+func (s *SynonymService) expand(keyword string) []string {
+	words := []string{keyword}
+	seen := map[string]struct{}{keyword: {}}
+
+	for _, synonym := range s.synonyms.Synonyms(keyword) {
+		if len(words) > maxSynonyms {
+			break
+		}
+
+		word := sanitizeLabel(synonym)
+		if _, ok := seen[word]; ok || word == "" {
+			continue
+		}
+
+		seen[word] = struct{}{}
+
+		words = append(words, word)
+	}
+
+	return words
+}
+
+// resolveTLDs returns the caller's TLDs, or defaults when there are none, normalized so
+// variants like "com", ".com" and "COM" collapse into a single entry.
+func resolveTLDs(tlds, defaults []string) []string {
+	normalized := normalizeTLDs(tlds)
+	if len(normalized) == 0 {
+		return normalizeTLDs(defaults)
+	}
+
+	return normalized
+}
+
+// normalizeTLDs trims, lowercases and strips the leading dot of every TLD, dropping
+// empty entries and duplicates while keeping the first-seen order.
+func normalizeTLDs(tlds []string) []string {
+	seen := make(map[string]struct{}, len(tlds))
+	normalized := make([]string, 0, len(tlds))
+
+	for _, tld := range tlds {
+		tld = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tld), "."))
+		if _, ok := seen[tld]; ok || tld == "" {
+			continue
+		}
+
+		seen[tld] = struct{}{}
+
+		normalized = append(normalized, tld)
+	}
+
+	return normalized
+}
+
+// sanitizeLabel lowercases s and drops everything that isn't valid in a domain label.
+func sanitizeLabel(s string) string {
+	var b strings.Builder
+
+	for _, r := range strings.ToLower(strings.TrimSpace(s)) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' {
+			b.WriteRune(r)
+		}
+	}
+
+	return strings.Trim(b.String(), "-")
+}
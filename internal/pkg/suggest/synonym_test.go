@@ -0,0 +1,121 @@
+package suggest_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap/namecheaptest"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/suggest"
+)
+
+// fakeSynonyms implements suggest.SynonymProvider with a fixed answer.
+type fakeSynonyms []string
+
+func (f fakeSynonyms) Synonyms(_ string) []string {
+	return f
+}
+
+// availableChecker returns a checker reporting the listed domains as available and the
+// rest as taken.
+func availableChecker(available map[string]bool) *namecheaptest.Checker {
+	return &namecheaptest.Checker{ //nolint:exhaustruct
+		Answer: func(domain string) namecheap.Result {
+			return namecheaptest.Result(domain, available[domain])
+		},
+	}
+}
+
+func TestSynonymService_Execute(t *testing.T) {
+	t.Parallel()
+
+	checker := availableChecker(map[string]bool{
+		"fast.io":   true,
+		"quick.com": true,
+		"quick.io":  true,
+	})
+
+	service := suggest.NewSynonymService(checker, fakeSynonyms{"Quick", "swift", "fast", "Quick"}, []string{"com", "io"})
+
+	out, err := service.Execute(context.Background(), suggest.SynonymParamsIn{Keyword: "Fast", TLDs: nil, MaxResults: 0})
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+
+	wantChecked := []string{"fast.com", "fast.io", "quick.com", "quick.io", "swift.com", "swift.io"}
+	if !reflect.DeepEqual(checker.Checked(), wantChecked) {
+		t.Errorf("checked = %v, want %v", checker.Checked(), wantChecked)
+	}
+
+	got := make(map[string][]string, len(out.Groups))
+	order := make([]string, 0, len(out.Groups))
+
+	for _, group := range out.Groups {
+		order = append(order, group.Synonym)
+
+		for _, result := range group.Domains {
+			got[group.Synonym] = append(got[group.Synonym], result.Domain)
+		}
+	}
+
+	want := map[string][]string{
+		"fast":  {"fast.io"},
+		"quick": {"quick.com", "quick.io"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("groups = %v, want %v", got, want)
+	}
+
+	if !reflect.DeepEqual(order, []string{"fast", "quick"}) {
+		t.Errorf("group order = %v, want keyword first", order)
+	}
+}
+
+func TestSynonymService_TLDOverride(t *testing.T) {
+	t.Parallel()
+
+	checker := availableChecker(nil)
+	service := suggest.NewSynonymService(checker, fakeSynonyms{}, []string{"com"})
+
+	_, err := service.Execute(context.Background(), suggest.SynonymParamsIn{Keyword: "fast", TLDs: []string{"dev"}, MaxResults: 0})
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(checker.Checked(), []string{"fast.dev"}) {
+		t.Errorf("checked = %v, want [fast.dev]", checker.Checked())
+	}
+}
+
+func TestSynonymService_TLDVariantsCollapse(t *testing.T) {
+	t.Parallel()
+
+	checker := availableChecker(nil)
+	service := suggest.NewSynonymService(checker, fakeSynonyms{}, []string{"net"})
+
+	_, err := service.Execute(context.Background(), suggest.SynonymParamsIn{
+		Keyword:    "example",
+		TLDs:       []string{"com", ".com", " COM "},
+		MaxResults: 0,
+	})
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(checker.Checked(), []string{"example.com"}) {
+		t.Errorf("checked = %v, want a single [example.com] check", checker.Checked())
+	}
+}
+
+func TestSynonymService_MissingKeyword(t *testing.T) {
+	t.Parallel()
+
+	service := suggest.NewSynonymService(availableChecker(nil), suggest.DefaultThesaurus(), nil)
+
+	_, err := service.Execute(context.Background(), suggest.SynonymParamsIn{Keyword: " !! ", TLDs: nil, MaxResults: 0})
+	if !errors.Is(err, suggest.ErrMissingKeyword) {
+		t.Errorf("Execute() error = %v, want %v", err, suggest.ErrMissingKeyword)
+	}
+}
@@ -0,0 +1,97 @@
+package suggest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/tool"
+)
+
+// ErrMissingName is returned when no second-level name is provided.
+var ErrMissingName = errors.New("missing name")
+
+// AvailableTLDsParamsIn represents the input parameters for an available-TLD lookup.
+type AvailableTLDsParamsIn struct {
+	// Name is the second-level name to check, without a TLD
+	Name string `json:"name" jsonschema:"The brand name to check, without a TLD, e.g. acme"`
+	// TLDs overrides the configured TLDs to check the name against
+	TLDs []string `json:"tlds,omitempty" jsonschema:"TLDs to try, e.g. com,io (default: server configured set)"`
+}
+
+// AvailableTLDsParamsOut represents the TLDs where the name is available.
+type AvailableTLDsParamsOut struct {
+	// Name is the sanitized name that was checked
+	Name string `json:"name" jsonschema:"The name that was checked"`
+	// TLDs are the TLDs where the name is available, sorted alphabetically
+	TLDs []string `json:"tlds" jsonschema:"TLDs where the name is available, sorted alphabetically"`
+}
+
+// AvailableTLDsService lists the TLDs where a name is available for registration.
+type AvailableTLDsService struct {
+	checker namecheap.DomainChecker
+	tlds    []string
+}
+
+// NewAvailableTLDsService creates an available-TLD service. tlds is the default set
+// checked when the caller doesn't provide any.
+func NewAvailableTLDsService(checker namecheap.DomainChecker, tlds []string) *AvailableTLDsService {
+	return &AvailableTLDsService{
+		checker: checker,
+		tlds:    tlds,
+	}
+}
+
+// Name returns the name of the available-TLD service.
+func (a *AvailableTLDsService) Name() string {
+	return "list_available_tlds"
+}
+
+// Description returns a description of the available-TLD service.
+func (a *AvailableTLDsService) Description() string {
+	return "Check a brand name across TLDs and return the sorted list of TLDs where it is available, " +
+		"without prices or taken domains"
+}
+
+// Execute checks the name across every TLD in a single check and returns the available TLDs.
+func (a *AvailableTLDsService) Execute(ctx context.Context, in AvailableTLDsParamsIn) (AvailableTLDsParamsOut, error) {
+	name := sanitizeLabel(in.Name)
+	if name == "" {
+		return AvailableTLDsParamsOut{}, fmt.Errorf("%w: %w", tool.ErrInvalidInput, ErrMissingName)
+	}
+
+	tlds := resolveTLDs(in.TLDs, a.tlds)
+
+	domains := make([]string, 0, len(tlds))
+	byDomain := make(map[string]string, len(tlds))
+
+	for _, tld := range tlds {
+		domain := name + "." + tld
+		domains = append(domains, domain)
+		byDomain[domain] = tld
+	}
+
+	results, err := a.checker.DomainsCheck(ctx, domains)
+	if err != nil && !namecheap.IsPartial(err) {
+		return AvailableTLDsParamsOut{}, err
+	}
+
+	available := make([]string, 0, len(results))
+
+	for _, result := range results {
+		if !result.Available || result.Error != "" {
+			continue
+		}
+
+		if tld, ok := byDomain[strings.ToLower(result.Domain)]; ok {
+			available = append(available, tld)
+		}
+	}
+
+	slices.Sort(available)
+
+	return AvailableTLDsParamsOut{Name: name, TLDs: slices.Compact(available)}, nil
+}
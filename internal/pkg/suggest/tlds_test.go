@@ -0,0 +1,48 @@
+package suggest_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/suggest"
+)
+
+func TestAvailableTLDsService_Execute(t *testing.T) {
+	t.Parallel()
+
+	checker := availableChecker(map[string]bool{
+		"acme.io":  true,
+		"acme.dev": true,
+		"acme.app": true,
+	})
+
+	service := suggest.NewAvailableTLDsService(checker, []string{"com", "io", "dev", "net", "app"})
+
+	out, err := service.Execute(context.Background(), suggest.AvailableTLDsParamsIn{Name: " Acme ", TLDs: nil})
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+
+	wantChecked := []string{"acme.com", "acme.io", "acme.dev", "acme.net", "acme.app"}
+	if !reflect.DeepEqual(checker.Checked(), wantChecked) {
+		t.Errorf("checked = %v, want %v", checker.Checked(), wantChecked)
+	}
+
+	want := suggest.AvailableTLDsParamsOut{Name: "acme", TLDs: []string{"app", "dev", "io"}}
+	if !reflect.DeepEqual(out, want) {
+		t.Errorf("Execute() = %+v, want %+v", out, want)
+	}
+}
+
+func TestAvailableTLDsService_MissingName(t *testing.T) {
+	t.Parallel()
+
+	service := suggest.NewAvailableTLDsService(availableChecker(nil), []string{"com"})
+
+	_, err := service.Execute(context.Background(), suggest.AvailableTLDsParamsIn{Name: "..", TLDs: nil})
+	if !errors.Is(err, suggest.ErrMissingName) {
+		t.Errorf("Execute() error = %v, want %v", err, suggest.ErrMissingName)
+	}
+}
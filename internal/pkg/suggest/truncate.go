@@ -0,0 +1,23 @@
+package suggest
+
+// Summary reports how many available domains matched and whether the output was cut short.
+type Summary struct {
+	// TotalMatched is the number of available domains found, before truncation
+	TotalMatched int `json:"totalMatched" jsonschema:"Number of available domains found before truncation"`
+	// Truncated is true when only the first maxResults domains are returned
+	Truncated bool `json:"truncated" jsonschema:"True when more available domains exist than were returned"`
+}
+
+// truncate returns the first maxResults entries of sorted and the summary describing the
+// cut. A maxResults of zero or less keeps every entry.
+func truncate[T any](sorted []T, maxResults int) ([]T, Summary) {
+	summary := Summary{TotalMatched: len(sorted), Truncated: false}
+
+	if maxResults > 0 && len(sorted) > maxResults {
+		summary.Truncated = true
+
+		return sorted[:maxResults], summary
+	}
+
+	return sorted, summary
+}
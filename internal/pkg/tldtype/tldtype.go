@@ -0,0 +1,62 @@
+// Package tldtype classifies top-level domains into families: legacy generic TLDs,
+// country-code TLDs and the new generic TLDs delegated since 2013.
+package tldtype
+
+import "strings"
+
+// Type is the family a TLD belongs to.
+type Type string
+
+const (
+	// Generic is a legacy generic TLD such as com or org.
+	Generic Type = "gTLD"
+	// CountryCode is a two-letter country-code TLD such as de or io.
+	CountryCode Type = "ccTLD"
+	// NewGeneric is a generic TLD from the new gTLD program such as app or dev.
+	NewGeneric Type = "newgTLD"
+)
+
+// countryCodeLength is the length of ISO 3166 country-code TLDs.
+const countryCodeLength = 2
+
+// Order lists the types in the order groups are presented.
+//
+//nolint:gochecknoglobals
+var Order = []Type{Generic, CountryCode, NewGeneric}
+
+// Map holds known TLD types, keyed by lowercase TLD without the leading dot.
+type Map map[string]Type
+
+// Default returns the bundled TLD-type data. Only legacy gTLDs are listed; country
+// codes and new gTLDs are recognised by Classify's fallback rules.
+func Default() Map {
+	m := make(Map)
+
+	for _, tld := range strings.Fields(`aero asia biz cat com coop edu gov info int jobs mil
+		mobi museum name net org pro tel travel`) {
+		m[tld] = Generic
+	}
+
+	return m
+}
+
+// Classify returns the type of tld. TLDs missing from the map are country codes when
+// they have two letters and new gTLDs otherwise.
+func (m Map) Classify(tld string) Type {
+	tld = strings.ToLower(strings.TrimPrefix(tld, "."))
+
+	if t, ok := m[tld]; ok {
+		return t
+	}
+
+	if len(tld) == countryCodeLength {
+		return CountryCode
+	}
+
+	return NewGeneric
+}
+
+// OfDomain returns the type of the domain's TLD.
+func (m Map) OfDomain(domain string) Type {
+	return m.Classify(domain[strings.LastIndex(domain, ".")+1:])
+}
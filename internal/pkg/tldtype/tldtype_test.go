@@ -0,0 +1,35 @@
+package tldtype_test
+
+import (
+	"testing"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/tldtype"
+)
+
+func TestMap_Classify(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		tld  string
+		want tldtype.Type
+	}{
+		{tld: "com", want: tldtype.Generic},
+		{tld: ".ORG", want: tldtype.Generic},
+		{tld: "de", want: tldtype.CountryCode},
+		{tld: "io", want: tldtype.CountryCode},
+		{tld: "app", want: tldtype.NewGeneric},
+		{tld: "photography", want: tldtype.NewGeneric},
+	}
+
+	types := tldtype.Default()
+
+	for _, tt := range tests {
+		t.Run(tt.tld, func(t *testing.T) {
+			t.Parallel()
+
+			if got := types.Classify(tt.tld); got != tt.want {
+				t.Errorf("Classify(%q) = %q, want %q", tt.tld, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package tool
+
+import (
+	"context"
+	"sync"
+)
+
+// Result Meta keys.
+const (
+	// metaRawResponses is the key of the raw upstream responses of a call.
+	metaRawResponses = "rawResponses"
+	// metaRequestID is the key of the request ID of a call.
+	metaRequestID = "requestId"
+)
+
+// RawResponse is an upstream response body recorded during a tool call for debugging.
+type RawResponse struct {
+	// Provider is the backend that answered, e.g. namecheap
+	Provider string `json:"provider"`
+	// Command is the API command the response answers, if the backend has commands
+	Command string `json:"command,omitempty"`
+	// Body is the response body as received
+	Body string `json:"body"`
+}
+
+// rawResponsesKey is the context key of the rawResponses of a call.
+type rawResponsesKey struct{}
+
+// rawResponses collects the responses recorded by the concurrent requests of a call.
+type rawResponses struct {
+	mu        sync.Mutex
+	responses []RawResponse
+}
+
+// RecordRawResponse attaches body to the result Meta of the tool call ctx belongs to, so
+// parsing mismatches can be diagnosed without server log access. Outside a tool call it
+// does nothing. The body is attached as is, so it must not contain credentials.
+func RecordRawResponse(ctx context.Context, provider, command string, body []byte) {
+	raw, ok := ctx.Value(rawResponsesKey{}).(*rawResponses)
+	if !ok {
+		return
+	}
+
+	raw.mu.Lock()
+	defer raw.mu.Unlock()
+
+	raw.responses = append(raw.responses, RawResponse{Provider: provider, Command: command, Body: string(body)})
+}
+
+// withRawResponses returns a context collecting the responses passed to RecordRawResponse.
+func withRawResponses(ctx context.Context) (context.Context, *rawResponses) {
+	raw := &rawResponses{} //nolint:exhaustruct
+
+	return context.WithValue(ctx, rawResponsesKey{}, raw), raw
+}
+
+// recorded returns the responses recorded so far.
+func (r *rawResponses) recorded() []RawResponse {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.responses
+}
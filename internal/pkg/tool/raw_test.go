@@ -0,0 +1,75 @@
+package tool_test
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/tool"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+)
+
+// rawService records a raw response per call when record is set.
+type rawService struct {
+	record bool
+}
+
+func (s *rawService) Name() string {
+	return "raw"
+}
+
+func (s *rawService) Description() string {
+	return "records raw responses"
+}
+
+func (s *rawService) Execute(ctx context.Context, in mockInput) (mockOutput, error) {
+	if s.record {
+		tool.RecordRawResponse(ctx, "namecheap", "namecheap.domains.check", []byte("<ApiResponse/>"))
+	}
+
+	return mockOutput{Result: in.Value}, nil
+}
+
+func TestToolHandler_Meta(t *testing.T) {
+	t.Parallel()
+
+	result, _, err := tool.NewTool(zap.NewNop(), &rawService{record: true}).
+		Handler(context.Background(), nil, mockInput{Value: "x"})
+	if err != nil {
+		t.Fatalf("Handler() unexpected error: %v", err)
+	}
+
+	want := []tool.RawResponse{{Provider: "namecheap", Command: "namecheap.domains.check", Body: "<ApiResponse/>"}}
+	if got := result.Meta["rawResponses"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("Meta[rawResponses] = %#v, want %#v", got, want)
+	}
+
+	result, _, err = tool.NewTool(zap.NewNop(), &rawService{record: false}).
+		Handler(context.Background(), nil, mockInput{Value: "x"})
+	if err != nil {
+		t.Fatalf("Handler() unexpected error: %v", err)
+	}
+
+	if result.Meta != nil {
+		t.Errorf("Meta = %v, want nil when nothing was recorded", result.Meta)
+	}
+
+	req := &mcp.CallToolRequest{ //nolint:exhaustruct
+		Extra: &mcp.RequestExtra{Header: http.Header{"X-Request-Id": []string{"req-1"}}}, //nolint:exhaustruct
+	}
+
+	result, _, err = tool.NewTool(zap.NewNop(), &rawService{record: false}).Handler(context.Background(), req,
+		mockInput{Value: "x"})
+	if err != nil {
+		t.Fatalf("Handler() unexpected error: %v", err)
+	}
+
+	if got := result.Meta["requestId"]; got != "req-1" {
+		t.Errorf("Meta[requestId] = %v, want req-1", got)
+	}
+
+	// Outside a tool call, recording is a no-op.
+	tool.RecordRawResponse(context.Background(), "namecheap", "", []byte("<ApiResponse/>"))
+}
@@ -4,33 +4,97 @@ package tool
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/metrics"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/requestid"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/tracing"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
 )
 
+// Outcomes logged for each tool call.
+const (
+	outcomeSuccess   = "success"
+	outcomeToolError = "tool_error"
+	outcomeError     = "error"
+)
+
+// DefaultTimeout bounds the calls of a Tool whose context has no deadline.
+const DefaultTimeout = 60 * time.Second
+
+// ErrInvalidInput marks errors caused by the caller's arguments. Services wrap it so
+// Handler can report the problem back to the model as a tool error it can correct,
+// rather than failing the whole call.
+var ErrInvalidInput = errors.New("invalid input")
+
+// ClientError maps a sentinel error to the code and explanation reported to the client
+// when a tool call fails with it, so the assistant can tell bad input from an upstream
+// outage and react, instead of the whole call failing.
+type ClientError struct {
+	// Err is the sentinel, matched with errors.Is
+	Err error
+	// Code is a stable, machine-readable identifier, e.g. "missing_domains"
+	Code string
+	// Message explains the failure to the client and what to do about it
+	Message string
+}
+
+// DomainCounter is implemented by tool inputs that cover a number of domains, so the
+// count can be logged with the duration of each call.
+type DomainCounter interface {
+	DomainCount() int
+}
+
 // Service defines a generic interface for MCP tool services.
 type Service[In, Out any] interface {
 	// Name returns the unique identifier name of the service.
 	Name() string
 	// Description returns a human-readable description of the service.
 	Description() string
-	// Execute performs the service operation with the given input.
-	Execute(in In) (Out, error)
+	// Execute performs the service operation with the given input. ctx is the MCP
+	// request context and is cancelled when the client goes away.
+	Execute(ctx context.Context, in In) (Out, error)
 }
 
 // Tool wraps a service for integration with the Model Context Protocol (MCP).
 type Tool[In, Out any] struct {
-	service Service[In, Out]
+	logger       *zap.Logger
+	service      Service[In, Out]
+	clientErrors []ClientError
+	// timeout bounds calls whose context has no deadline; zero or less leaves them unbounded
+	timeout time.Duration
 }
 
-// NewTool creates a new Tool wrapper around a service.
-func NewTool[In, Out any](service Service[In, Out]) *Tool[In, Out] {
+// NewTool creates a new Tool wrapper around a service. Every call is logged to logger with
+// its duration and outcome. clientErrors lists the errors reported to the client as tool
+// errors, most specific first. Calls without a deadline are bounded by DefaultTimeout,
+// see WithTimeout.
+func NewTool[In, Out any](logger *zap.Logger, service Service[In, Out], clientErrors ...ClientError) *Tool[In, Out] {
 	return &Tool[In, Out]{
-		service: service,
+		logger:       logger,
+		service:      service,
+		clientErrors: clientErrors,
+		timeout:      DefaultTimeout,
 	}
 }
 
+// WithTimeout sets the deadline given to calls whose context has none, so a client that
+// never sets one can't keep a call running indefinitely; zero or less leaves such calls
+// unbounded. It returns t.
+func (t *Tool[In, Out]) WithTimeout(timeout time.Duration) *Tool[In, Out] {
+	t.timeout = timeout
+
+	return t
+}
+
 // Name returns the name of the tool.
 func (t *Tool[In, Out]) Name() string {
 	return t.service.Name()
@@ -42,33 +106,171 @@ func (t *Tool[In, Out]) Description() string {
 }
 
 // Handler processes requests via the Model Context Protocol.
+// Errors matching a ClientError are returned as an IsError result carrying its code and
+// explanation, and other errors wrapping ErrInvalidInput as an IsError result with the
+// error text; only the remaining, unexpected errors are returned as Go errors.
+// ctx is passed to the service so cancelling the request cancels its outbound calls.
+// Calls without a deadline get one, see WithTimeout.
+// Each call runs in a span that continues the trace of the HTTP request carrying it.
+// The request ID of the HTTP request carrying the call, see requestid.Middleware, is added
+// to its logs and to the result Meta under "requestId"; upstream responses recorded with
+// RecordRawResponse during the call are attached there under "rawResponses".
 func (t *Tool[In, Out]) Handler( //nolint:ireturn
-	_ context.Context,
-	_ *mcp.CallToolRequest,
+	ctx context.Context,
+	req *mcp.CallToolRequest,
 	args In,
 ) (*mcp.CallToolResult, Out, error) {
 	var zero Out
 
-	output, err := t.service.Execute(args)
+	if _, ok := ctx.Deadline(); !ok && t.timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, t.timeout)
+		defer cancel()
+	}
+
+	ctx, span := t.startSpan(ctx, req, args)
+	defer span.End()
+
+	ctx = withRequestID(ctx, req)
+	ctx, raw := withRawResponses(ctx)
+
+	start := time.Now()
+	output, err := t.service.Execute(ctx, args)
+	elapsed := time.Since(start)
+
 	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		for _, clientErr := range t.clientErrors {
+			if errors.Is(err, clientErr.Err) {
+				t.logCall(ctx, args, elapsed, outcomeToolError, err)
+
+				return withMeta(ctx, textResult(clientErr.format(err), true), raw), zero, nil
+			}
+		}
+
+		if errors.Is(err, ErrInvalidInput) {
+			t.logCall(ctx, args, elapsed, outcomeToolError, err)
+
+			return withMeta(ctx, textResult(err.Error(), true), raw), zero, nil
+		}
+
+		t.logCall(ctx, args, elapsed, outcomeError, err)
+
 		return nil, zero, err
 	}
 
+	t.logCall(ctx, args, elapsed, outcomeSuccess, nil)
+
 	jsonData, err := json.Marshal(output)
 	if err != nil {
 		return nil, zero, fmt.Errorf("error marshaling results to JSON: %w", err)
 	}
 
+	return withMeta(ctx, textResult(string(jsonData), false), raw), output, nil
+}
+
+// startSpan starts the span of a call. Over HTTP, the trace context of the request
+// headers becomes its parent.
+//
+//nolint:ireturn
+func (t *Tool[In, Out]) startSpan(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	args In,
+) (context.Context, trace.Span) {
+	if req != nil && req.Extra != nil && req.Extra.Header != nil {
+		ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(req.Extra.Header))
+	}
+
+	attributes := []attribute.KeyValue{attribute.String(tracing.AttrTool, t.service.Name())}
+	if counter, ok := any(args).(DomainCounter); ok {
+		attributes = append(attributes, attribute.Int(tracing.AttrDomainCount, counter.DomainCount()))
+	}
+
+	return tracing.Tracer().Start(ctx, "tool "+t.service.Name(),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attributes...),
+	)
+}
+
+// logCall logs a finished call with its duration, outcome, request ID and, when the input
+// reports one, its domain count, and counts it in the tool call metrics.
+func (t *Tool[In, Out]) logCall(ctx context.Context, args In, elapsed time.Duration, outcome string, err error) {
+	metrics.ToolCall(t.service.Name(), outcome)
+
+	fields := []zap.Field{
+		zap.String("tool", t.service.Name()),
+		zap.Duration("elapsed", elapsed),
+		zap.String("outcome", outcome),
+		requestid.Field(ctx),
+	}
+
+	if counter, ok := any(args).(DomainCounter); ok {
+		fields = append(fields, zap.Int("domain_count", counter.DomainCount()))
+	}
+
+	if err != nil {
+		fields = append(fields, zap.Error(err))
+	}
+
+	t.logger.Info("Tool call finished", fields...)
+}
+
+// format renders err for the client as "code: message", followed by the error itself.
+func (c ClientError) format(err error) string {
+	return fmt.Sprintf("%s: %s\ndetails: %s", c.Code, c.Message, err.Error())
+}
+
+// withMeta attaches the request ID of ctx and the responses recorded in raw, if any, to
+// the Meta of result.
+func withMeta(ctx context.Context, result *mcp.CallToolResult, raw *rawResponses) *mcp.CallToolResult {
+	meta := mcp.Meta{}
+
+	if id := requestid.FromContext(ctx); id != "" {
+		meta[metaRequestID] = id
+	}
+
+	if responses := raw.recorded(); len(responses) > 0 {
+		meta[metaRawResponses] = responses
+	}
+
+	if len(meta) > 0 {
+		result.Meta = meta
+	}
+
+	return result
+}
+
+// withRequestID returns ctx carrying the request ID of the HTTP request req came with,
+// which requestid.Middleware sets on its header. Calls without one, e.g. over stdio, keep
+// ctx unchanged.
+func withRequestID(ctx context.Context, req *mcp.CallToolRequest) context.Context {
+	if requestid.FromContext(ctx) != "" || req == nil || req.Extra == nil || req.Extra.Header == nil {
+		return ctx
+	}
+
+	if id := req.Extra.Header.Get(requestid.Header); requestid.Valid(id) {
+		return requestid.NewContext(ctx, id)
+	}
+
+	return ctx
+}
+
+// textResult builds a single-text-content result addressed to the assistant.
+func textResult(text string, isError bool) *mcp.CallToolResult {
 	return &mcp.CallToolResult{ //nolint:exhaustruct
-		IsError: false,
+		IsError: isError,
 		Content: []mcp.Content{
 			&mcp.TextContent{ //nolint:exhaustruct
-				Text: string(jsonData),
+				Text: text,
 				Annotations: &mcp.Annotations{ //nolint:exhaustruct
 					Audience: []mcp.Role{"assistant"},
 					Priority: 1,
 				},
 			},
 		},
-	}, output, nil
+	}
 }
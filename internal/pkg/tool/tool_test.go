@@ -4,10 +4,19 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/jsgv/mcp-domain-checker/internal/pkg/tool"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 // mockService implements tool.Service[In, Out] for testing.
@@ -33,7 +42,7 @@ func (m *mockService) Description() string {
 	return m.description
 }
 
-func (m *mockService) Execute(in mockInput) (mockOutput, error) {
+func (m *mockService) Execute(_ context.Context, in mockInput) (mockOutput, error) {
 	if m.executeFunc != nil {
 		return m.executeFunc(in)
 	}
@@ -52,7 +61,7 @@ func TestNewTool(t *testing.T) {
 		executeFunc: nil,
 	}
 
-	testTool := tool.NewTool(service)
+	testTool := tool.NewTool(zap.NewNop(), service)
 
 	if testTool == nil {
 		t.Fatal("NewTool() returned nil")
@@ -102,7 +111,7 @@ func TestToolNameAndDescription(t *testing.T) {
 				executeFunc: nil,
 			}
 
-			testTool := tool.NewTool(service)
+			testTool := tool.NewTool(zap.NewNop(), service)
 
 			if got := testTool.Name(); got != tt.wantName {
 				t.Errorf("Tool.Name() = %v, want %v", got, tt.wantName)
@@ -127,7 +136,7 @@ func TestToolHandler_Success(t *testing.T) {
 		},
 	}
 
-	testTool := tool.NewTool(service)
+	testTool := tool.NewTool(zap.NewNop(), service)
 
 	result, output, err := testTool.Handler(context.Background(), nil, mockInput{Value: "test-input"})
 	if err != nil {
@@ -196,7 +205,7 @@ func TestToolHandler_ServiceError(t *testing.T) {
 		},
 	}
 
-	testTool := tool.NewTool(service)
+	testTool := tool.NewTool(zap.NewNop(), service)
 
 	result, output, err := testTool.Handler(context.Background(), nil, mockInput{Value: "test"})
 
@@ -228,7 +237,7 @@ func (u *unmarshalableService) Description() string {
 	return "unmarshalable"
 }
 
-func (u *unmarshalableService) Execute(_ mockInput) (unmarshalableOutput, error) {
+func (u *unmarshalableService) Execute(_ context.Context, _ mockInput) (unmarshalableOutput, error) {
 	return unmarshalableOutput{Channel: make(chan int)}, nil
 }
 
@@ -236,7 +245,7 @@ func TestToolHandler_JSONMarshalError(t *testing.T) {
 	t.Parallel()
 
 	service := &unmarshalableService{}
-	testTool := tool.NewTool(service)
+	testTool := tool.NewTool(zap.NewNop(), service)
 
 	result, _, err := testTool.Handler(context.Background(), nil, mockInput{Value: "test"})
 	if err == nil {
@@ -246,4 +255,285 @@ func TestToolHandler_JSONMarshalError(t *testing.T) {
 	if result != nil {
 		t.Error("Handler() result should be nil on JSON marshal error")
 	}
-}
\ No newline at end of file
+}
+func TestToolHandler_InvalidInput(t *testing.T) {
+	t.Parallel()
+
+	service := &mockService{
+		name:        "test",
+		description: "test",
+		executeFunc: func(_ mockInput) (mockOutput, error) {
+			return mockOutput{Result: ""}, fmt.Errorf("%w: value is required", tool.ErrInvalidInput)
+		},
+	}
+
+	testTool := tool.NewTool(zap.NewNop(), service)
+
+	result, _, err := testTool.Handler(context.Background(), nil, mockInput{Value: ""})
+	if err != nil {
+		t.Fatalf("Handler() unexpected error: %v", err)
+	}
+
+	if result == nil || !result.IsError {
+		t.Fatalf("Handler() result = %+v, want IsError result", result)
+	}
+
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	if !ok {
+		t.Fatal("Handler() result.Content[0] is not TextContent")
+	}
+
+	if textContent.Text != "invalid input: value is required" {
+		t.Errorf("Handler() text = %q, want %q", textContent.Text, "invalid input: value is required")
+	}
+}
+
+var (
+	errUpstreamDown = errors.New("upstream down")
+	errMissingValue = errors.New("missing value")
+)
+
+func TestToolHandler_ClientErrors(t *testing.T) {
+	t.Parallel()
+
+	clientErrors := []tool.ClientError{
+		{Err: errMissingValue, Code: "missing_value", Message: "Pass a value."},
+		{Err: errUpstreamDown, Code: "upstream_unavailable", Message: "Try again shortly."},
+	}
+
+	tests := []struct {
+		name     string
+		err      error
+		wantText string
+		wantErr  error
+	}{
+		{
+			name:     "wrapped sentinel becomes a tool error",
+			err:      fmt.Errorf("API call failed: %w", errUpstreamDown),
+			wantText: "upstream_unavailable: Try again shortly.\ndetails: API call failed: upstream down",
+			wantErr:  nil,
+		},
+		{
+			name:     "mapping wins over invalid input",
+			err:      fmt.Errorf("%w: %w", tool.ErrInvalidInput, errMissingValue),
+			wantText: "missing_value: Pass a value.\ndetails: invalid input: missing value",
+			wantErr:  nil,
+		},
+		{
+			name:     "unmapped errors fail the call",
+			err:      errServiceError,
+			wantText: "",
+			wantErr:  errServiceError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			service := &mockService{
+				name:        "test",
+				description: "test",
+				executeFunc: func(_ mockInput) (mockOutput, error) {
+					return mockOutput{Result: ""}, tt.err
+				},
+			}
+
+			result, _, err := tool.NewTool(zap.NewNop(), service, clientErrors...).Handler(context.Background(), nil, mockInput{Value: ""})
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil) != (err == nil) {
+				t.Fatalf("Handler() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			if result == nil || !result.IsError {
+				t.Fatalf("Handler() result = %+v, want IsError result", result)
+			}
+
+			textContent, ok := result.Content[0].(*mcp.TextContent)
+			if !ok || textContent.Text != tt.wantText {
+				t.Errorf("Handler() content = %+v, want text %q", result.Content[0], tt.wantText)
+			}
+		})
+	}
+}
+
+// countedInput implements tool.DomainCounter.
+type countedInput struct {
+	Domains []string `json:"domains"`
+}
+
+func (c countedInput) DomainCount() int {
+	return len(c.Domains)
+}
+
+type countedService struct {
+	err error
+}
+
+func (c *countedService) Name() string {
+	return "counted"
+}
+
+func (c *countedService) Description() string {
+	return "counted"
+}
+
+func (c *countedService) Execute(_ context.Context, _ countedInput) (mockOutput, error) {
+	return mockOutput{Result: ""}, c.err
+}
+
+func TestToolHandler_LogsCalls(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		err         error
+		wantOutcome string
+	}{
+		{name: "success", err: nil, wantOutcome: "success"},
+		{name: "invalid input", err: fmt.Errorf("%w: bad domain", tool.ErrInvalidInput), wantOutcome: "tool_error"},
+		{name: "service error", err: errServiceError, wantOutcome: "error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			core, logs := observer.New(zap.InfoLevel)
+			testTool := tool.NewTool(zap.New(core), &countedService{err: tt.err})
+
+			_, _, _ = testTool.Handler(context.Background(), nil, countedInput{Domains: []string{"a.com", "b.com"}})
+
+			entries := logs.FilterMessage("Tool call finished").All()
+			if len(entries) != 1 {
+				t.Fatalf("logged %d call entries, want 1", len(entries))
+			}
+
+			fields := entries[0].ContextMap()
+			if fields["tool"] != "counted" || fields["outcome"] != tt.wantOutcome || fields["domain_count"] != int64(2) {
+				t.Errorf("fields = %v, want tool counted, outcome %s and domain_count 2", fields, tt.wantOutcome)
+			}
+
+			if _, ok := fields["elapsed"]; !ok {
+				t.Error("elapsed duration was not logged")
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Installs the global tracer provider and propagator.
+func TestToolHandler_TraceContext(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	service := &mockService{name: "traced-tool", description: "", executeFunc: nil}
+
+	header := http.Header{}
+	header.Set("Traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	req := &mcp.CallToolRequest{Session: nil, Params: nil, Extra: &mcp.RequestExtra{Header: header}} //nolint:exhaustruct
+
+	_, _, err := tool.NewTool(zap.NewNop(), service).Handler(context.Background(), req, mockInput{Value: "x"})
+	if err != nil {
+		t.Fatalf("Handler() unexpected error: %v", err)
+	}
+
+	var span sdktrace.ReadOnlySpan
+
+	for _, ended := range recorder.Ended() {
+		if ended.Name() == "tool traced-tool" {
+			span = ended
+		}
+	}
+
+	if span == nil {
+		t.Fatal("no span recorded for the tool call")
+	}
+
+	if got := span.Parent().TraceID().String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("parent trace ID = %s, want the one of the traceparent header", got)
+	}
+
+	if got := span.Parent().SpanID().String(); got != "00f067aa0ba902b7" {
+		t.Errorf("parent span ID = %s, want the one of the traceparent header", got)
+	}
+}
+
+// deadlineService reports the deadline of the context it is called with.
+type deadlineService struct {
+	deadline time.Time
+	ok       bool
+}
+
+func (s *deadlineService) Name() string {
+	return "deadline"
+}
+
+func (s *deadlineService) Description() string {
+	return "reports its deadline"
+}
+
+func (s *deadlineService) Execute(ctx context.Context, _ mockInput) (mockOutput, error) {
+	s.deadline, s.ok = ctx.Deadline()
+
+	return mockOutput{Result: ""}, nil
+}
+
+func TestToolHandler_DefaultsDeadline(t *testing.T) {
+	t.Parallel()
+
+	clientDeadline := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name       string
+		timeout    time.Duration
+		deadline   time.Time
+		wantOK     bool
+		wantWithin time.Duration
+	}{
+		{name: "no deadline gets the timeout", timeout: time.Minute, deadline: time.Time{}, wantOK: true,
+			wantWithin: time.Minute},
+		{name: "client deadline is kept", timeout: time.Minute, deadline: clientDeadline, wantOK: true,
+			wantWithin: time.Hour},
+		{name: "zero timeout leaves calls unbounded", timeout: 0, deadline: time.Time{}, wantOK: false,
+			wantWithin: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			if !tt.deadline.IsZero() {
+				var cancel context.CancelFunc
+
+				ctx, cancel = context.WithDeadline(ctx, tt.deadline)
+				defer cancel()
+			}
+
+			service := &deadlineService{deadline: time.Time{}, ok: false}
+
+			_, _, err := tool.NewTool(zap.NewNop(), service).WithTimeout(tt.timeout).Handler(ctx, nil, mockInput{Value: ""})
+			if err != nil {
+				t.Fatalf("Handler() unexpected error: %v", err)
+			}
+
+			if service.ok != tt.wantOK {
+				t.Fatalf("deadline set = %v, want %v", service.ok, tt.wantOK)
+			}
+
+			if tt.wantOK {
+				remaining := time.Until(service.deadline)
+				if remaining <= tt.wantWithin-time.Minute/2 || remaining > tt.wantWithin {
+					t.Errorf("deadline in %v, want about %v", remaining, tt.wantWithin)
+				}
+			}
+		})
+	}
+}
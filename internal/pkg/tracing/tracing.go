@@ -0,0 +1,95 @@
+// Package tracing sets up OpenTelemetry tracing. Tracing is off unless the standard OTEL_*
+// environment variables ask for an exporter; until then the global tracer provider is
+// OpenTelemetry's no-op one, so spans cost next to nothing.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies the server's spans.
+const instrumentationName = "github.com/jsgv/mcp-domain-checker"
+
+// Attribute keys shared by the instrumented packages.
+const (
+	AttrProvider    = "domain_checker.provider"
+	AttrDomainCount = "domain_checker.domain_count"
+	AttrTool        = "mcp.tool.name"
+)
+
+// Enabled reports whether the OTEL_* environment asks for trace export: an OTLP endpoint
+// is configured, or OTEL_TRACES_EXPORTER is otlp. OTEL_SDK_DISABLED=true and
+// OTEL_TRACES_EXPORTER=none turn tracing off.
+func Enabled() bool {
+	if strings.EqualFold(os.Getenv("OTEL_SDK_DISABLED"), "true") {
+		return false
+	}
+
+	switch strings.ToLower(os.Getenv("OTEL_TRACES_EXPORTER")) {
+	case "none":
+		return false
+	case "otlp":
+		return true
+	}
+
+	return os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+}
+
+// Setup installs the global tracer provider and the W3C trace context propagator when
+// Enabled, exporting spans over OTLP/HTTP as configured by the OTEL_EXPORTER_OTLP_*
+// variables. The service name, resource attributes and sampler come from OTEL_SERVICE_NAME,
+// OTEL_RESOURCE_ATTRIBUTES and OTEL_TRACES_SAMPLER. The returned function flushes and
+// stops the exporter; it is a no-op when tracing is disabled.
+func Setup(ctx context.Context, serviceName, version string) (func(context.Context) error, error) {
+	if !Enabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	// Environment attributes come last so OTEL_SERVICE_NAME overrides the default name.
+	res, err := resource.Merge(
+		resource.NewWithAttributes(semconv.SchemaURL,
+			semconv.ServiceName(serviceName),
+			semconv.ServiceVersion(version),
+		),
+		resource.Environment(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return provider.Shutdown, nil
+}
+
+// Tracer returns the server's tracer from the global provider.
+//
+//nolint:ireturn
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
@@ -0,0 +1,60 @@
+package tracing_test
+
+import (
+	"testing"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/tracing"
+)
+
+//nolint:paralleltest // Uses t.Setenv.
+func TestEnabled(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected bool
+	}{
+		{name: "off by default", env: map[string]string{}, expected: false},
+		{
+			name:     "OTLP endpoint",
+			env:      map[string]string{"OTEL_EXPORTER_OTLP_ENDPOINT": "http://collector:4318"},
+			expected: true,
+		},
+		{
+			name:     "OTLP traces endpoint",
+			env:      map[string]string{"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT": "http://collector:4318/v1/traces"},
+			expected: true,
+		},
+		{name: "otlp exporter", env: map[string]string{"OTEL_TRACES_EXPORTER": "otlp"}, expected: true},
+		{
+			name: "none exporter",
+			env: map[string]string{
+				"OTEL_TRACES_EXPORTER":        "none",
+				"OTEL_EXPORTER_OTLP_ENDPOINT": "http://collector:4318",
+			},
+			expected: false,
+		},
+		{
+			name: "SDK disabled",
+			env: map[string]string{
+				"OTEL_SDK_DISABLED":    "true",
+				"OTEL_TRACES_EXPORTER": "otlp",
+			},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{
+				"OTEL_SDK_DISABLED", "OTEL_TRACES_EXPORTER",
+				"OTEL_EXPORTER_OTLP_ENDPOINT", "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT",
+			} {
+				t.Setenv(name, tt.env[name])
+			}
+
+			if got := tracing.Enabled(); got != tt.expected {
+				t.Errorf("Enabled() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
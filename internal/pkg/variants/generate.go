@@ -0,0 +1,206 @@
+package variants
+
+import (
+	"strings"
+)
+
+// idnaPrefixEnd is the end of the "xn--" style prefix whose third and fourth characters
+// may not be hyphens in an ordinary label.
+const idnaPrefixEnd = 4
+
+// Techniques name how a variant was derived from the original domain.
+const (
+	TechniqueOmission    = "omission"
+	TechniqueAdjacentKey = "adjacent-key"
+	TechniqueHomoglyph   = "homoglyph"
+	TechniqueTLDSwap     = "tld-swap"
+	TechniqueHyphen      = "hyphen"
+)
+
+// Variant is a generated look-alike of a domain.
+type Variant struct {
+	// Domain is the variant domain name
+	Domain string
+	// Technique is how the variant was derived, e.g. omission
+	Technique string
+}
+
+// adjacentKeys maps each key to its neighbours on a QWERTY keyboard.
+//
+//nolint:gochecknoglobals
+var adjacentKeys = map[rune]string{
+	'1': "2q", '2': "13qw", '3': "24we", '4': "35er", '5': "46rt",
+	'6': "57ty", '7': "68yu", '8': "79ui", '9': "80io", '0': "9op",
+	'q': "12wa", 'w': "23qeas", 'e': "34wrsd", 'r': "45etdf", 't': "56ryfg",
+	'y': "67tugh", 'u': "78yihj", 'i': "89uojk", 'o': "90ipkl", 'p': "0ol",
+	'a': "qwsz", 's': "weadzx", 'd': "ersfxc", 'f': "rtdgcv", 'g': "tyfhvb",
+	'h': "yugjbn", 'j': "uihknm", 'k': "iojlm", 'l': "opk",
+	'z': "asx", 'x': "sdzc", 'c': "dfxv", 'v': "fgcb", 'b': "ghvn", 'n': "hjbm", 'm': "jkn",
+}
+
+// homoglyphs maps character sequences to ASCII look-alikes that survive in a hostname.
+//
+//nolint:gochecknoglobals
+var homoglyphs = map[string][]string{
+	"o":  {"0"},
+	"0":  {"o"},
+	"l":  {"1", "i"},
+	"i":  {"1", "l"},
+	"1":  {"l", "i"},
+	"e":  {"3"},
+	"a":  {"4"},
+	"s":  {"5"},
+	"b":  {"6"},
+	"g":  {"9", "q"},
+	"q":  {"g"},
+	"m":  {"rn"},
+	"rn": {"m"},
+	"w":  {"vv"},
+	"vv": {"w"},
+	"d":  {"cl"},
+	"cl": {"d"},
+}
+
+// Generate returns up to limit typosquat variants of domain: character omissions,
+// adjacent-key substitutions, homoglyphs, added hyphens on the second-level name, and
+// the same name on each of tlds. Techniques are interleaved so a small limit still
+// covers each of them. The original domain and duplicates are left out, as are
+// variants that aren't valid hostnames. A limit of zero or less keeps every variant.
+func Generate(domain string, tlds []string, limit int) []Variant {
+	name, tld, _ := strings.Cut(strings.ToLower(domain), ".")
+	if name == "" {
+		return nil
+	}
+
+	withTLD := func(labels []string) []string {
+		domains := make([]string, 0, len(labels))
+		for _, label := range labels {
+			domains = append(domains, label+"."+tld)
+		}
+
+		return domains
+	}
+
+	swapped := make([]string, 0, len(tlds))
+	for _, other := range tlds {
+		swapped = append(swapped, name+"."+strings.TrimPrefix(strings.ToLower(other), "."))
+	}
+
+	groups := []struct {
+		technique string
+		domains   []string
+	}{
+		{TechniqueOmission, withTLD(omissions(name))},
+		{TechniqueAdjacentKey, withTLD(adjacentKeySwaps(name))},
+		{TechniqueHomoglyph, withTLD(homoglyphSwaps(name))},
+		{TechniqueTLDSwap, swapped},
+		{TechniqueHyphen, withTLD(hyphenations(name))},
+	}
+
+	seen := map[string]bool{name + "." + tld: true}
+
+	var variants []Variant
+
+	for i := 0; ; i++ {
+		remaining := false
+
+		for _, group := range groups {
+			if i >= len(group.domains) {
+				continue
+			}
+
+			remaining = true
+
+			candidate := group.domains[i]
+			if seen[candidate] || !validHostname(candidate) {
+				continue
+			}
+
+			seen[candidate] = true
+
+			variants = append(variants, Variant{Domain: candidate, Technique: group.technique})
+			if limit > 0 && len(variants) == limit {
+				return variants
+			}
+		}
+
+		if !remaining {
+			return variants
+		}
+	}
+}
+
+// omissions drops each character of name in turn.
+func omissions(name string) []string {
+	labels := make([]string, 0, len(name))
+	for i := range len(name) {
+		labels = append(labels, name[:i]+name[i+1:])
+	}
+
+	return labels
+}
+
+// adjacentKeySwaps replaces each character of name with each of its keyboard neighbours.
+func adjacentKeySwaps(name string) []string {
+	var labels []string
+
+	for i, char := range name {
+		for _, neighbour := range adjacentKeys[char] {
+			labels = append(labels, name[:i]+string(neighbour)+name[i+1:])
+		}
+	}
+
+	return labels
+}
+
+// homoglyphSwaps replaces each occurrence of a homoglyphs key with each of its look-alikes.
+func homoglyphSwaps(name string) []string {
+	var labels []string
+
+	for i := range len(name) {
+		for _, size := range []int{1, 2} {
+			if i+size > len(name) {
+				continue
+			}
+
+			for _, glyph := range homoglyphs[name[i:i+size]] {
+				labels = append(labels, name[:i]+glyph+name[i+size:])
+			}
+		}
+	}
+
+	return labels
+}
+
+// hyphenations inserts a hyphen between each pair of characters of name.
+func hyphenations(name string) []string {
+	labels := make([]string, 0, len(name))
+	for i := 1; i < len(name); i++ {
+		labels = append(labels, name[:i]+"-"+name[i:])
+	}
+
+	return labels
+}
+
+// validHostname reports whether every label of domain is non-empty, made of letters,
+// digits and hyphens, and neither starts nor ends with a hyphen or has one at the third
+// and fourth position, which is reserved for IDNA.
+func validHostname(domain string) bool {
+	for label := range strings.SplitSeq(domain, ".") {
+		if label == "" || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+
+		if len(label) >= idnaPrefixEnd && label[idnaPrefixEnd-2:idnaPrefixEnd] == "--" {
+			return false
+		}
+
+		for _, char := range label {
+			if (char < 'a' || char > 'z') && (char < '0' || char > '9') && char != '-' {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,124 @@
+// Package variants generates typosquat look-alikes of a domain and checks which of them
+// are already registered, for brand protection: a taken variant is one to monitor for abuse.
+package variants
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/tool"
+)
+
+// ErrMissingDomain is returned when no domain with a TLD is provided.
+var ErrMissingDomain = errors.New("missing domain, e.g. example.com")
+
+// ParamsIn represents the input parameters for a variant check.
+type ParamsIn struct {
+	// Domain is the brand domain to generate variants of
+	Domain string `json:"domain" jsonschema:"The brand domain to protect, e.g. example.com"`
+	// MaxVariants caps the number of variants checked, up to the server maximum
+	MaxVariants int `json:"maxVariants,omitempty" jsonschema:"Maximum variants to check (default: server maximum)"`
+}
+
+// Result is the availability of one variant.
+type Result struct {
+	// Result is the availability result of the variant
+	Result namecheap.Result `json:"result" jsonschema:"The availability result of the variant"`
+	// Technique is how the variant was derived from the domain
+	Technique string `json:"technique" jsonschema:"omission, adjacent-key, homoglyph, tld-swap or hyphen"`
+	// Taken is true when the variant is registered and should be monitored
+	Taken bool `json:"taken" jsonschema:"True when the variant is registered"`
+}
+
+// ParamsOut represents the checked variants.
+type ParamsOut struct {
+	// Domain is the normalized brand domain
+	Domain string `json:"domain" jsonschema:"The brand domain the variants were generated from"`
+	// Variants are the checked variants, taken ones first
+	Variants []Result `json:"variants" jsonschema:"Checked variants, taken ones first"`
+	// TakenCount is the number of registered variants
+	TakenCount int `json:"takenCount" jsonschema:"Number of registered variants"`
+}
+
+// Service checks the typosquat variants of a domain.
+type Service struct {
+	checker     namecheap.DomainChecker
+	tlds        []string
+	maxVariants int
+}
+
+// NewService creates a variant service. tlds are the TLDs the name is swapped onto and
+// maxVariants caps how many variants a single call checks; zero or less means no cap.
+func NewService(checker namecheap.DomainChecker, tlds []string, maxVariants int) *Service {
+	return &Service{
+		checker:     checker,
+		tlds:        tlds,
+		maxVariants: maxVariants,
+	}
+}
+
+// Name returns the name of the variant service.
+func (s *Service) Name() string {
+	return "check_variants"
+}
+
+// Description returns a description of the variant service.
+func (s *Service) Description() string {
+	return "Generate typosquat variants of a brand domain (omitted characters, adjacent-key typos, " +
+		"homoglyphs, other TLDs, added hyphens) and flag the ones already registered"
+}
+
+// Execute generates the variants of the domain, checks them in a single check and returns
+// them with the taken ones first. Variants whose check failed are neither taken nor
+// available; their Result.Error says why.
+func (s *Service) Execute(ctx context.Context, in ParamsIn) (ParamsOut, error) {
+	domain := namecheap.NormalizeDomain(in.Domain)
+	if !strings.Contains(domain, ".") {
+		return ParamsOut{}, fmt.Errorf("%w: %w", tool.ErrInvalidInput, ErrMissingDomain)
+	}
+
+	limit := s.maxVariants
+	if in.MaxVariants > 0 && (limit <= 0 || in.MaxVariants < limit) {
+		limit = in.MaxVariants
+	}
+
+	variants := Generate(domain, s.tlds, limit)
+	if len(variants) == 0 {
+		return ParamsOut{Domain: domain, Variants: []Result{}, TakenCount: 0}, nil
+	}
+
+	domains := make([]string, 0, len(variants))
+	for _, variant := range variants {
+		domains = append(domains, variant.Domain)
+	}
+
+	results, err := s.checker.DomainsCheck(ctx, domains)
+	if err != nil && !namecheap.IsPartial(err) {
+		return ParamsOut{}, err
+	}
+
+	// The checker may answer in its own order or leave variants out.
+	results = namecheap.MatchResults(domains, results)
+
+	taken := make([]Result, 0, len(results))
+	rest := make([]Result, 0, len(results))
+
+	for i, result := range results {
+		checked := Result{
+			Result:    result,
+			Technique: variants[i].Technique,
+			Taken:     result.Error == "" && !result.Available,
+		}
+
+		if checked.Taken {
+			taken = append(taken, checked)
+		} else {
+			rest = append(rest, checked)
+		}
+	}
+
+	return ParamsOut{Domain: domain, Variants: append(taken, rest...), TakenCount: len(taken)}, nil
+}
@@ -0,0 +1,204 @@
+package variants_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap/namecheaptest"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/tool"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/variants"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		domain string
+		tlds   []string
+		limit  int
+		want   []variants.Variant
+	}{
+		{
+			name:   "techniques interleaved, duplicates skipped",
+			domain: "om.com",
+			tlds:   []string{"net", "com"},
+			limit:  8,
+			want: []variants.Variant{
+				{Domain: "m.com", Technique: variants.TechniqueOmission},
+				{Domain: "9m.com", Technique: variants.TechniqueAdjacentKey},
+				{Domain: "0m.com", Technique: variants.TechniqueHomoglyph},
+				{Domain: "om.net", Technique: variants.TechniqueTLDSwap},
+				{Domain: "o-m.com", Technique: variants.TechniqueHyphen},
+				{Domain: "o.com", Technique: variants.TechniqueOmission},
+				{Domain: "orn.com", Technique: variants.TechniqueHomoglyph},
+				{Domain: "im.com", Technique: variants.TechniqueAdjacentKey},
+			},
+		},
+		{
+			name:   "limit",
+			domain: "Ab.io",
+			tlds:   nil,
+			limit:  3,
+			want: []variants.Variant{
+				{Domain: "b.io", Technique: variants.TechniqueOmission},
+				{Domain: "qb.io", Technique: variants.TechniqueAdjacentKey},
+				{Domain: "4b.io", Technique: variants.TechniqueHomoglyph},
+			},
+		},
+		{
+			name:   "invalid hostnames dropped",
+			domain: "a.com",
+			tlds:   nil,
+			limit:  3,
+			want: []variants.Variant{
+				{Domain: "q.com", Technique: variants.TechniqueAdjacentKey},
+				{Domain: "4.com", Technique: variants.TechniqueHomoglyph},
+				{Domain: "w.com", Technique: variants.TechniqueAdjacentKey},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := variants.Generate(tt.domain, tt.tlds, tt.limit)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Generate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_Execute(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		maxVariants int
+		in          variants.ParamsIn
+		wantChecked int
+		wantTaken   []string
+	}{
+		{
+			name:        "server maximum",
+			maxVariants: 5,
+			in:          variants.ParamsIn{Domain: "https://OM.com/", MaxVariants: 0},
+			wantChecked: 5,
+			wantTaken:   []string{"0m.com", "om.net"},
+		},
+		{
+			name:        "caller limit below the maximum",
+			maxVariants: 5,
+			in:          variants.ParamsIn{Domain: "om.com", MaxVariants: 3},
+			wantChecked: 3,
+			wantTaken:   []string{"0m.com"},
+		},
+		{
+			name:        "caller limit above the maximum",
+			maxVariants: 2,
+			in:          variants.ParamsIn{Domain: "om.com", MaxVariants: 10},
+			wantChecked: 2,
+			wantTaken:   []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			checker := &namecheaptest.Checker{Answers: map[string]namecheap.Result{ //nolint:exhaustruct
+				"0m.com": namecheaptest.Taken("0m.com"),
+				"om.net": namecheaptest.Taken("om.net"),
+			}}
+			service := variants.NewService(checker, []string{"net"}, tt.maxVariants)
+
+			out, err := service.Execute(context.Background(), tt.in)
+			if err != nil {
+				t.Fatalf("Execute() unexpected error: %v", err)
+			}
+
+			if len(checker.Checked()) != tt.wantChecked {
+				t.Errorf("checked %d variants, want %d", len(checker.Checked()), tt.wantChecked)
+			}
+
+			if out.Domain != "om.com" {
+				t.Errorf("Domain = %q, want %q", out.Domain, "om.com")
+			}
+
+			taken := []string{}
+			for _, variant := range out.Variants[:out.TakenCount] {
+				if !variant.Taken {
+					t.Errorf("variant %s listed before the untaken ones but not taken", variant.Result.Domain)
+				}
+
+				taken = append(taken, variant.Result.Domain)
+			}
+
+			if !reflect.DeepEqual(taken, tt.wantTaken) {
+				t.Errorf("taken = %v, want %v", taken, tt.wantTaken)
+			}
+
+			for _, variant := range out.Variants[out.TakenCount:] {
+				if variant.Taken {
+					t.Errorf("variant %s taken but listed after the untaken ones", variant.Result.Domain)
+				}
+			}
+		})
+	}
+}
+
+func TestService_MissingDomain(t *testing.T) {
+	t.Parallel()
+
+	service := variants.NewService(&namecheaptest.Checker{}, nil, 0) //nolint:exhaustruct
+
+	_, err := service.Execute(context.Background(), variants.ParamsIn{Domain: "example", MaxVariants: 0})
+	if !errors.Is(err, tool.ErrInvalidInput) || !errors.Is(err, variants.ErrMissingDomain) {
+		t.Errorf("Execute() error = %v, want %v", err, variants.ErrMissingDomain)
+	}
+}
+
+// reversedChecker answers in reverse request order, like a backend answering in its own.
+type reversedChecker struct {
+	namecheaptest.Checker
+}
+
+func (r *reversedChecker) DomainsCheck(ctx context.Context, domains []string) ([]namecheap.Result, error) {
+	results, err := r.Checker.DomainsCheck(ctx, domains)
+	slices.Reverse(results)
+
+	return results, err
+}
+
+func TestService_Execute_MatchesTechniquesByDomain(t *testing.T) {
+	t.Parallel()
+
+	tlds := []string{"net"}
+	service := variants.NewService(&reversedChecker{}, tlds, 0) //nolint:exhaustruct
+
+	out, err := service.Execute(context.Background(), variants.ParamsIn{Domain: "acme.com", MaxVariants: 0})
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+
+	want := make(map[string]string)
+	for _, variant := range variants.Generate("acme.com", tlds, 0) {
+		want[variant.Domain] = variant.Technique
+	}
+
+	if len(out.Variants) != len(want) {
+		t.Fatalf("got %d variants, want %d", len(out.Variants), len(want))
+	}
+
+	for _, variant := range out.Variants {
+		if variant.Technique != want[variant.Result.Domain] {
+			t.Errorf("%s: Technique = %q, want %q", variant.Result.Domain, variant.Technique, want[variant.Result.Domain])
+		}
+	}
+}
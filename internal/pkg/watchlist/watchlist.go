@@ -0,0 +1,256 @@
+// Package watchlist tracks the availability of a fixed list of domains over time. A
+// Scheduler checks the list on an interval and appends a Snapshot per domain to a
+// pluggable Store, which HistoryService exposes as an MCP tool.
+package watchlist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/tool"
+	"go.uber.org/zap"
+)
+
+// defaultInterval is the time between checks when Config.Interval is unset.
+const defaultInterval = time.Hour
+
+// ErrMissingDomain is returned when no domain is provided for a history query.
+var ErrMissingDomain = errors.New("missing domain")
+
+// Snapshot is the availability of a domain at one check.
+type Snapshot struct {
+	// Domain is the normalized domain name
+	Domain string `json:"domain" jsonschema:"The domain that was checked"`
+	// Available indicates if the domain was available for registration
+	Available bool `json:"available" jsonschema:"Whether the domain was available"`
+	// Availability is available, taken, or unknown when the check failed
+	Availability string `json:"availability" jsonschema:"available, taken, or unknown when the check failed"`
+	// Error is the reason the check failed, if it did
+	Error string `json:"error,omitempty" jsonschema:"Error message if the check failed"`
+	// Timestamp is when the check was made
+	Timestamp time.Time `json:"timestamp" jsonschema:"When the domain was checked"`
+}
+
+// Store persists snapshots and returns them per domain.
+type Store interface {
+	// Append stores the given snapshots.
+	Append(snapshots ...Snapshot) error
+	// History returns the snapshots of domain in the order they were appended.
+	History(domain string) ([]Snapshot, error)
+}
+
+// MemoryStore is a Store that keeps snapshots in memory for the lifetime of the process.
+type MemoryStore struct {
+	mu        sync.RWMutex
+	snapshots map[string][]Snapshot
+}
+
+// NewMemoryStore creates an empty in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		mu:        sync.RWMutex{},
+		snapshots: make(map[string][]Snapshot),
+	}
+}
+
+// Append stores the given snapshots.
+func (m *MemoryStore) Append(snapshots ...Snapshot) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, snapshot := range snapshots {
+		m.snapshots[snapshot.Domain] = append(m.snapshots[snapshot.Domain], snapshot)
+	}
+
+	return nil
+}
+
+// History returns a copy of the snapshots recorded for domain.
+func (m *MemoryStore) History(domain string) ([]Snapshot, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return append([]Snapshot(nil), m.snapshots[namecheap.NormalizeDomain(domain)]...), nil
+}
+
+// Clock is the time source of a Scheduler, replaceable in tests.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// Ticker returns a channel delivering a tick every d, and a function stopping it.
+	Ticker(d time.Duration) (<-chan time.Time, func())
+}
+
+// realClock is the Clock backed by the time package.
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) Ticker(d time.Duration) (<-chan time.Time, func()) {
+	ticker := time.NewTicker(d)
+
+	return ticker.C, ticker.Stop
+}
+
+// Config holds the configuration of a Scheduler.
+type Config struct {
+	// Domains is the watchlist
+	Domains []string
+	// Interval is the time between checks; zero or less uses one hour
+	Interval time.Duration
+	// Clock is the time source; nil uses the system clock
+	Clock Clock
+}
+
+// Scheduler checks the watchlist on an interval and records the results.
+type Scheduler struct {
+	logger  *zap.Logger
+	checker namecheap.DomainChecker
+	store   Store
+	config  Config
+}
+
+// NewScheduler creates a scheduler that checks config.Domains with checker and appends
+// the snapshots to store.
+func NewScheduler(logger *zap.Logger, checker namecheap.DomainChecker, store Store, config Config) *Scheduler {
+	domains := make([]string, 0, len(config.Domains))
+	for _, domain := range config.Domains {
+		if domain = namecheap.NormalizeDomain(domain); domain != "" {
+			domains = append(domains, domain)
+		}
+	}
+
+	config.Domains = domains
+
+	if config.Interval <= 0 {
+		config.Interval = defaultInterval
+	}
+
+	if config.Clock == nil {
+		config.Clock = realClock{}
+	}
+
+	return &Scheduler{
+		logger:  logger,
+		checker: checker,
+		store:   store,
+		config:  config,
+	}
+}
+
+// Run checks the watchlist straight away and then on every interval until ctx is done,
+// which also cancels a check in progress. It returns once the scheduler has stopped.
+func (s *Scheduler) Run(ctx context.Context) {
+	if len(s.config.Domains) == 0 {
+		return
+	}
+
+	s.logger.Info("Watchlist scheduler started",
+		zap.Int("domain_count", len(s.config.Domains)),
+		zap.Duration("interval", s.config.Interval),
+	)
+
+	ticks, stop := s.config.Clock.Ticker(s.config.Interval)
+	defer stop()
+
+	s.snapshot(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("Watchlist scheduler stopped")
+
+			return
+		case <-ticks:
+			s.snapshot(ctx)
+		}
+	}
+}
+
+// snapshot checks the watchlist once and appends the results to the store. Failures
+// are logged; the next tick tries again.
+func (s *Scheduler) snapshot(ctx context.Context) {
+	results, err := s.checker.DomainsCheck(ctx, s.config.Domains)
+	if err != nil && !namecheap.IsPartial(err) {
+		if ctx.Err() == nil {
+			s.logger.Warn("Watchlist check failed", zap.Error(err))
+		}
+
+		return
+	}
+
+	now := s.config.Clock.Now().UTC()
+	snapshots := make([]Snapshot, 0, len(results))
+
+	for _, result := range results {
+		snapshots = append(snapshots, Snapshot{
+			Domain:       namecheap.NormalizeDomain(result.Domain),
+			Available:    result.Available,
+			Availability: result.Availability,
+			Error:        result.Error,
+			Timestamp:    now,
+		})
+	}
+
+	err = s.store.Append(snapshots...)
+	if err != nil {
+		s.logger.Warn("Failed to record watchlist snapshots", zap.Error(err))
+	}
+}
+
+// ParamsIn represents the input parameters for a watchlist history query.
+type ParamsIn struct {
+	// Domain is the watched domain to return snapshots for
+	Domain string `json:"domain" jsonschema:"The watched domain to return snapshots for, e.g. example.com"`
+}
+
+// ParamsOut represents the recorded availability history of a domain.
+type ParamsOut struct {
+	// Snapshots are the recorded checks, oldest first
+	Snapshots []Snapshot `json:"snapshots" jsonschema:"Recorded availability checks, oldest first"`
+}
+
+// HistoryService exposes the watchlist snapshots as an MCP tool.
+type HistoryService struct {
+	store Store
+}
+
+// NewHistoryService creates a tool service that reads snapshots from store.
+func NewHistoryService(store Store) *HistoryService {
+	return &HistoryService{
+		store: store,
+	}
+}
+
+// Name returns the name of the watchlist history service.
+func (h *HistoryService) Name() string {
+	return "watchlist_history"
+}
+
+// Description returns a description of the watchlist history service.
+func (h *HistoryService) Description() string {
+	return "Return the availability snapshots recorded for a watched domain by the periodic watchlist checks, " +
+		"oldest first"
+}
+
+// Execute returns the recorded snapshots for the requested domain.
+func (h *HistoryService) Execute(_ context.Context, in ParamsIn) (ParamsOut, error) {
+	domain := strings.TrimSpace(in.Domain)
+	if domain == "" {
+		return ParamsOut{}, fmt.Errorf("%w: %w", tool.ErrInvalidInput, ErrMissingDomain)
+	}
+
+	snapshots, err := h.store.History(domain)
+	if err != nil {
+		return ParamsOut{}, err
+	}
+
+	return ParamsOut{Snapshots: snapshots}, nil
+}
@@ -0,0 +1,167 @@
+package watchlist_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap/namecheaptest"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/tool"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/watchlist"
+	"go.uber.org/zap"
+)
+
+// fakeClock advances one minute on every Now call and ticks when the test says so.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	ticks   chan time.Time
+	stopped chan struct{}
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{
+		mu:      sync.Mutex{},
+		now:     time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC),
+		ticks:   make(chan time.Time),
+		stopped: make(chan struct{}),
+	}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(time.Minute)
+
+	return c.now
+}
+
+func (c *fakeClock) Ticker(_ time.Duration) (<-chan time.Time, func()) {
+	return c.ticks, func() { close(c.stopped) }
+}
+
+// notifyingStore signals every Append so the test knows when a check has been recorded.
+type notifyingStore struct {
+	*watchlist.MemoryStore
+
+	appended chan struct{}
+}
+
+func (n *notifyingStore) Append(snapshots ...watchlist.Snapshot) error {
+	err := n.MemoryStore.Append(snapshots...)
+	n.appended <- struct{}{}
+
+	return err
+}
+
+//nolint:cyclop
+func TestScheduler_SnapshotsAccumulateOverTicks(t *testing.T) {
+	t.Parallel()
+
+	const ticks = 3
+
+	clock := newFakeClock()
+	store := &notifyingStore{MemoryStore: watchlist.NewMemoryStore(), appended: make(chan struct{})}
+
+	// Every domain is available on odd checks and taken on even ones.
+	checker := &namecheaptest.Checker{} //nolint:exhaustruct
+	checker.Answer = func(domain string) namecheap.Result {
+		return namecheaptest.Result(domain, checker.Calls()%2 == 1)
+	}
+
+	scheduler := watchlist.NewScheduler(zap.NewNop(), checker, store,
+		watchlist.Config{
+			Domains:  []string{"Example.com", "example.org."},
+			Interval: time.Minute,
+			Clock:    clock,
+		})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		scheduler.Run(ctx)
+		close(done)
+	}()
+
+	// The first check happens straight away, then one per tick.
+	<-store.appended
+
+	for range ticks {
+		clock.ticks <- time.Time{}
+
+		<-store.appended
+	}
+
+	cancel()
+	<-done
+
+	select {
+	case <-clock.stopped:
+	default:
+		t.Error("Run() returned without stopping the ticker")
+	}
+
+	for _, domain := range []string{"example.com", "example.org"} {
+		history, err := store.History(domain)
+		if err != nil {
+			t.Fatalf("History(%q) unexpected error: %v", domain, err)
+		}
+
+		if len(history) != ticks+1 {
+			t.Fatalf("History(%q) has %d snapshots, want %d", domain, len(history), ticks+1)
+		}
+
+		for i, snapshot := range history {
+			if snapshot.Domain != domain {
+				t.Errorf("snapshot %d Domain = %q, want %q", i, snapshot.Domain, domain)
+			}
+
+			if want := i%2 == 0; snapshot.Available != want {
+				t.Errorf("snapshot %d Available = %v, want %v", i, snapshot.Available, want)
+			}
+
+			if i > 0 && !snapshot.Timestamp.After(history[i-1].Timestamp) {
+				t.Errorf("snapshot %d Timestamp = %v, not after %v", i, snapshot.Timestamp, history[i-1].Timestamp)
+			}
+		}
+	}
+}
+
+func TestHistoryService_Execute(t *testing.T) {
+	t.Parallel()
+
+	store := watchlist.NewMemoryStore()
+	snapshot := watchlist.Snapshot{
+		Domain:       "example.com",
+		Available:    true,
+		Availability: namecheap.AvailabilityAvailable,
+		Error:        "",
+		Timestamp:    time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	err := store.Append(snapshot)
+	if err != nil {
+		t.Fatalf("Append() unexpected error: %v", err)
+	}
+
+	service := watchlist.NewHistoryService(store)
+
+	out, err := service.Execute(context.Background(), watchlist.ParamsIn{Domain: "EXAMPLE.com"})
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+
+	if len(out.Snapshots) != 1 || out.Snapshots[0] != snapshot {
+		t.Errorf("Snapshots = %+v, want [%+v]", out.Snapshots, snapshot)
+	}
+
+	_, err = service.Execute(context.Background(), watchlist.ParamsIn{Domain: " "})
+	if !errors.Is(err, watchlist.ErrMissingDomain) || !errors.Is(err, tool.ErrInvalidInput) {
+		t.Errorf("Execute() error = %v, want %v", err, watchlist.ErrMissingDomain)
+	}
+}
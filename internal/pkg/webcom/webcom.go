@@ -0,0 +1,280 @@
+// Package webcom provides domain availability checking through the Web.com family reseller
+// API (Web.com, Domain.com, Network Solutions). Its Service implements
+// namecheap.DomainChecker, so it plugs into the same tools and decorators as the other
+// providers.
+package webcom
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/limit"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/metrics"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/requestid"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/retry"
+	"go.uber.org/zap"
+)
+
+const (
+	// metricsProvider labels the metrics of this backend.
+	metricsProvider = "webcom"
+
+	// defaultRequestTimeout is the per-request HTTP timeout when Config.RequestTimeout is unset.
+	defaultRequestTimeout = 30 * time.Second
+	// retryBackoff is the delay before the first retry.
+	retryBackoff = 250 * time.Millisecond
+
+	// availabilityPath is the bulk availability endpoint, relative to Config.Endpoint.
+	availabilityPath = "domains/availability"
+	// apiKeyHeader carries the reseller API key.
+	apiKeyHeader = "X-Api-Key"
+)
+
+var (
+	// ErrMissingAPIKey is returned when the API key is missing.
+	ErrMissingAPIKey = errors.New("missing Web.com API key")
+	// ErrMissingEndpoint is returned when the reseller API endpoint is missing.
+	ErrMissingEndpoint = errors.New("missing Web.com API endpoint")
+	// ErrUnexpectedStatus is returned when the API answers with a non-200 HTTP status.
+	ErrUnexpectedStatus = errors.New("unexpected Web.com API status")
+	// ErrNotInResponse is reported per domain when it is in neither response list.
+	ErrNotInResponse = errors.New("domain missing from Web.com response")
+)
+
+// Config holds the configuration for the Web.com reseller API client.
+type Config struct {
+	// APIKey is the reseller API key, sent in the X-Api-Key header
+	APIKey string
+	// Endpoint is the base URL of the reseller API, as given in the reseller account
+	Endpoint string
+	// RequestTimeout bounds each API request; zero uses a 30 second default
+	RequestTimeout time.Duration
+	// MaxRetries is how many times a transient network failure is retried; zero disables retries
+	MaxRetries int
+	// RateLimitPerSecond caps outbound API requests per second; zero or less means unlimited
+	RateLimitPerSecond float64
+}
+
+// availabilityRequest is the body of an availability request.
+type availabilityRequest struct {
+	Domains []string `json:"domains"`
+}
+
+// AvailabilityResponse is the answer of the availability endpoint. Available and taken
+// domains come back in separate lists.
+type AvailabilityResponse struct {
+	Available []AvailableDomain `json:"available"`
+	Taken     []string          `json:"taken"`
+}
+
+// AvailableDomain is an entry of the available list with its one-year quotes.
+type AvailableDomain struct {
+	Domain       string  `json:"domain"`
+	Premium      bool    `json:"premium"`
+	Price        float64 `json:"price"`
+	RenewalPrice float64 `json:"renewalPrice"`
+	Currency     string  `json:"currency"`
+}
+
+// Service checks domain availability using the Web.com reseller API.
+type Service struct {
+	logger *zap.Logger
+	config Config
+	client *http.Client
+}
+
+// NewService creates a new Web.com service with the given configuration.
+// Returns ErrMissingAPIKey or ErrMissingEndpoint if either is missing.
+func NewService(logger *zap.Logger, config Config) (*Service, error) {
+	if config.APIKey == "" {
+		return nil, ErrMissingAPIKey
+	}
+
+	if config.Endpoint == "" {
+		return nil, ErrMissingEndpoint
+	}
+
+	timeout := config.RequestTimeout
+	if timeout <= 0 {
+		timeout = defaultRequestTimeout
+	}
+
+	return &Service{
+		logger: logger,
+		config: config,
+		client: &http.Client{ //nolint:exhaustruct
+			Timeout: timeout,
+			Transport: retry.NewTransport(
+				limit.NewTransport(http.DefaultTransport, config.RateLimitPerSecond), config.MaxRetries, retryBackoff),
+		},
+	}, nil
+}
+
+// Name returns the name of the Web.com service.
+func (s *Service) Name() string {
+	return "check_availability_webcom"
+}
+
+// Description returns a description of the Web.com service.
+func (s *Service) Description() string {
+	return "Check domain availability using the Web.com reseller API"
+}
+
+// DomainsCheck checks all domains in one availability request and returns the results in
+// input order. A domain the API leaves out of both lists is reported in its Result.Error.
+func (s *Service) DomainsCheck(ctx context.Context, domains []string) ([]namecheap.Result, error) {
+	if len(domains) == 0 {
+		return nil, namecheap.ErrMissingDomains
+	}
+
+	metrics.DomainsCheck(metricsProvider, len(domains))
+
+	resp, err := s.availability(ctx, domains)
+	if err != nil {
+		return nil, err
+	}
+
+	available := make(map[string]AvailableDomain, len(resp.Available))
+	for _, entry := range resp.Available {
+		available[strings.ToLower(entry.Domain)] = entry
+	}
+
+	taken := make(map[string]struct{}, len(resp.Taken))
+	for _, domain := range resp.Taken {
+		taken[strings.ToLower(domain)] = struct{}{}
+	}
+
+	results := make([]namecheap.Result, 0, len(domains))
+
+	for _, domain := range domains {
+		key := strings.ToLower(domain)
+
+		if entry, ok := available[key]; ok {
+			results = append(results, availableResult(domain, entry))
+
+			continue
+		}
+
+		result := namecheap.Result{ //nolint:exhaustruct
+			Domain:       domain,
+			Availability: namecheap.AvailabilityTaken,
+			Status:       namecheap.StatusRegistered,
+		}
+
+		if _, ok := taken[key]; !ok {
+			result.Availability = namecheap.AvailabilityUnknown
+			result.Status = namecheap.StatusError
+			result.Error = ErrNotInResponse.Error()
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// VerifyCredentials makes a single availability check and returns the API error when
+// the key is rejected.
+func (s *Service) VerifyCredentials(ctx context.Context) error {
+	_, err := s.availability(ctx, []string{"web.com"})
+	if err != nil {
+		return fmt.Errorf("failed to verify credentials: %w", err)
+	}
+
+	return nil
+}
+
+// availableResult maps an available entry to a Result. The quote of a premium name is its
+// premium price; any other name's quote is the standard price of its TLD.
+func availableResult(domain string, entry AvailableDomain) namecheap.Result {
+	result := namecheap.Result{
+		Domain:                    domain,
+		Available:                 true,
+		Availability:              namecheap.AvailabilityAvailable,
+		Status:                    "",
+		IsPremiumName:             entry.Premium,
+		PremiumRegistrationPrice:  0,
+		PremiumRenewalPrice:       0,
+		IcannFee:                  0,
+		EapFee:                    0,
+		StandardRegistrationPrice: 0,
+		Currency:                  "",
+		Error:                     "",
+		ErrorCode:                 0,
+		RegisterURL:               "",
+		Blocked:                   false,
+		BlockReason:               "",
+		CorrelationID:             "",
+		Input:                     "",
+		ReferencePrice:            0,
+		ReferencePriceDelta:       0,
+		Raw:                       nil,
+	}
+
+	if entry.Premium {
+		result.PremiumRegistrationPrice = entry.Price
+		result.PremiumRenewalPrice = entry.RenewalPrice
+	} else {
+		result.StandardRegistrationPrice = entry.Price
+	}
+
+	if entry.Price > 0 {
+		result.Currency = entry.Currency
+	}
+
+	result.Status = namecheap.StatusOf(result)
+
+	return result
+}
+
+// availability POSTs the domains to the availability endpoint and decodes the answer.
+func (s *Service) availability(ctx context.Context, domains []string) (AvailabilityResponse, error) {
+	body, err := json.Marshal(availabilityRequest{Domains: domains})
+	if err != nil {
+		return AvailabilityResponse{}, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	reqURL := strings.TrimSuffix(s.config.Endpoint, "/") + "/" + availabilityPath
+
+	s.logger.Debug("Making Web.com API call", zap.Int("domain_count", len(domains)), requestid.Field(ctx))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL, bytes.NewReader(body))
+	if err != nil {
+		return AvailabilityResponse{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(apiKeyHeader, s.config.APIKey)
+
+	start := time.Now()
+	resp, err := s.client.Do(req)
+	metrics.UpstreamRequest(metricsProvider, time.Since(start), resp, err)
+
+	if err != nil {
+		return AvailabilityResponse{}, fmt.Errorf("HTTP request failed: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return AvailabilityResponse{}, fmt.Errorf("%w: %d", ErrUnexpectedStatus, resp.StatusCode)
+	}
+
+	var availability AvailabilityResponse
+
+	err = json.NewDecoder(resp.Body).Decode(&availability)
+	if err != nil {
+		return AvailabilityResponse{}, fmt.Errorf("failed to decode JSON response: %w", err)
+	}
+
+	return availability, nil
+}
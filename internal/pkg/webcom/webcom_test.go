@@ -0,0 +1,172 @@
+package webcom_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/webcom"
+	"go.uber.org/zap"
+)
+
+func newTestService(t *testing.T, apiKey string) *webcom.Service {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/domains/availability" {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		if r.Header.Get("X-Api-Key") != "reseller-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		var body struct {
+			Domains []string `json:"domains"`
+		}
+
+		err := json.NewDecoder(r.Body).Decode(&body)
+		if err != nil || len(body.Domains) == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{
+			"available": [
+				{"domain": "fresh.com", "premium": false, "price": 11.99, "renewalPrice": 13.99, "currency": "USD"},
+				{"domain": "LUXURY.IO", "premium": true, "price": 2500, "renewalPrice": 45, "currency": "USD"}
+			],
+			"taken": ["taken.com"]
+		}`))
+	}))
+	t.Cleanup(server.Close)
+
+	service, err := webcom.NewService(zap.NewNop(), webcom.Config{
+		APIKey:             apiKey,
+		Endpoint:           server.URL + "/v1/",
+		RequestTimeout:     0,
+		MaxRetries:         0,
+		RateLimitPerSecond: 0,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+
+	return service
+}
+
+func TestNewService_MissingConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		config  webcom.Config
+		wantErr error
+	}{
+		{
+			name: "missing API key",
+			config: webcom.Config{APIKey: "", Endpoint: "https://reseller.example", RequestTimeout: 0, MaxRetries: 0,
+				RateLimitPerSecond: 0},
+			wantErr: webcom.ErrMissingAPIKey,
+		},
+		{
+			name: "missing endpoint",
+			config: webcom.Config{APIKey: "reseller-key", Endpoint: "", RequestTimeout: 0, MaxRetries: 0,
+				RateLimitPerSecond: 0},
+			wantErr: webcom.ErrMissingEndpoint,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := webcom.NewService(zap.NewNop(), tt.config)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewService() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDomainsCheck(t *testing.T) {
+	t.Parallel()
+
+	service := newTestService(t, "reseller-key")
+
+	results, err := service.DomainsCheck(context.Background(), []string{"fresh.com", "taken.com", "luxury.io", "lost.net"})
+	if err != nil {
+		t.Fatalf("DomainsCheck() unexpected error: %v", err)
+	}
+
+	want := []namecheap.Result{
+		{
+			Domain: "fresh.com", Available: true, Availability: namecheap.AvailabilityAvailable,
+			Status: namecheap.StatusAvailable, IsPremiumName: false,
+			PremiumRegistrationPrice: 0, PremiumRenewalPrice: 0, IcannFee: 0, EapFee: 0,
+			StandardRegistrationPrice: 11.99, Currency: "USD",
+			Error: "", ErrorCode: 0, RegisterURL: "", Blocked: false, BlockReason: "", CorrelationID: "", Input: "",
+			ReferencePrice: 0, ReferencePriceDelta: 0, Raw: nil,
+		},
+		{
+			Domain: "taken.com", Available: false, Availability: namecheap.AvailabilityTaken,
+			Status: namecheap.StatusRegistered, IsPremiumName: false,
+			PremiumRegistrationPrice: 0, PremiumRenewalPrice: 0, IcannFee: 0, EapFee: 0, Currency: "",
+			Error: "", ErrorCode: 0, RegisterURL: "", Blocked: false, BlockReason: "", CorrelationID: "", Input: "",
+			ReferencePrice: 0, ReferencePriceDelta: 0, Raw: nil,
+		},
+		{
+			Domain: "luxury.io", Available: true, Availability: namecheap.AvailabilityAvailable,
+			Status: namecheap.StatusPremium, IsPremiumName: true,
+			PremiumRegistrationPrice: 2500, PremiumRenewalPrice: 45, IcannFee: 0, EapFee: 0, Currency: "USD",
+			Error: "", ErrorCode: 0, RegisterURL: "", Blocked: false, BlockReason: "", CorrelationID: "", Input: "",
+			ReferencePrice: 0, ReferencePriceDelta: 0, Raw: nil,
+		},
+		{
+			Domain: "lost.net", Available: false, Availability: namecheap.AvailabilityUnknown,
+			Status: namecheap.StatusError, IsPremiumName: false,
+			PremiumRegistrationPrice: 0, PremiumRenewalPrice: 0, IcannFee: 0, EapFee: 0, Currency: "",
+			Error: webcom.ErrNotInResponse.Error(), ErrorCode: 0, RegisterURL: "", Blocked: false, BlockReason: "",
+			CorrelationID: "", Input: "", ReferencePrice: 0, ReferencePriceDelta: 0, Raw: nil,
+		},
+	}
+
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("DomainsCheck() = %+v, want %+v", results, want)
+	}
+}
+
+func TestDomainsCheck_Unauthorized(t *testing.T) {
+	t.Parallel()
+
+	service := newTestService(t, "wrong-key")
+
+	_, err := service.DomainsCheck(context.Background(), []string{"fresh.com"})
+	if !errors.Is(err, webcom.ErrUnexpectedStatus) {
+		t.Errorf("DomainsCheck() error = %v, want %v", err, webcom.ErrUnexpectedStatus)
+	}
+}
+
+func TestVerifyCredentials(t *testing.T) {
+	t.Parallel()
+
+	err := newTestService(t, "reseller-key").VerifyCredentials(context.Background())
+	if err != nil {
+		t.Errorf("VerifyCredentials() unexpected error: %v", err)
+	}
+
+	err = newTestService(t, "wrong-key").VerifyCredentials(context.Background())
+	if !errors.Is(err, webcom.ErrUnexpectedStatus) {
+		t.Errorf("VerifyCredentials() error = %v, want %v", err, webcom.ErrUnexpectedStatus)
+	}
+}
@@ -0,0 +1,291 @@
+// Package whois provides availability checking over the WHOIS protocol (RFC 3912) for
+// TLDs whose registries don't offer RDAP. WHOIS answers are free text, so availability
+// is decided by matching the response against known "not registered" phrases.
+package whois
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/metrics"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"go.uber.org/zap"
+)
+
+const (
+	// metricsProvider labels the metrics of this backend.
+	metricsProvider = "whois"
+
+	// defaultPort is the well-known WHOIS port, used when a server has none.
+	defaultPort = "43"
+	// defaultTimeout bounds a query when Config.Timeout is unset.
+	defaultTimeout = 10 * time.Second
+	// maxResponseSize caps how much of a WHOIS response is read.
+	maxResponseSize = 1 << 20
+)
+
+var (
+	// ErrNoWhoisServer is reported per domain when no WHOIS server is known for its TLD.
+	ErrNoWhoisServer = errors.New("no WHOIS server for TLD")
+	// ErrEmptyResponse is reported per domain when the server closes without answering.
+	ErrEmptyResponse = errors.New("empty WHOIS response")
+)
+
+// Config holds the configuration for the WHOIS checker.
+type Config struct {
+	// Servers maps a TLD to its WHOIS server as "host" or "host:port" (port 43 by default).
+	// Entries are added to the built-in servers, replacing any for the same TLD.
+	Servers map[string]string
+	// AvailablePatterns are case-insensitive substrings; a response containing any of them
+	// means the domain isn't registered, any other response means it is. Empty uses the
+	// built-in set covering the common registries:
+	//
+	//	"No match for"                          Verisign (com, net)
+	//	"NOT FOUND"                             PIR (org), Afilias and most gTLD back ends
+	//	"Domain not found"                      Identity Digital (io, ai, me, ...)
+	//	"The queried object does not exist"     Google Registry (dev, app, ...)
+	//	"No Data Found"                         .co, .us and other Neustar/GoDaddy registries
+	//	"No entries found"                      AFNIC (fr) and several ccTLDs
+	//	"Status: free"                          DENIC (de)
+	//	"Status: AVAILABLE"                     EURid (eu)
+	//	"is free"                               SIDN (nl)
+	//	"This domain name has not been registered"  Nominet (uk)
+	AvailablePatterns []string
+	// Timeout bounds each query, from connecting to reading the last byte; zero uses 10 seconds
+	Timeout time.Duration
+}
+
+// defaultServers are the WHOIS servers of common TLDs.
+func defaultServers() map[string]string {
+	return map[string]string{
+		"com":  "whois.verisign-grs.com",
+		"net":  "whois.verisign-grs.com",
+		"org":  "whois.pir.org",
+		"info": "whois.nic.info",
+		"io":   "whois.nic.io",
+		"ai":   "whois.nic.ai",
+		"me":   "whois.nic.me",
+		"co":   "whois.nic.co",
+		"us":   "whois.nic.us",
+		"dev":  "whois.nic.google",
+		"app":  "whois.nic.google",
+		"de":   "whois.denic.de",
+		"eu":   "whois.eu",
+		"fr":   "whois.nic.fr",
+		"nl":   "whois.domain-registry.nl",
+		"uk":   "whois.nic.uk",
+	}
+}
+
+// defaultAvailablePatterns are the phrases documented on Config.AvailablePatterns.
+func defaultAvailablePatterns() []string {
+	return []string{
+		"No match for",
+		"NOT FOUND",
+		"Domain not found",
+		"The queried object does not exist",
+		"No Data Found",
+		"No entries found",
+		"Status: free",
+		"Status: AVAILABLE",
+		"is free",
+		"This domain name has not been registered",
+	}
+}
+
+// Service checks domain availability with WHOIS queries.
+type Service struct {
+	logger   *zap.Logger
+	servers  map[string]string
+	patterns []string
+	timeout  time.Duration
+}
+
+// NewService creates a WHOIS checker. It needs no credentials.
+func NewService(logger *zap.Logger, config Config) *Service {
+	servers := defaultServers()
+	for tld, server := range config.Servers {
+		servers[strings.ToLower(strings.TrimPrefix(tld, "."))] = server
+	}
+
+	patterns := config.AvailablePatterns
+	if len(patterns) == 0 {
+		patterns = defaultAvailablePatterns()
+	}
+
+	lowered := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		lowered = append(lowered, strings.ToLower(pattern))
+	}
+
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	return &Service{
+		logger:   logger,
+		servers:  servers,
+		patterns: lowered,
+		timeout:  timeout,
+	}
+}
+
+// Name returns the name of the WHOIS service.
+func (s *Service) Name() string {
+	return "check_availability_whois"
+}
+
+// Description returns a description of the WHOIS service.
+func (s *Service) Description() string {
+	return "Check whether domains are registered using WHOIS; no pricing information is returned"
+}
+
+// DomainsCheck queries the WHOIS server of every domain's TLD. Failed queries are reported
+// in that domain's Result.Error; only a cancelled ctx fails the whole check.
+func (s *Service) DomainsCheck(ctx context.Context, domains []string) ([]namecheap.Result, error) {
+	if len(domains) == 0 {
+		return nil, namecheap.ErrMissingDomains
+	}
+
+	metrics.DomainsCheck(metricsProvider, len(domains))
+
+	results := make([]namecheap.Result, 0, len(domains))
+
+	for _, domain := range domains {
+		result, err := s.check(ctx, domain)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+
+			result = namecheap.Result{ //nolint:exhaustruct
+				Domain:       domain,
+				Availability: namecheap.AvailabilityUnknown,
+				Status:       namecheap.StatusError,
+				Error:        err.Error(),
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func (s *Service) check(ctx context.Context, domain string) (namecheap.Result, error) {
+	name := strings.ToLower(strings.TrimSuffix(domain, "."))
+	tld := name[strings.LastIndex(name, ".")+1:]
+
+	server, ok := s.servers[tld]
+	if !ok {
+		return namecheap.Result{}, fmt.Errorf("%w %q", ErrNoWhoisServer, tld)
+	}
+
+	response, err := s.query(ctx, server, name)
+	if err != nil {
+		return namecheap.Result{}, err
+	}
+
+	available := s.matchesAvailable(response)
+
+	availability, status := namecheap.AvailabilityTaken, namecheap.StatusRegistered
+	if available {
+		availability, status = namecheap.AvailabilityAvailable, namecheap.StatusAvailable
+	}
+
+	return namecheap.Result{
+		Domain:                    domain,
+		Available:                 available,
+		Availability:              availability,
+		Status:                    status,
+		IsPremiumName:             false,
+		PremiumRegistrationPrice:  0,
+		PremiumRenewalPrice:       0,
+		IcannFee:                  0,
+		EapFee:                    0,
+		StandardRegistrationPrice: 0,
+		Currency:                  "",
+		Error:                     "",
+		ErrorCode:                 0,
+		RegisterURL:               "",
+		Blocked:                   false,
+		BlockReason:               "",
+		CorrelationID:             "",
+		Input:                     "",
+		ReferencePrice:            0,
+		ReferencePriceDelta:       0,
+		Raw:                       nil,
+	}, nil
+}
+
+// query sends name to server and returns the whole response.
+func (s *Service) query(ctx context.Context, server, name string) (string, error) {
+	addr := server
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		addr = net.JoinHostPort(server, defaultPort)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	dialer := net.Dialer{} //nolint:exhaustruct
+
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to WHOIS server: %w", err)
+	}
+
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	deadline, _ := ctx.Deadline()
+
+	err = conn.SetDeadline(deadline)
+	if err != nil {
+		return "", fmt.Errorf("failed to set WHOIS deadline: %w", err)
+	}
+
+	// Unblock the read below when ctx is cancelled before the deadline.
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.SetDeadline(time.Now())
+	})
+	defer stop()
+
+	_, err = io.WriteString(conn, name+"\r\n")
+	if err != nil {
+		return "", fmt.Errorf("failed to send WHOIS query: %w", err)
+	}
+
+	response, err := io.ReadAll(io.LimitReader(conn, maxResponseSize))
+	if err != nil {
+		return "", fmt.Errorf("failed to read WHOIS response: %w", err)
+	}
+
+	if len(strings.TrimSpace(string(response))) == 0 {
+		return "", ErrEmptyResponse
+	}
+
+	s.logger.Debug("WHOIS response", zap.String("domain", name), zap.Int("bytes", len(response)))
+
+	return string(response), nil
+}
+
+// matchesAvailable reports whether response contains one of the "not registered" patterns.
+func (s *Service) matchesAvailable(response string) bool {
+	response = strings.ToLower(response)
+
+	for _, pattern := range s.patterns {
+		if strings.Contains(response, pattern) {
+			return true
+		}
+	}
+
+	return false
+}
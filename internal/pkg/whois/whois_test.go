@@ -0,0 +1,94 @@
+package whois_test
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/namecheap"
+	"github.com/jsgv/mcp-domain-checker/internal/pkg/whois"
+	"go.uber.org/zap"
+)
+
+// newWhoisServer starts a WHOIS server answering each query with responses[domain].
+func newWhoisServer(t *testing.T, responses map[string]string) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			line, _ := bufio.NewReader(conn).ReadString('\n')
+			_, _ = conn.Write([]byte(responses[strings.TrimSpace(line)]))
+			_ = conn.Close()
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func TestDomainsCheck(t *testing.T) {
+	t.Parallel()
+
+	server := newWhoisServer(t, map[string]string{
+		"free.test":   "No match for \"FREE.TEST\".\r\n>>> Last update of whois database <<<\r\n",
+		"taken.test":  "Domain Name: TAKEN.TEST\r\nRegistrar: Example Registrar\r\n",
+		"custom.test": "Domain custom.test is up for grabs\r\n",
+		"silent.test": "",
+	})
+
+	tests := []struct {
+		name     string
+		patterns []string
+		domain   string
+		want     string
+		wantErr  string
+	}{
+		{name: "default pattern", patterns: nil, domain: "free.test", want: "available", wantErr: ""},
+		{name: "registered", patterns: nil, domain: "taken.test", want: "taken", wantErr: ""},
+		{name: "unmatched response", patterns: nil, domain: "custom.test", want: "taken", wantErr: ""},
+		{name: "custom pattern", patterns: []string{"UP FOR GRABS"}, domain: "custom.test", want: "available", wantErr: ""},
+		{name: "empty response", patterns: nil, domain: "silent.test", want: "unknown", wantErr: "empty WHOIS response"},
+		{name: "unknown TLD", patterns: nil, domain: "example.zz", want: "unknown", wantErr: "no WHOIS server"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			service := whois.NewService(zap.NewNop(), whois.Config{
+				Servers:           map[string]string{".TEST": server},
+				AvailablePatterns: tt.patterns,
+				Timeout:           0,
+			})
+
+			results, err := service.DomainsCheck(context.Background(), []string{tt.domain})
+			if err != nil {
+				t.Fatalf("DomainsCheck() unexpected error: %v", err)
+			}
+
+			got := results[0]
+			if got.Availability != tt.want || got.Available != (tt.want == namecheap.AvailabilityAvailable) {
+				t.Errorf("result = %+v, want availability %q", got, tt.want)
+			}
+
+			if !strings.Contains(got.Error, tt.wantErr) || (tt.wantErr == "") != (got.Error == "") {
+				t.Errorf("result.Error = %q, want it to contain %q", got.Error, tt.wantErr)
+			}
+		})
+	}
+}